@@ -0,0 +1,81 @@
+package database
+
+import (
+	"apiclient/backend/models"
+	"time"
+)
+
+// Certificate operations
+func CreateCertificate(certificate *models.Certificate) error {
+	query := `
+		INSERT INTO certificates (name, host_pattern, format, cert, key, passphrase, ca, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	var id int
+	var createdAt string
+	err := DB.QueryRow(query, certificate.Name, certificate.HostPattern, certificate.Format, certificate.Cert, certificate.Key, certificate.Passphrase, certificate.CA, certificate.Enabled).Scan(&id, &createdAt)
+	if err != nil {
+		return err
+	}
+
+	certificate.ID = id
+	certificate.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	return nil
+}
+
+func GetCertificates() ([]*models.Certificate, error) {
+	query := `SELECT id, name, host_pattern, format, cert, key, passphrase, ca, enabled, created_at FROM certificates ORDER BY name`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certificates []*models.Certificate
+	for rows.Next() {
+		var certificate models.Certificate
+		var createdAt string
+		err := rows.Scan(&certificate.ID, &certificate.Name, &certificate.HostPattern, &certificate.Format, &certificate.Cert, &certificate.Key, &certificate.Passphrase, &certificate.CA, &certificate.Enabled, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		certificate.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		certificates = append(certificates, &certificate)
+	}
+
+	return certificates, nil
+}
+
+func GetCertificate(id int) (*models.Certificate, error) {
+	query := `SELECT id, name, host_pattern, format, cert, key, passphrase, ca, enabled, created_at FROM certificates WHERE id = ?`
+	row := DB.QueryRow(query, id)
+
+	var certificate models.Certificate
+	var createdAt string
+	err := row.Scan(&certificate.ID, &certificate.Name, &certificate.HostPattern, &certificate.Format, &certificate.Cert, &certificate.Key, &certificate.Passphrase, &certificate.CA, &certificate.Enabled, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	certificate.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+	return &certificate, nil
+}
+
+func UpdateCertificate(certificate *models.Certificate) error {
+	query := `
+		UPDATE certificates
+		SET name = ?, host_pattern = ?, format = ?, cert = ?, key = ?, passphrase = ?, ca = ?, enabled = ?
+		WHERE id = ?
+	`
+
+	_, err := DB.Exec(query, certificate.Name, certificate.HostPattern, certificate.Format, certificate.Cert, certificate.Key, certificate.Passphrase, certificate.CA, certificate.Enabled, certificate.ID)
+	return err
+}
+
+func DeleteCertificate(id int) error {
+	query := `DELETE FROM certificates WHERE id = ?`
+	_, err := DB.Exec(query, id)
+	return err
+}
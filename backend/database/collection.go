@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 	"apiclient/backend/models"
 )
@@ -27,7 +28,7 @@ func CreateCollection(collection *models.Collection) error {
 }
 
 func GetCollections() ([]*models.Collection, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM collections ORDER BY name`
+	query := `SELECT id, name, description, retry_policy, created_at, updated_at FROM collections ORDER BY name`
 	rows, err := DB.Query(query)
 	if err != nil {
 		return nil, err
@@ -37,8 +38,12 @@ func GetCollections() ([]*models.Collection, error) {
 	var collections []*models.Collection
 	for rows.Next() {
 		var collection models.Collection
-		var createdAt, updatedAt string
-		err := rows.Scan(&collection.ID, &collection.Name, &collection.Description, &createdAt, &updatedAt)
+		var retryPolicy, createdAt, updatedAt string
+		err := rows.Scan(&collection.ID, &collection.Name, &collection.Description, &retryPolicy, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		collection.RetryPolicy, err = unmarshalRetryPolicy(retryPolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -51,12 +56,16 @@ func GetCollections() ([]*models.Collection, error) {
 }
 
 func GetCollection(id int) (*models.Collection, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM collections WHERE id = ?`
+	query := `SELECT id, name, description, retry_policy, created_at, updated_at FROM collections WHERE id = ?`
 	row := DB.QueryRow(query, id)
 
 	var collection models.Collection
-	var createdAt, updatedAt string
-	err := row.Scan(&collection.ID, &collection.Name, &collection.Description, &createdAt, &updatedAt)
+	var retryPolicy, createdAt, updatedAt string
+	err := row.Scan(&collection.ID, &collection.Name, &collection.Description, &retryPolicy, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	collection.RetryPolicy, err = unmarshalRetryPolicy(retryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +99,46 @@ func UpdateCollection(collection *models.Collection) error {
 	return nil
 }
 
+// UpdateCollectionRetryPolicy replaces the retry policy of a collection; nil removes it
+func UpdateCollectionRetryPolicy(id int, policy *models.RetryPolicy) error {
+	retryPolicy, err := marshalRetryPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE collections 
+		SET retry_policy = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+
+	_, err = DB.Exec(query, retryPolicy, id)
+	return err
+}
+
 func DeleteCollection(id int) error {
 	query := `DELETE FROM collections WHERE id = ?`
 	_, err := DB.Exec(query, id)
 	return err
+}
+
+// marshalRetryPolicy converts the retry policy of a collection to the JSON stored in the database
+func marshalRetryPolicy(policy *models.RetryPolicy) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(policy)
+	return string(data), err
+}
+
+func unmarshalRetryPolicy(data string) (*models.RetryPolicy, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var policy models.RetryPolicy
+	err := json.Unmarshal([]byte(data), &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
 }
\ No newline at end of file
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"apiclient/backend/models"
+	"database/sql"
+	"time"
+)
+
+// Cookie operations
+func CreateCookie(cookie *models.Cookie) error {
+	query := `
+		INSERT INTO cookies (environment_id, name, value, domain, path, expires, secure, http_only, host_only)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	var id int
+	var createdAt string
+	err := DB.QueryRow(query, cookie.EnvironmentID, cookie.Name, cookie.Value, cookie.Domain, cookie.Path, formatExpires(cookie.Expires), cookie.Secure, cookie.HttpOnly, cookie.HostOnly).Scan(&id, &createdAt)
+	if err != nil {
+		return err
+	}
+
+	cookie.ID = id
+	cookie.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	return nil
+}
+
+// SaveCookie stores cookie, replacing the cookie of the environment with the same domain, path and name
+func SaveCookie(cookie *models.Cookie) error {
+	err := DeleteCookieByName(cookie.EnvironmentID, cookie.Domain, cookie.Path, cookie.Name)
+	if err != nil {
+		return err
+	}
+
+	return CreateCookie(cookie)
+}
+
+func GetCookies(environmentID *int) ([]*models.Cookie, error) {
+	query := `SELECT id, environment_id, name, value, domain, path, expires, secure, http_only, host_only, created_at FROM cookies WHERE environment_id IS ? ORDER BY domain, path, name`
+	rows, err := DB.Query(query, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cookies []*models.Cookie
+	for rows.Next() {
+		var cookie models.Cookie
+		var cookieEnvironmentID sql.NullInt64
+		var expires sql.NullTime
+		var createdAt string
+		err := rows.Scan(&cookie.ID, &cookieEnvironmentID, &cookie.Name, &cookie.Value, &cookie.Domain, &cookie.Path, &expires, &cookie.Secure, &cookie.HttpOnly, &cookie.HostOnly, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if cookieEnvironmentID.Valid {
+			val := int(cookieEnvironmentID.Int64)
+			cookie.EnvironmentID = &val
+		}
+
+		if expires.Valid {
+			val := expires.Time
+			cookie.Expires = &val
+		}
+
+		cookie.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		cookies = append(cookies, &cookie)
+	}
+
+	return cookies, nil
+}
+
+func GetCookie(id int) (*models.Cookie, error) {
+	query := `SELECT id, environment_id, name, value, domain, path, expires, secure, http_only, host_only, created_at FROM cookies WHERE id = ?`
+	row := DB.QueryRow(query, id)
+
+	var cookie models.Cookie
+	var environmentID sql.NullInt64
+	var expires sql.NullTime
+	var createdAt string
+	err := row.Scan(&cookie.ID, &environmentID, &cookie.Name, &cookie.Value, &cookie.Domain, &cookie.Path, &expires, &cookie.Secure, &cookie.HttpOnly, &cookie.HostOnly, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if environmentID.Valid {
+		val := int(environmentID.Int64)
+		cookie.EnvironmentID = &val
+	}
+
+	if expires.Valid {
+		val := expires.Time
+		cookie.Expires = &val
+	}
+
+	cookie.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+	return &cookie, nil
+}
+
+func UpdateCookie(cookie *models.Cookie) error {
+	query := `
+		UPDATE cookies
+		SET environment_id = ?, name = ?, value = ?, domain = ?, path = ?, expires = ?, secure = ?, http_only = ?, host_only = ?
+		WHERE id = ?
+	`
+
+	_, err := DB.Exec(query, cookie.EnvironmentID, cookie.Name, cookie.Value, cookie.Domain, cookie.Path, formatExpires(cookie.Expires), cookie.Secure, cookie.HttpOnly, cookie.HostOnly, cookie.ID)
+	return err
+}
+
+func DeleteCookie(id int) error {
+	query := `DELETE FROM cookies WHERE id = ?`
+	_, err := DB.Exec(query, id)
+	return err
+}
+
+// DeleteCookieByName removes the cookie of the environment with the given domain, path and name
+func DeleteCookieByName(environmentID *int, domain, path, name string) error {
+	query := `DELETE FROM cookies WHERE environment_id IS ? AND domain = ? AND path = ? AND name = ?`
+	_, err := DB.Exec(query, environmentID, domain, path, name)
+	return err
+}
+
+func DeleteExpiredCookies() error {
+	query := `DELETE FROM cookies WHERE expires IS NOT NULL AND expires <= ?`
+	_, err := DB.Exec(query, time.Now().UTC().Format("2006-01-02 15:04:05"))
+	return err
+}
+
+func ClearCookies(environmentID *int) error {
+	query := `DELETE FROM cookies WHERE environment_id IS ?`
+	_, err := DB.Exec(query, environmentID)
+	return err
+}
+
+// formatExpires converts an optional expiry to its database representation, in UTC like CURRENT_TIMESTAMP
+func formatExpires(expires *time.Time) any {
+	if expires == nil {
+		return nil
+	}
+	return expires.UTC().Format("2006-01-02 15:04:05")
+}
@@ -33,6 +33,9 @@ func InitDB() {
 
 	// Create tables if they don't exist
 	createTables()
+
+	// Bring tables created by older versions up to date
+	migrateTables()
 }
 
 func createTables() {
@@ -42,6 +45,7 @@ func createTables() {
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
 		description TEXT,
+		retry_policy TEXT DEFAULT '', -- JSON
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
@@ -67,6 +71,10 @@ func createTables() {
 		url TEXT NOT NULL,
 		headers TEXT, -- JSON
 		body TEXT,
+		body_type TEXT DEFAULT '',
+		auth TEXT DEFAULT '', -- JSON
+		settings TEXT DEFAULT '', -- JSON
+		type TEXT DEFAULT 'http',
 		collection_id INTEGER,
 		folder_id INTEGER,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
@@ -94,10 +102,81 @@ func createTables() {
 		response_time INTEGER,
 		response_body TEXT,
 		response_headers TEXT, -- JSON
+		timings TEXT DEFAULT '', -- JSON
+		transcript TEXT DEFAULT '', -- JSON
 		executed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (request_id) REFERENCES requests(id) ON DELETE CASCADE
 	);`
 
+	// Certificates table
+	certificatesTable := `
+	CREATE TABLE IF NOT EXISTS certificates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		host_pattern TEXT NOT NULL,
+		format TEXT NOT NULL DEFAULT 'pem',
+		cert TEXT,
+		key TEXT,
+		passphrase TEXT,
+		ca TEXT,
+		enabled BOOLEAN DEFAULT TRUE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Proxies table
+	proxiesTable := `
+	CREATE TABLE IF NOT EXISTS proxies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		environment_id INTEGER,
+		type TEXT NOT NULL,
+		host TEXT,
+		port INTEGER,
+		username TEXT,
+		password TEXT,
+		bypass TEXT,
+		enabled BOOLEAN DEFAULT TRUE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (environment_id) REFERENCES environments(id) ON DELETE CASCADE
+	);`
+
+	// Cookies table
+	cookiesTable := `
+	CREATE TABLE IF NOT EXISTS cookies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		environment_id INTEGER,
+		name TEXT NOT NULL,
+		value TEXT,
+		domain TEXT NOT NULL,
+		path TEXT NOT NULL DEFAULT '/',
+		expires DATETIME,
+		secure BOOLEAN DEFAULT FALSE,
+		http_only BOOLEAN DEFAULT FALSE,
+		host_only BOOLEAN DEFAULT FALSE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (environment_id) REFERENCES environments(id) ON DELETE CASCADE
+	);`
+
+	// OAuth 2.0 tokens table
+	oauth2TokensTable := `
+	CREATE TABLE IF NOT EXISTS oauth2_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		cache_key TEXT NOT NULL UNIQUE,
+		access_token TEXT NOT NULL,
+		refresh_token TEXT,
+		token_type TEXT,
+		expires DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// GraphQL schemas table
+	graphqlSchemasTable := `
+	CREATE TABLE IF NOT EXISTS graphql_schemas (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		endpoint TEXT NOT NULL UNIQUE,
+		schema TEXT NOT NULL,
+		fetched_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
 	// Execute table creation queries
 	queries := []string{
 		collectionsTable,
@@ -105,6 +184,11 @@ func createTables() {
 		requestsTable,
 		environmentsTable,
 		requestHistoryTable,
+		certificatesTable,
+		proxiesTable,
+		cookiesTable,
+		oauth2TokensTable,
+		graphqlSchemasTable,
 	}
 
 	for _, query := range queries {
@@ -113,4 +197,39 @@ func createTables() {
 			log.Fatal(err)
 		}
 	}
+}
+
+func migrateTables() {
+	// Columns added after their table was first released
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"request_history", "timings", "TEXT DEFAULT ''"},
+		{"requests", "auth", "TEXT DEFAULT ''"},
+		{"collections", "retry_policy", "TEXT DEFAULT ''"},
+		{"request_history", "transcript", "TEXT DEFAULT ''"},
+		{"requests", "type", "TEXT DEFAULT 'http'"},
+		{"requests", "body_type", "TEXT DEFAULT ''"},
+		{"requests", "settings", "TEXT DEFAULT ''"},
+	}
+
+	for _, c := range columns {
+		err := addColumnIfMissing(c.table, c.column, c.definition)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func addColumnIfMissing(table, column, definition string) error {
+	var count int
+	err := DB.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	_, err = DB.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	return err
 }
\ No newline at end of file
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"apiclient/backend/models"
+	"database/sql"
+	"time"
+)
+
+// GraphQL schema operations
+
+// SaveGraphQLSchema stores schema, replacing the schema cached for the same endpoint
+func SaveGraphQLSchema(schema *models.GraphQLSchema) error {
+	query := `
+		INSERT INTO graphql_schemas (endpoint, schema)
+		VALUES (?, ?)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			schema = excluded.schema,
+			fetched_at = CURRENT_TIMESTAMP
+		RETURNING id, fetched_at
+	`
+
+	var id int
+	var fetchedAt string
+	err := DB.QueryRow(query, schema.Endpoint, schema.Schema).Scan(&id, &fetchedAt)
+	if err != nil {
+		return err
+	}
+
+	schema.ID = id
+	schema.FetchedAt, _ = time.Parse("2006-01-02 15:04:05", fetchedAt)
+	return nil
+}
+
+// GetGraphQLSchema returns the schema cached for endpoint, or nil if there is none
+func GetGraphQLSchema(endpoint string) (*models.GraphQLSchema, error) {
+	query := `SELECT id, endpoint, schema, fetched_at FROM graphql_schemas WHERE endpoint = ?`
+	row := DB.QueryRow(query, endpoint)
+
+	var schema models.GraphQLSchema
+	var fetchedAt string
+	err := row.Scan(&schema.ID, &schema.Endpoint, &schema.Schema, &fetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schema.FetchedAt, _ = time.Parse("2006-01-02 15:04:05", fetchedAt)
+	return &schema, nil
+}
+
+func DeleteGraphQLSchema(endpoint string) error {
+	query := `DELETE FROM graphql_schemas WHERE endpoint = ?`
+	_, err := DB.Exec(query, endpoint)
+	return err
+}
@@ -8,14 +8,14 @@ import (
 // RequestHistory operations
 func CreateRequestHistory(history *models.RequestHistory) error {
 	query := `
-		INSERT INTO request_history (request_id, response_status, response_time, response_body, response_headers) 
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO request_history (request_id, response_status, response_time, response_body, response_headers, timings, transcript) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 		RETURNING id, executed_at
 	`
 
 	var id int
 	var executedAt string
-	err := DB.QueryRow(query, history.RequestID, history.ResponseStatus, history.ResponseTime, history.ResponseBody, history.ResponseHeaders).Scan(&id, &executedAt)
+	err := DB.QueryRow(query, history.RequestID, history.ResponseStatus, history.ResponseTime, history.ResponseBody, history.ResponseHeaders, history.Timings, history.Transcript).Scan(&id, &executedAt)
 	if err != nil {
 		return err
 	}
@@ -26,7 +26,7 @@ func CreateRequestHistory(history *models.RequestHistory) error {
 }
 
 func GetRequestHistory() ([]*models.RequestHistory, error) {
-	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, executed_at FROM request_history ORDER BY executed_at DESC`
+	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, timings, transcript, executed_at FROM request_history ORDER BY executed_at DESC`
 	rows, err := DB.Query(query)
 	if err != nil {
 		return nil, err
@@ -37,7 +37,7 @@ func GetRequestHistory() ([]*models.RequestHistory, error) {
 	for rows.Next() {
 		var history models.RequestHistory
 		var executedAt string
-		err := rows.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &executedAt)
+		err := rows.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &history.Timings, &history.Transcript, &executedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -49,7 +49,7 @@ func GetRequestHistory() ([]*models.RequestHistory, error) {
 }
 
 func GetRequestHistoryByRequest(requestID int) ([]*models.RequestHistory, error) {
-	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, executed_at FROM request_history WHERE request_id = ? ORDER BY executed_at DESC`
+	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, timings, transcript, executed_at FROM request_history WHERE request_id = ? ORDER BY executed_at DESC`
 	rows, err := DB.Query(query, requestID)
 	if err != nil {
 		return nil, err
@@ -60,7 +60,7 @@ func GetRequestHistoryByRequest(requestID int) ([]*models.RequestHistory, error)
 	for rows.Next() {
 		var history models.RequestHistory
 		var executedAt string
-		err := rows.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &executedAt)
+		err := rows.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &history.Timings, &history.Transcript, &executedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -72,12 +72,12 @@ func GetRequestHistoryByRequest(requestID int) ([]*models.RequestHistory, error)
 }
 
 func GetRequestHistoryByID(id int) (*models.RequestHistory, error) {
-	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, executed_at FROM request_history WHERE id = ?`
+	query := `SELECT id, request_id, response_status, response_time, response_body, response_headers, timings, transcript, executed_at FROM request_history WHERE id = ?`
 	row := DB.QueryRow(query, id)
 
 	var history models.RequestHistory
 	var executedAt string
-	err := row.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &executedAt)
+	err := row.Scan(&history.ID, &history.RequestID, &history.ResponseStatus, &history.ResponseTime, &history.ResponseBody, &history.ResponseHeaders, &history.Timings, &history.Transcript, &executedAt)
 	if err != nil {
 		return nil, err
 	}
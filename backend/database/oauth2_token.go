@@ -0,0 +1,75 @@
+package database
+
+import (
+	"apiclient/backend/models"
+	"database/sql"
+	"time"
+)
+
+// OAuth 2.0 token operations
+
+// SaveOAuth2Token stores token, replacing the token cached under the same key
+func SaveOAuth2Token(token *models.OAuth2Token) error {
+	query := `
+		INSERT INTO oauth2_tokens (cache_key, access_token, refresh_token, token_type, expires)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (cache_key) DO UPDATE SET
+			access_token = excluded.access_token,
+			refresh_token = excluded.refresh_token,
+			token_type = excluded.token_type,
+			expires = excluded.expires,
+			created_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at
+	`
+
+	var id int
+	var createdAt string
+	err := DB.QueryRow(query, token.CacheKey, token.AccessToken, token.RefreshToken, token.TokenType, formatExpires(token.Expires)).Scan(&id, &createdAt)
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	token.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	return nil
+}
+
+// GetOAuth2Token returns the token cached under cacheKey, or nil if there is none
+func GetOAuth2Token(cacheKey string) (*models.OAuth2Token, error) {
+	query := `SELECT id, cache_key, access_token, refresh_token, token_type, expires, created_at FROM oauth2_tokens WHERE cache_key = ?`
+	row := DB.QueryRow(query, cacheKey)
+
+	var token models.OAuth2Token
+	var refreshToken, tokenType sql.NullString
+	var expires sql.NullTime
+	var createdAt string
+	err := row.Scan(&token.ID, &token.CacheKey, &token.AccessToken, &refreshToken, &tokenType, &expires, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token.RefreshToken = refreshToken.String
+	token.TokenType = tokenType.String
+	if expires.Valid {
+		val := expires.Time
+		token.Expires = &val
+	}
+	token.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+	return &token, nil
+}
+
+func DeleteOAuth2Token(cacheKey string) error {
+	query := `DELETE FROM oauth2_tokens WHERE cache_key = ?`
+	_, err := DB.Exec(query, cacheKey)
+	return err
+}
+
+func ClearOAuth2Tokens() error {
+	query := `DELETE FROM oauth2_tokens`
+	_, err := DB.Exec(query)
+	return err
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"apiclient/backend/models"
+	"database/sql"
+	"time"
+)
+
+// Proxy operations
+func CreateProxy(proxy *models.Proxy) error {
+	query := `
+		INSERT INTO proxies (environment_id, type, host, port, username, password, bypass, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+
+	var id int
+	var createdAt string
+	err := DB.QueryRow(query, proxy.EnvironmentID, proxy.Type, proxy.Host, proxy.Port, proxy.Username, proxy.Password, proxy.Bypass, proxy.Enabled).Scan(&id, &createdAt)
+	if err != nil {
+		return err
+	}
+
+	proxy.ID = id
+	proxy.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	return nil
+}
+
+func GetProxies() ([]*models.Proxy, error) {
+	query := `SELECT id, environment_id, type, host, port, username, password, bypass, enabled, created_at FROM proxies ORDER BY environment_id, id`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var proxies []*models.Proxy
+	for rows.Next() {
+		var proxy models.Proxy
+		var environmentID sql.NullInt64
+		var createdAt string
+		err := rows.Scan(&proxy.ID, &environmentID, &proxy.Type, &proxy.Host, &proxy.Port, &proxy.Username, &proxy.Password, &proxy.Bypass, &proxy.Enabled, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if environmentID.Valid {
+			val := int(environmentID.Int64)
+			proxy.EnvironmentID = &val
+		}
+
+		proxy.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		proxies = append(proxies, &proxy)
+	}
+
+	return proxies, nil
+}
+
+func GetProxy(id int) (*models.Proxy, error) {
+	query := `SELECT id, environment_id, type, host, port, username, password, bypass, enabled, created_at FROM proxies WHERE id = ?`
+	row := DB.QueryRow(query, id)
+
+	var proxy models.Proxy
+	var environmentID sql.NullInt64
+	var createdAt string
+	err := row.Scan(&proxy.ID, &environmentID, &proxy.Type, &proxy.Host, &proxy.Port, &proxy.Username, &proxy.Password, &proxy.Bypass, &proxy.Enabled, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if environmentID.Valid {
+		val := int(environmentID.Int64)
+		proxy.EnvironmentID = &val
+	}
+
+	proxy.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+	return &proxy, nil
+}
+
+// GetEffectiveProxy returns the enabled proxy of the environment, falling back to the enabled
+// global proxy. It returns nil when requests should go out directly.
+func GetEffectiveProxy(environmentID *int) (*models.Proxy, error) {
+	query := `
+		SELECT id, environment_id, type, host, port, username, password, bypass, enabled, created_at
+		FROM proxies
+		WHERE enabled = 1 AND (environment_id = ? OR environment_id IS NULL)
+		ORDER BY environment_id IS NULL, id
+		LIMIT 1
+	`
+	row := DB.QueryRow(query, environmentID)
+
+	var proxy models.Proxy
+	var proxyEnvironmentID sql.NullInt64
+	var createdAt string
+	err := row.Scan(&proxy.ID, &proxyEnvironmentID, &proxy.Type, &proxy.Host, &proxy.Port, &proxy.Username, &proxy.Password, &proxy.Bypass, &proxy.Enabled, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No proxy configured
+		}
+		return nil, err
+	}
+
+	if proxyEnvironmentID.Valid {
+		val := int(proxyEnvironmentID.Int64)
+		proxy.EnvironmentID = &val
+	}
+
+	proxy.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+
+	return &proxy, nil
+}
+
+func UpdateProxy(proxy *models.Proxy) error {
+	query := `
+		UPDATE proxies
+		SET environment_id = ?, type = ?, host = ?, port = ?, username = ?, password = ?, bypass = ?, enabled = ?
+		WHERE id = ?
+	`
+
+	_, err := DB.Exec(query, proxy.EnvironmentID, proxy.Type, proxy.Host, proxy.Port, proxy.Username, proxy.Password, proxy.Bypass, proxy.Enabled, proxy.ID)
+	return err
+}
+
+func DeleteProxy(id int) error {
+	query := `DELETE FROM proxies WHERE id = ?`
+	_, err := DB.Exec(query, id)
+	return err
+}
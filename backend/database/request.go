@@ -3,20 +3,30 @@ package database
 import (
 	"apiclient/backend/models"
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 // Request operations
 func CreateRequest(request *models.Request) error {
 	query := `
-		INSERT INTO requests (name, method, url, headers, body, collection_id, folder_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO requests (name, method, url, headers, body, body_type, auth, settings, type, collection_id, folder_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		RETURNING id, created_at, updated_at
 	`
 
+	auth, err := marshalAuth(request.Auth)
+	if err != nil {
+		return err
+	}
+	settings, err := marshalSettings(request.Settings)
+	if err != nil {
+		return err
+	}
+
 	var id int
 	var createdAt, updatedAt string
-	err := DB.QueryRow(query, request.Name, request.Method, request.URL, request.Headers, request.Body, request.CollectionID, request.FolderID).Scan(&id, &createdAt, &updatedAt)
+	err = DB.QueryRow(query, request.Name, request.Method, request.URL, request.Headers, request.Body, request.BodyType, auth, settings, request.Type, request.CollectionID, request.FolderID).Scan(&id, &createdAt, &updatedAt)
 	if err != nil {
 		return err
 	}
@@ -28,7 +38,7 @@ func CreateRequest(request *models.Request) error {
 }
 
 func GetRequests() ([]*models.Request, error) {
-	query := `SELECT id, name, method, url, headers, body, collection_id, folder_id, created_at, updated_at FROM requests ORDER BY name`
+	query := `SELECT id, name, method, url, headers, body, body_type, auth, settings, type, collection_id, folder_id, created_at, updated_at FROM requests ORDER BY name`
 	rows, err := DB.Query(query)
 	if err != nil {
 		return nil, err
@@ -39,8 +49,8 @@ func GetRequests() ([]*models.Request, error) {
 	for rows.Next() {
 		var request models.Request
 		var collectionID, folderID sql.NullInt64
-		var createdAt, updatedAt string
-		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &collectionID, &folderID, &createdAt, &updatedAt)
+		var auth, settings, createdAt, updatedAt string
+		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &request.BodyType, &auth, &settings, &request.Type, &collectionID, &folderID, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -55,6 +65,15 @@ func GetRequests() ([]*models.Request, error) {
 			request.FolderID = &val
 		}
 		
+		request.Auth, err = unmarshalAuth(auth)
+		if err != nil {
+			return nil, err
+		}
+		request.Settings, err = unmarshalSettings(settings)
+		if err != nil {
+			return nil, err
+		}
+		
 		request.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
 		request.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 		requests = append(requests, &request)
@@ -64,13 +83,13 @@ func GetRequests() ([]*models.Request, error) {
 }
 
 func GetRequest(id int) (*models.Request, error) {
-	query := `SELECT id, name, method, url, headers, body, collection_id, folder_id, created_at, updated_at FROM requests WHERE id = ?`
+	query := `SELECT id, name, method, url, headers, body, body_type, auth, settings, type, collection_id, folder_id, created_at, updated_at FROM requests WHERE id = ?`
 	row := DB.QueryRow(query, id)
 
 	var request models.Request
 	var collectionID, folderID sql.NullInt64
-	var createdAt, updatedAt string
-	err := row.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &collectionID, &folderID, &createdAt, &updatedAt)
+	var auth, settings, createdAt, updatedAt string
+	err := row.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &request.BodyType, &auth, &settings, &request.Type, &collectionID, &folderID, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +104,15 @@ func GetRequest(id int) (*models.Request, error) {
 		request.FolderID = &val
 	}
 	
+	request.Auth, err = unmarshalAuth(auth)
+	if err != nil {
+		return nil, err
+	}
+	request.Settings, err = unmarshalSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	
 	request.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
 	request.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 
@@ -94,11 +122,20 @@ func GetRequest(id int) (*models.Request, error) {
 func UpdateRequest(request *models.Request) error {
 	query := `
 		UPDATE requests 
-		SET name = ?, method = ?, url = ?, headers = ?, body = ?, collection_id = ?, folder_id = ?, updated_at = CURRENT_TIMESTAMP
+		SET name = ?, method = ?, url = ?, headers = ?, body = ?, body_type = ?, auth = ?, settings = ?, type = ?, collection_id = ?, folder_id = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`
 
-	_, err := DB.Exec(query, request.Name, request.Method, request.URL, request.Headers, request.Body, request.CollectionID, request.FolderID, request.ID)
+	auth, err := marshalAuth(request.Auth)
+	if err != nil {
+		return err
+	}
+	settings, err := marshalSettings(request.Settings)
+	if err != nil {
+		return err
+	}
+
+	_, err = DB.Exec(query, request.Name, request.Method, request.URL, request.Headers, request.Body, request.BodyType, auth, settings, request.Type, request.CollectionID, request.FolderID, request.ID)
 	if err != nil {
 		return err
 	}
@@ -122,7 +159,7 @@ func DeleteRequest(id int) error {
 }
 
 func GetRequestsByCollection(collectionID int) ([]*models.Request, error) {
-	query := `SELECT id, name, method, url, headers, body, collection_id, folder_id, created_at, updated_at FROM requests WHERE collection_id = ? ORDER BY name`
+	query := `SELECT id, name, method, url, headers, body, body_type, auth, settings, type, collection_id, folder_id, created_at, updated_at FROM requests WHERE collection_id = ? ORDER BY name`
 	rows, err := DB.Query(query, collectionID)
 	if err != nil {
 		return nil, err
@@ -133,8 +170,8 @@ func GetRequestsByCollection(collectionID int) ([]*models.Request, error) {
 	for rows.Next() {
 		var request models.Request
 		var folderID sql.NullInt64
-		var createdAt, updatedAt string
-		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &collectionID, &folderID, &createdAt, &updatedAt)
+		var auth, settings, createdAt, updatedAt string
+		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &request.BodyType, &auth, &settings, &request.Type, &collectionID, &folderID, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -144,6 +181,15 @@ func GetRequestsByCollection(collectionID int) ([]*models.Request, error) {
 			request.FolderID = &val
 		}
 		
+		request.Auth, err = unmarshalAuth(auth)
+		if err != nil {
+			return nil, err
+		}
+		request.Settings, err = unmarshalSettings(settings)
+		if err != nil {
+			return nil, err
+		}
+		
 		request.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
 		request.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 		requests = append(requests, &request)
@@ -153,7 +199,7 @@ func GetRequestsByCollection(collectionID int) ([]*models.Request, error) {
 }
 
 func GetRequestsByFolder(folderID int) ([]*models.Request, error) {
-	query := `SELECT id, name, method, url, headers, body, collection_id, folder_id, created_at, updated_at FROM requests WHERE folder_id = ? ORDER BY name`
+	query := `SELECT id, name, method, url, headers, body, body_type, auth, settings, type, collection_id, folder_id, created_at, updated_at FROM requests WHERE folder_id = ? ORDER BY name`
 	rows, err := DB.Query(query, folderID)
 	if err != nil {
 		return nil, err
@@ -164,8 +210,8 @@ func GetRequestsByFolder(folderID int) ([]*models.Request, error) {
 	for rows.Next() {
 		var request models.Request
 		var collectionID sql.NullInt64
-		var createdAt, updatedAt string
-		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &collectionID, &folderID, &createdAt, &updatedAt)
+		var auth, settings, createdAt, updatedAt string
+		err := rows.Scan(&request.ID, &request.Name, &request.Method, &request.URL, &request.Headers, &request.Body, &request.BodyType, &auth, &settings, &request.Type, &collectionID, &folderID, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -175,10 +221,61 @@ func GetRequestsByFolder(folderID int) ([]*models.Request, error) {
 			request.CollectionID = &val
 		}
 		
+		request.Auth, err = unmarshalAuth(auth)
+		if err != nil {
+			return nil, err
+		}
+		request.Settings, err = unmarshalSettings(settings)
+		if err != nil {
+			return nil, err
+		}
+		
 		request.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
 		request.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
 		requests = append(requests, &request)
 	}
 
 	return requests, nil
-}
\ No newline at end of file
+}
+
+// marshalAuth converts the auth settings of a request to the JSON stored in the database
+func marshalAuth(auth *models.RequestAuth) (string, error) {
+	if auth == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(auth)
+	return string(data), err
+}
+
+func unmarshalAuth(data string) (*models.RequestAuth, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var auth models.RequestAuth
+	err := json.Unmarshal([]byte(data), &auth)
+	if err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+// marshalSettings converts the execution settings of a request to the JSON stored in the database
+func marshalSettings(settings *models.RequestSettings) (string, error) {
+	if settings == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(settings)
+	return string(data), err
+}
+
+func unmarshalSettings(data string) (*models.RequestSettings, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var settings models.RequestSettings
+	err := json.Unmarshal([]byte(data), &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
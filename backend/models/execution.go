@@ -0,0 +1,252 @@
+package models
+
+import "time"
+
+// KeyValue represents a single name/value pair such as a header or query parameter
+type KeyValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Enabled bool   `json:"enabled"`
+}
+
+// FormField represents a field of a form-data or x-www-form-urlencoded body
+type FormField struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`         // text or file
+	FilePath    string `json:"file_path"`    // file to upload, for file fields
+	ContentType string `json:"content_type"` // of the file, guessed from its extension when empty
+	Enabled     bool   `json:"enabled"`
+}
+
+// RequestAuth represents the authentication settings of a request
+type RequestAuth struct {
+	Type     string        `json:"type"` // none, bearer, basic, digest, api-key, oauth1, oauth2, aws-sigv4 or jwt
+	Token    string        `json:"token"`
+	Username string        `json:"username"`
+	Password string        `json:"password"`
+	Key      string        `json:"key"`
+	Value    string        `json:"value"`
+	AddTo    string        `json:"add_to"` // header or query
+	OAuth1   *OAuth1Config `json:"oauth1,omitempty"`
+	OAuth2   *OAuth2Config `json:"oauth2,omitempty"`
+	AWS      *AWSConfig    `json:"aws,omitempty"`
+	JWT      *JWTConfig    `json:"jwt,omitempty"`
+}
+
+// OAuth1Config represents the settings of OAuth 1.0a request signing
+type OAuth1Config struct {
+	ConsumerKey     string `json:"consumer_key"`
+	ConsumerSecret  string `json:"consumer_secret"`
+	Token           string `json:"token"`
+	TokenSecret     string `json:"token_secret"`
+	SignatureMethod string `json:"signature_method"` // HMAC-SHA1, RSA-SHA1 or PLAINTEXT
+	PrivateKey      string `json:"private_key"`      // PEM RSA key, for RSA-SHA1
+	Realm           string `json:"realm"`
+	AddTo           string `json:"add_to"` // header, query or body
+}
+
+// OAuth2Config represents the settings of OAuth 2.0 auth
+type OAuth2Config struct {
+	GrantType    string `json:"grant_type"` // client_credentials, password or authorization_code
+	TokenURL     string `json:"token_url"`
+	AuthURL      string `json:"auth_url"` // authorization endpoint, for the authorization_code grant
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`         // space separated
+	Username     string `json:"username"`      // resource owner, for the password grant
+	Password     string `json:"password"`      // resource owner, for the password grant
+	RedirectPort int    `json:"redirect_port"` // port of the loopback redirect listener, 0 picks a free one
+	ClientAuth   string `json:"client_auth"`   // header or body; empty tries both
+}
+
+// AWSConfig represents the credentials used to sign requests with AWS Signature Version 4
+type AWSConfig struct {
+	AccessKey    string `json:"access_key"`
+	SecretKey    string `json:"secret_key"`
+	SessionToken string `json:"session_token"` // for temporary credentials
+	Region       string `json:"region"`
+	Service      string `json:"service"` // e.g. execute-api or s3
+}
+
+// JWTConfig represents the settings of a JWT signed locally and sent as a bearer token
+type JWTConfig struct {
+	Algorithm      string `json:"algorithm"`        // HS256, HS384, HS512, RS256 or ES256
+	Secret         string `json:"secret"`           // shared secret, for the HS algorithms
+	PrivateKey     string `json:"private_key"`      // PEM key, for RS256 and ES256
+	KeyID          string `json:"key_id"`           // kid header, optional
+	Claims         string `json:"claims"`           // JSON object, may contain {{variables}}
+	ExpiresIn      int    `json:"expires_in"`       // seconds from now for exp; 0 keeps the exp of the claims, if any
+	IssuedAtOffset int    `json:"issued_at_offset"` // seconds added to now for iat, e.g. -30 to allow for clock skew
+}
+
+// RequestSettings represents per-request execution options
+type RequestSettings struct {
+	TimeoutMs          int  `json:"timeout_ms"`            // 0 uses the default timeout of 30 seconds
+	DisableRedirects   bool `json:"disable_redirects"`     // return 3xx responses instead of following them
+	MaxRedirects       int  `json:"max_redirects"`         // 0 uses the default of 10 hops
+	RewriteMethodOn307 bool `json:"rewrite_method_on_307"` // follow 307/308 with a GET without body
+	SkipTLSVerify      bool `json:"skip_tls_verify"`       // accept any server certificate, e.g. self-signed ones
+
+	// UnixSocket is the path of a Unix domain socket the request is sent through instead of
+	// connecting to the host of its URL, e.g. /var/run/docker.sock
+	UnixSocket string `json:"unix_socket"`
+
+	MaxResponseBytes int64  `json:"max_response_bytes"` // 0 keeps up to 50 MiB of the body in memory
+	SaveToFile       string `json:"save_to_file"`       // stream the body to this path instead of returning it
+
+	// Retry overrides the retry policy of the collection of the request
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// RetryPolicy represents when and how often a failed execution is attempted again.
+// The delay doubles after every attempt, with jitter, up to MaxDelayMs, unless the response asks
+// for one with Retry-After.
+type RetryPolicy struct {
+	MaxAttempts      int      `json:"max_attempts"`       // including the first one; 0 or 1 never retries
+	StatusCodes      []int    `json:"status_codes"`       // responses to retry, e.g. 429, 502, 503 and 504
+	NetworkErrors    []string `json:"network_errors"`     // connection_refused, connection_reset, dns, timeout or eof
+	InitialDelayMs   int      `json:"initial_delay_ms"`   // 0 uses 500 ms
+	MaxDelayMs       int      `json:"max_delay_ms"`       // 0 uses 30 seconds; also caps Retry-After
+	IgnoreRetryAfter bool     `json:"ignore_retry_after"` // back off even when the response sends Retry-After
+}
+
+// RequestSpec describes an HTTP request to be executed
+type RequestSpec struct {
+	ExecutionID string          `json:"execution_id"` // generated when empty; pass one to be able to cancel the execution
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	Headers     []KeyValue      `json:"headers"`
+	QueryParams []KeyValue      `json:"query_params"`
+	BodyType    string          `json:"body_type"` // none, json, xml, raw, form-data, x-www-form-urlencoded or graphql
+	Body        string          `json:"body"`
+	FormFields  []FormField     `json:"form_fields"`       // body of form-data and x-www-form-urlencoded requests
+	GraphQL     *GraphQLBody    `json:"graphql,omitempty"` // body of graphql requests
+	Auth        *RequestAuth    `json:"auth"`
+	Settings    RequestSettings `json:"settings"`
+
+	// CollectionID is the collection the request belongs to, whose retry policy applies by default
+	CollectionID *int `json:"collection_id"`
+	// RequestID is the saved request the spec was built from, whose history stream transcripts are saved to
+	RequestID *int `json:"request_id"`
+}
+
+// ExecutionTimings represents how long each phase of an execution took, in milliseconds
+type ExecutionTimings struct {
+	DNSLookup    float64 `json:"dns_lookup"`
+	TCPConnect   float64 `json:"tcp_connect"`
+	TLSHandshake float64 `json:"tls_handshake"`
+	FirstByte    float64 `json:"first_byte"` // from the request being written to the first response byte
+	Download     float64 `json:"download"`
+	Total        float64 `json:"total"`
+}
+
+// ExchangeRecord represents an intermediate request/response exchange, such as a redirect hop
+type ExchangeRecord struct {
+	Method         string              `json:"method"`
+	URL            string              `json:"url"`
+	Status         int                 `json:"status"`
+	StatusText     string              `json:"status_text"`
+	Headers        map[string][]string `json:"headers"`                   // response headers
+	RequestHeaders map[string][]string `json:"request_headers,omitempty"` // recorded for auth exchanges
+}
+
+// AttemptRecord represents one attempt of an execution retried according to its RetryPolicy
+type AttemptRecord struct {
+	Attempt    int     `json:"attempt"` // starting at 1
+	Status     int     `json:"status"`  // 0 when the attempt failed without a response
+	StatusText string  `json:"status_text"`
+	Error      string  `json:"error"`
+	DurationMs float64 `json:"duration_ms"`
+	DelayMs    float64 `json:"delay_ms"`    // waited before the next attempt, 0 for the last one
+	RetryAfter bool    `json:"retry_after"` // the delay came from the Retry-After header of the response
+}
+
+// Execution outcomes
+const (
+	OutcomeCompleted = "completed"
+	OutcomeCancelled = "cancelled"
+	OutcomeTimedOut  = "timed_out"
+)
+
+// ExecutionResult represents the response of an executed RequestSpec
+type ExecutionResult struct {
+	ExecutionID string              `json:"execution_id"`
+	Outcome     string              `json:"outcome"` // completed, cancelled or timed_out
+	Error       string              `json:"error"`
+	Status      int                 `json:"status"`
+	StatusText  string              `json:"status_text"`
+	Protocol    string              `json:"protocol"`
+	URL         string              `json:"url"`   // final URL, after redirects
+	Proxy       string              `json:"proxy"` // proxy the final request went through, empty when direct
+	Headers     map[string][]string `json:"headers"`
+	Trailers    map[string][]string `json:"trailers"` // sent after the body, e.g. the gRPC status
+	Body        string              `json:"body"`
+	ContentType string              `json:"content_type"`
+	BodySize    int64               `json:"body_size"` // bytes received, or written to SavedTo
+
+	BodyEncoding string           `json:"body_encoding"` // text, or base64 for binary bodies
+	IsBinary     bool             `json:"is_binary"`
+	Truncated    bool             `json:"truncated"` // the body exceeded max_response_bytes and was cut
+	SavedTo      string           `json:"saved_to"`  // file the body was streamed to
+	HeadersSize  int64            `json:"headers_size"`
+	Timings      ExecutionTimings `json:"timings"` // phases of the final exchange, total across all hops
+	Redirects    []ExchangeRecord `json:"redirects"`
+
+	// AuthExchanges records challenge/response authentication, e.g. the 401 answered by Digest auth
+	// followed by the authorized exchange
+	AuthExchanges []ExchangeRecord `json:"auth_exchanges"`
+
+	// Attempts records every attempt when the request was retried; Redirects and AuthExchanges
+	// only cover the last one
+	Attempts []AttemptRecord `json:"attempts"`
+
+	// Events is the transcript of a text/event-stream response, whose raw stream is kept in Body
+	Events []ServerSentEvent `json:"events"`
+
+	// Messages is the transcript of a gRPC call, whose response messages are also kept in Body
+	Messages []GRPCMessage `json:"messages"`
+	// GRPCStatus is the status a gRPC call ended with, whose JSON form is the Body of failed calls
+	GRPCStatus *GRPCStatus `json:"grpc_status"`
+
+	// GraphQLErrors are the errors of a GraphQL response, which usually comes with a 200 status
+	GraphQLErrors []GraphQLError `json:"graphql_errors"`
+	// SubscriptionEvents is the transcript of a GraphQL subscription
+	SubscriptionEvents []GraphQLSubscriptionEvent `json:"subscription_events"`
+
+	// SOAPFault is the fault of a SOAP response, which usually comes with a 500 status
+	SOAPFault *SOAPFault `json:"soap_fault"`
+
+	// UnresolvedVariables lists the {{placeholders}} that had no matching variable
+	UnresolvedVariables []string `json:"unresolved_variables"`
+	// GeneratedVariables records the values produced for {{$dynamic}} variables during this execution
+	GeneratedVariables map[string]string `json:"generated_variables"`
+}
+
+// ServerSentEvent represents an event received from a text/event-stream response
+type ServerSentEvent struct {
+	ExecutionID string    `json:"execution_id"`
+	Event       string    `json:"event"` // message unless the server names it
+	ID          string    `json:"id"`    // last event ID, which may have been set by a previous event
+	Data        string    `json:"data"`
+	Retry       int       `json:"retry"` // reconnection time in ms sent along with this event, 0 if none
+	ReceivedAt  time.Time `json:"received_at"`
+}
+
+// AuthorizationPrompt asks the user to authorize an execution in the browser
+type AuthorizationPrompt struct {
+	ExecutionID string `json:"execution_id"`
+	URL         string `json:"url"`
+}
+
+// TransferProgress represents how much of the body of an execution was transferred so far
+type TransferProgress struct {
+	ExecutionID string `json:"execution_id"`
+	Direction   string `json:"direction"` // upload or download
+	Bytes       int64  `json:"bytes"`
+	Total       int64  `json:"total"` // -1 when unknown
+
+	// BytesPerSecond is the rate since the previous event, or over the whole transfer once done
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	Done           bool    `json:"done"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// GraphQLBody represents the operation sent by a request whose body type is graphql
+type GraphQLBody struct {
+	Query         string `json:"query"`
+	Variables     string `json:"variables"` // JSON object, may be empty
+	OperationName string `json:"operation_name"`
+
+	// ConnectionParams is the JSON payload of the connection_init message of subscriptions,
+	// often carrying their auth
+	ConnectionParams string `json:"connection_params"`
+}
+
+// GraphQLError represents an entry of the errors of a GraphQL response
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"` // field names and list indexes
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// GraphQLLocation represents a position in a GraphQL document
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQL subscription event types
+const (
+	GraphQLEventNext     = "next"
+	GraphQLEventError    = "error"
+	GraphQLEventComplete = "complete"
+)
+
+// GraphQLSubscriptionEvent represents a message received for a GraphQL subscription
+type GraphQLSubscriptionEvent struct {
+	ExecutionID string    `json:"execution_id"`
+	Type        string    `json:"type"`    // next, error or complete
+	Payload     string    `json:"payload"` // JSON; the execution result for next, the errors for error
+	ReceivedAt  time.Time `json:"received_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// GRPCSpec describes a gRPC call to be executed
+type GRPCSpec struct {
+	ExecutionID string     `json:"execution_id"` // generated when empty; pass one to be able to cancel the call
+	Target      string     `json:"target"`       // host:port, or a grpc:// or grpcs:// URL
+	Method      string     `json:"method"`       // full method name, package.Service/Method
+	Messages    []string   `json:"messages"`     // JSON request messages; unary and server streaming calls send only the first one
+	Metadata    []KeyValue `json:"metadata"`
+
+	// ProtoFiles are the .proto files describing the services. When empty, they're discovered
+	// through server reflection.
+	ProtoFiles  []string `json:"proto_files"`
+	ImportPaths []string `json:"import_paths"` // directories imports of ProtoFiles are resolved from
+
+	TLS           bool `json:"tls"` // implied by grpcs:// targets
+	SkipTLSVerify bool `json:"skip_tls_verify"`
+	TimeoutMs     int  `json:"timeout_ms"` // 0 uses the default of 30 seconds; streams are only bounded until the server answers
+
+	// RequestID is the saved request the spec was built from, whose history streaming transcripts are saved to
+	RequestID *int `json:"request_id"`
+}
+
+// GRPCService represents a service and its methods, as described by reflection or .proto files
+type GRPCService struct {
+	Name    string       `json:"name"` // fully qualified, e.g. helloworld.Greeter
+	Methods []GRPCMethod `json:"methods"`
+}
+
+// GRPCMethod represents a method of a GRPCService
+type GRPCMethod struct {
+	Name            string `json:"name"`
+	FullName        string `json:"full_name"` // package.Service/Method, as used by GRPCSpec
+	ClientStreaming bool   `json:"client_streaming"`
+	ServerStreaming bool   `json:"server_streaming"`
+	InputType       string `json:"input_type"`
+	OutputType      string `json:"output_type"`
+	InputTemplate   string `json:"input_template"` // JSON request message with every field set to its zero value
+}
+
+// GRPCMessage represents a message sent or received during a gRPC call
+type GRPCMessage struct {
+	ExecutionID string    `json:"execution_id"`
+	Direction   string    `json:"direction"` // sent or received
+	Data        string    `json:"data"`      // JSON
+	Time        time.Time `json:"time"`
+}
+
+// GRPCStatus represents the status a gRPC call ended with, which servers send in the trailers of a
+// 200 response
+type GRPCStatus struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"` // name of the code, e.g. NotFound
+	Message string `json:"message"`
+}
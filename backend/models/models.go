@@ -7,6 +7,7 @@ type Collection struct {
 	ID          int       `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	RetryPolicy *RetryPolicy `json:"retry_policy"` // applies to the requests that don't set their own
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -24,16 +25,27 @@ type Folder struct {
 type Request struct {
 	ID           int       `json:"id"`
 	Name         string    `json:"name"`
+	Type         string    `json:"type"` // http, websocket or grpc
 	Method       string    `json:"method"`
 	URL          string    `json:"url"`
 	Headers      string    `json:"headers"` // JSON string
 	Body         string    `json:"body"`
+	BodyType     string    `json:"body_type"` // see RequestSpec.BodyType; empty for requests saved before it was kept
+	Auth         *RequestAuth `json:"auth"`
+	Settings     *RequestSettings `json:"settings"` // nil uses the defaults
 	CollectionID *int      `json:"collection_id"`
 	FolderID     *int      `json:"folder_id"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// Request types
+const (
+	RequestTypeHTTP      = "http"
+	RequestTypeWebSocket = "websocket"
+	RequestTypeGRPC      = "grpc"
+)
+
 // Environment represents an environment with variables
 type Environment struct {
 	ID        int       `json:"id"`
@@ -51,5 +63,69 @@ type RequestHistory struct {
 	ResponseTime   int       `json:"response_time"`
 	ResponseBody   string    `json:"response_body"`
 	ResponseHeaders string   `json:"response_headers"` // JSON string
+	Timings        string    `json:"timings"`          // JSON string
+	Transcript     string    `json:"transcript"`       // JSON string, messages of a streamed session
 	ExecutedAt     time.Time `json:"executed_at"`
+}
+
+// Certificate represents a client certificate and/or extra CA roots used for matching hosts
+type Certificate struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	HostPattern string    `json:"host_pattern"` // host[:port], optionally with a leading *. wildcard
+	Format      string    `json:"format"`       // pem or pkcs12
+	Cert        string    `json:"cert"`         // PEM certificate chain, or base64 PKCS#12 bundle
+	Key         string    `json:"key"`          // PEM private key, unused for PKCS#12
+	Passphrase  string    `json:"passphrase"`
+	CA          string    `json:"ca"` // PEM root certificates to trust in addition to the system ones
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Proxy represents the proxy requests go through, either globally or for one environment
+type Proxy struct {
+	ID            int       `json:"id"`
+	EnvironmentID *int      `json:"environment_id"` // nil for the global proxy
+	Type          string    `json:"type"`           // http, https, socks5 or system
+	Host          string    `json:"host"`
+	Port          int       `json:"port"`
+	Username      string    `json:"username"`
+	Password      string    `json:"password"`
+	Bypass        string    `json:"bypass"` // comma separated hosts, domains and CIDRs, like NO_PROXY
+	Enabled       bool      `json:"enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Cookie represents a cookie stored in the cookie jar of an environment
+type Cookie struct {
+	ID            int        `json:"id"`
+	EnvironmentID *int       `json:"environment_id"` // nil when no environment is active
+	Name          string     `json:"name"`
+	Value         string     `json:"value"`
+	Domain        string     `json:"domain"`
+	Path          string     `json:"path"`
+	Expires       *time.Time `json:"expires"` // nil for session cookies
+	Secure        bool       `json:"secure"`
+	HttpOnly      bool       `json:"http_only"`
+	HostOnly      bool       `json:"host_only"` // only sent to Domain itself, not to its subdomains
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// OAuth2Token represents an OAuth 2.0 token cached for the auth settings it was issued for
+type OAuth2Token struct {
+	ID           int        `json:"id"`
+	CacheKey     string     `json:"cache_key"`
+	AccessToken  string     `json:"access_token"`
+	RefreshToken string     `json:"refresh_token"`
+	TokenType    string     `json:"token_type"`
+	Expires      *time.Time `json:"expires"` // nil when the token doesn't expire
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// GraphQLSchema represents the introspection result of a GraphQL endpoint, cached for completion
+type GraphQLSchema struct {
+	ID        int       `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	Schema    string    `json:"schema"` // JSON of the data of the introspection query, holding __schema
+	FetchedAt time.Time `json:"fetched_at"`
 }
\ No newline at end of file
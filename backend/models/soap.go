@@ -0,0 +1,12 @@
+package models
+
+// SOAPFault represents the fault reported by a SOAP 1.1 or 1.2 response
+type SOAPFault struct {
+	Version  string   `json:"version"`  // 1.1 or 1.2
+	Code     string   `json:"code"`     // faultcode, or the value of the 1.2 Code, e.g. soap:Server
+	Subcodes []string `json:"subcodes"` // SOAP 1.2 subcode values, outermost first
+	Reason   string   `json:"reason"`   // faultstring, or the first text of the 1.2 Reason
+	Actor    string   `json:"actor"`    // faultactor, or the 1.2 Role
+	Node     string   `json:"node"`     // SOAP 1.2 only
+	Detail   string   `json:"detail"`   // XML content of the detail element
+}
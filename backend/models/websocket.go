@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// WebSocketSpec describes a WebSocket session to open
+type WebSocketSpec struct {
+	SessionID     string     `json:"session_id"` // generated when empty
+	URL           string     `json:"url"`        // ws:// or wss://
+	Headers       []KeyValue `json:"headers"`
+	Subprotocols  []string   `json:"subprotocols"` // offered in order of preference
+	TimeoutMs     int        `json:"timeout_ms"`   // of the handshake; 0 uses the default of 30 seconds
+	SkipTLSVerify bool       `json:"skip_tls_verify"`
+	RequestID     *int       `json:"request_id"` // saved request whose history the message log is saved to
+}
+
+// WebSocketSession represents an open WebSocket session
+type WebSocketSession struct {
+	SessionID   string              `json:"session_id"`
+	URL         string              `json:"url"`
+	Subprotocol string              `json:"subprotocol"` // chosen by the server, empty if none
+	Status      int                 `json:"status"`
+	Headers     map[string][]string `json:"headers"` // of the handshake response
+
+	// UnresolvedVariables lists the {{placeholders}} that had no matching variable
+	UnresolvedVariables []string `json:"unresolved_variables"`
+}
+
+// WebSocket message types
+const (
+	WebSocketText   = "text"
+	WebSocketBinary = "binary"
+	WebSocketPing   = "ping"
+	WebSocketPong   = "pong"
+	WebSocketClose  = "close"
+)
+
+// WebSocketMessage represents a message or control frame of a WebSocket session
+type WebSocketMessage struct {
+	SessionID string    `json:"session_id"`
+	Direction string    `json:"direction"` // sent or received
+	Type      string    `json:"type"`      // text, binary, ping, pong or close
+	Data      string    `json:"data"`      // base64 for binary messages; the reason of close frames
+	CloseCode int       `json:"close_code"`
+	Time      time.Time `json:"time"`
+}
+
+// WebSocketClosed represents the end of a WebSocket session
+type WebSocketClosed struct {
+	SessionID string `json:"session_id"`
+	Code      int    `json:"code"` // close code received, 1006 when the connection dropped without one
+	Reason    string `json:"reason"`
+	Error     string `json:"error"` // set when the session ended on an error rather than a close frame
+}
@@ -3,17 +3,25 @@ package services
 import (
 	"apiclient/backend/database"
 	"apiclient/backend/models"
-	"encoding/json"
-	"io"
-	"net/http"
+	"context"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // APIClientService provides the main API for the frontend
-type APIClientService struct{}
+type APIClientService struct {
+	events     EventEmitter
+	executions executionRegistry
+	websockets webSocketRegistry
+}
+
+// NewAPIClientService creates the service, publishing events to the frontend through events
+func NewAPIClientService(events EventEmitter) *APIClientService {
+	return &APIClientService{events: events}
+}
 
 // Collection methods
 func (s *APIClientService) CreateCollection(name, description string) (*models.Collection, error) {
@@ -50,7 +58,19 @@ func (s *APIClientService) UpdateCollection(id int, name, description string) (*
 		return nil, err
 	}
 	
-	return collection, nil
+	// Read it back so the result keeps the retry policy
+	return database.GetCollection(id)
+}
+
+// SetCollectionRetryPolicy sets the retry policy applied to the requests of a collection
+// that don't set their own; nil disables retries for them
+func (s *APIClientService) SetCollectionRetryPolicy(id int, policy *models.RetryPolicy) (*models.Collection, error) {
+	err := database.UpdateCollectionRetryPolicy(id, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.GetCollection(id)
 }
 
 func (s *APIClientService) DeleteCollection(id int) error {
@@ -106,17 +126,25 @@ func (s *APIClientService) GetFoldersByCollection(collectionID int) ([]*models.F
 }
 
 // Request methods
-func (s *APIClientService) CreateRequest(name, method, url, headers, body string, collectionID, folderID *int) (*models.Request, error) {
+func (s *APIClientService) CreateRequest(name, requestType, method, url, headers, body, bodyType string, auth *models.RequestAuth, settings *models.RequestSettings, collectionID, folderID *int) (*models.Request, error) {
 	request := &models.Request{
 		Name:         name,
+		Type:         requestType,
 		Method:       method,
 		URL:          url,
 		Headers:      headers,
 		Body:         body,
+		BodyType:     bodyType,
+		Auth:         auth,
+		Settings:     settings,
 		CollectionID: collectionID,
 		FolderID:     folderID,
 	}
 	
+	if request.Type == "" {
+		request.Type = models.RequestTypeHTTP
+	}
+	
 	err := database.CreateRequest(request)
 	if err != nil {
 		return nil, err
@@ -133,18 +161,26 @@ func (s *APIClientService) GetRequest(id int) (*models.Request, error) {
 	return database.GetRequest(id)
 }
 
-func (s *APIClientService) UpdateRequest(id int, name, method, url, headers, body string, collectionID, folderID *int) (*models.Request, error) {
+func (s *APIClientService) UpdateRequest(id int, name, requestType, method, url, headers, body, bodyType string, auth *models.RequestAuth, settings *models.RequestSettings, collectionID, folderID *int) (*models.Request, error) {
 	request := &models.Request{
 		ID:           id,
 		Name:         name,
+		Type:         requestType,
 		Method:       method,
 		URL:          url,
 		Headers:      headers,
 		Body:         body,
+		BodyType:     bodyType,
+		Auth:         auth,
+		Settings:     settings,
 		CollectionID: collectionID,
 		FolderID:     folderID,
 	}
 	
+	if request.Type == "" {
+		request.Type = models.RequestTypeHTTP
+	}
+	
 	err := database.UpdateRequest(request)
 	if err != nil {
 		return nil, err
@@ -221,13 +257,14 @@ func (s *APIClientService) GetActiveEnvironment() (*models.Environment, error) {
 }
 
 // RequestHistory methods
-func (s *APIClientService) CreateRequestHistory(requestID, responseStatus, responseTime int, responseBody, responseHeaders string) (*models.RequestHistory, error) {
+func (s *APIClientService) CreateRequestHistory(requestID, responseStatus, responseTime int, responseBody, responseHeaders, timings string) (*models.RequestHistory, error) {
 	history := &models.RequestHistory{
 		RequestID:       requestID,
 		ResponseStatus:  responseStatus,
 		ResponseTime:    responseTime,
 		ResponseBody:    responseBody,
 		ResponseHeaders: responseHeaders,
+		Timings:         timings,
 	}
 	
 	err := database.CreateRequestHistory(history)
@@ -258,102 +295,216 @@ func (s *APIClientService) ClearRequestHistory() error {
 	return database.ClearRequestHistory()
 }
 
-// SaveFileToDownloads saves a file to the user's Downloads folder
-func (s *APIClientService) SaveFileToDownloads(filename, content string) (string, error) {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// Certificate methods
+func (s *APIClientService) CreateCertificate(name, hostPattern, format, cert, key, passphrase, ca string, enabled bool) (*models.Certificate, error) {
+	certificate := &models.Certificate{
+		Name:        name,
+		HostPattern: hostPattern,
+		Format:      format,
+		Cert:        cert,
+		Key:         key,
+		Passphrase:  passphrase,
+		CA:          ca,
+		Enabled:     enabled,
 	}
-	
-	// Create downloads path
-	downloadsPath := filepath.Join(homeDir, "Downloads", filename)
-	
-	// Write file
-	err = os.WriteFile(downloadsPath, []byte(content), 0644)
+
+	err := database.CreateCertificate(certificate)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	
-	return downloadsPath, nil
+
+	return certificate, nil
+}
+
+func (s *APIClientService) GetCertificates() ([]*models.Certificate, error) {
+	return database.GetCertificates()
+}
+
+func (s *APIClientService) GetCertificate(id int) (*models.Certificate, error) {
+	return database.GetCertificate(id)
 }
 
-// ExecuteRequest sends an HTTP request and returns the response
-func (s *APIClientService) ExecuteRequest(method, url, headers, body string) (map[string]interface{}, error) {
-	// Replace environment variables in the URL
-	activeEnv, err := database.GetActiveEnvironment()
+func (s *APIClientService) UpdateCertificate(id int, name, hostPattern, format, cert, key, passphrase, ca string, enabled bool) (*models.Certificate, error) {
+	certificate := &models.Certificate{
+		ID:          id,
+		Name:        name,
+		HostPattern: hostPattern,
+		Format:      format,
+		Cert:        cert,
+		Key:         key,
+		Passphrase:  passphrase,
+		CA:          ca,
+		Enabled:     enabled,
+	}
+
+	err := database.UpdateCertificate(certificate)
 	if err != nil {
 		return nil, err
 	}
-	
-	if activeEnv != nil {
-		// Parse environment variables
-		var vars map[string]string
-		err = json.Unmarshal([]byte(activeEnv.Variables), &vars)
-		if err != nil {
-			return nil, err
-		}
-		
-		// Replace variables in URL
-		for key, value := range vars {
-			url = strings.ReplaceAll(url, "{{"+key+"}}", value)
-		}
+
+	return certificate, nil
+}
+
+func (s *APIClientService) DeleteCertificate(id int) error {
+	return database.DeleteCertificate(id)
+}
+
+// Proxy methods
+func (s *APIClientService) CreateProxy(environmentID *int, proxyType, host string, port int, username, password, bypass string, enabled bool) (*models.Proxy, error) {
+	proxy := &models.Proxy{
+		EnvironmentID: environmentID,
+		Type:          proxyType,
+		Host:          host,
+		Port:          port,
+		Username:      username,
+		Password:      password,
+		Bypass:        bypass,
+		Enabled:       enabled,
 	}
-	
-	// Create HTTP request
-	req, err := http.NewRequest(method, url, strings.NewReader(body))
+
+	err := database.CreateProxy(proxy)
 	if err != nil {
 		return nil, err
 	}
-	
-	// Add headers
-	if headers != "" {
-		var headerMap map[string]string
-		err = json.Unmarshal([]byte(headers), &headerMap)
-		if err != nil {
-			return nil, err
-		}
-		
-		for key, value := range headerMap {
-			req.Header.Set(key, value)
-		}
+
+	return proxy, nil
+}
+
+func (s *APIClientService) GetProxies() ([]*models.Proxy, error) {
+	return database.GetProxies()
+}
+
+func (s *APIClientService) GetProxy(id int) (*models.Proxy, error) {
+	return database.GetProxy(id)
+}
+
+func (s *APIClientService) UpdateProxy(id int, environmentID *int, proxyType, host string, port int, username, password, bypass string, enabled bool) (*models.Proxy, error) {
+	proxy := &models.Proxy{
+		ID:            id,
+		EnvironmentID: environmentID,
+		Type:          proxyType,
+		Host:          host,
+		Port:          port,
+		Username:      username,
+		Password:      password,
+		Bypass:        bypass,
+		Enabled:       enabled,
 	}
-	
-	// Record start time
-	startTime := time.Now()
-	
-	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+
+	err := database.UpdateProxy(proxy)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	// Calculate response time
-	responseTime := time.Since(startTime).Milliseconds()
-	
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+
+	return proxy, nil
+}
+
+func (s *APIClientService) DeleteProxy(id int) error {
+	return database.DeleteProxy(id)
+}
+
+// Cookie methods
+func (s *APIClientService) CreateCookie(environmentID *int, name, value, domain, path string, expires *time.Time, secure, httpOnly, hostOnly bool) (*models.Cookie, error) {
+	cookie := &models.Cookie{
+		EnvironmentID: environmentID,
+		Name:          name,
+		Value:         value,
+		Domain:        domain,
+		Path:          path,
+		Expires:       expires,
+		Secure:        secure,
+		HttpOnly:      httpOnly,
+		HostOnly:      hostOnly,
+	}
+
+	err := database.SaveCookie(cookie)
 	if err != nil {
 		return nil, err
 	}
-	
-	// Convert headers to JSON string
-	headerBytes, err := json.Marshal(resp.Header)
+
+	return cookie, nil
+}
+
+func (s *APIClientService) GetCookies(environmentID *int) ([]*models.Cookie, error) {
+	return database.GetCookies(environmentID)
+}
+
+func (s *APIClientService) GetCookie(id int) (*models.Cookie, error) {
+	return database.GetCookie(id)
+}
+
+func (s *APIClientService) UpdateCookie(id int, environmentID *int, name, value, domain, path string, expires *time.Time, secure, httpOnly, hostOnly bool) (*models.Cookie, error) {
+	cookie := &models.Cookie{
+		ID:            id,
+		EnvironmentID: environmentID,
+		Name:          name,
+		Value:         value,
+		Domain:        domain,
+		Path:          path,
+		Expires:       expires,
+		Secure:        secure,
+		HttpOnly:      httpOnly,
+		HostOnly:      hostOnly,
+	}
+
+	err := database.UpdateCookie(cookie)
 	if err != nil {
 		return nil, err
 	}
+
+	return cookie, nil
+}
+
+func (s *APIClientService) DeleteCookie(id int) error {
+	return database.DeleteCookie(id)
+}
+
+// ClearCookies removes every cookie of the environment, or the cookies kept without an environment when nil
+func (s *APIClientService) ClearCookies(environmentID *int) error {
+	return database.ClearCookies(environmentID)
+}
+
+// ClearOAuth2Tokens forgets every cached OAuth 2.0 token, so the next requests authorize again
+func (s *APIClientService) ClearOAuth2Tokens() error {
+	return database.ClearOAuth2Tokens()
+}
+
+// SaveFileToDownloads saves a file to the user's Downloads folder
+func (s *APIClientService) SaveFileToDownloads(filename, content string) (string, error) {
+	// Get user's home directory
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	
+	// Create downloads path
+	downloadsPath := filepath.Join(homeDir, "Downloads", filename)
 	
-	// Prepare response data
-	responseData := map[string]interface{}{
-		"status":         resp.StatusCode,
-		"statusText":     resp.Status,
-		"headers":        string(headerBytes),
-		"body":           string(respBody),
-		"responseTime":   responseTime,
-		"contentType":    resp.Header.Get("Content-Type"),
+	// Write file
+	err = os.WriteFile(downloadsPath, []byte(content), 0644)
+	if err != nil {
+		return "", err
 	}
 	
-	return responseData, nil
+	return downloadsPath, nil
+}
+
+// ExecuteRequest sends the HTTP request described by spec and returns the response
+func (s *APIClientService) ExecuteRequest(ctx context.Context, spec models.RequestSpec) (*models.ExecutionResult, error) {
+	if spec.ExecutionID == "" {
+		spec.ExecutionID = uuid.NewString()
+	}
+
+	ctx, done, err := s.executions.start(ctx, spec.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return s.executeRequest(ctx, spec)
+}
+
+// CancelRequest stops the in-flight execution with the given ID
+func (s *APIClientService) CancelRequest(executionID string) error {
+	return s.executions.cancel(executionID)
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"reflect"
+	"testing"
+)
+
+func TestRequestSettingsAreSaved(t *testing.T) {
+	useTestDatabase(t)
+
+	s := &APIClientService{}
+	request, err := s.CreateRequest("Export", models.RequestTypeHTTP, "GET", "http://example.com/export", "{}", "", "none", nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := s.GetRequest(request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Settings != nil {
+		t.Errorf("got settings %+v for a request saved without any, want nil", saved.Settings)
+	}
+
+	settings := &models.RequestSettings{
+		TimeoutMs:        5000,
+		DisableRedirects: true,
+		SkipTLSVerify:    true,
+		UnixSocket:       "/var/run/docker.sock",
+		MaxResponseBytes: 1 << 20,
+		SaveToFile:       "export.csv",
+		Retry:            &models.RetryPolicy{MaxAttempts: 3, StatusCodes: []int{503}},
+	}
+	_, err = s.UpdateRequest(request.ID, "Export", models.RequestTypeHTTP, "GET", "http://example.com/export", "{}", "", "none", nil, settings, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err = s.GetRequest(request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved.Settings, settings) {
+		t.Errorf("got settings %+v, want %+v", saved.Settings, settings)
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// cookieJar is an http.CookieJar keeping its cookies in the database, scoped to one environment
+type cookieJar struct {
+	environmentID *int
+}
+
+// SetCookies stores the cookies received from u, following the domain and path rules of RFC 6265
+func (j *cookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	host := canonicalHost(u)
+	now := time.Now()
+
+	for _, c := range cookies {
+		cookie := &models.Cookie{
+			EnvironmentID: j.environmentID,
+			Name:          c.Name,
+			Value:         c.Value,
+			Path:          c.Path,
+			Secure:        c.Secure,
+			HttpOnly:      c.HttpOnly,
+		}
+
+		domain, hostOnly, ok := cookieDomain(host, c.Domain)
+		if !ok {
+			continue
+		}
+		cookie.Domain = domain
+		cookie.HostOnly = hostOnly
+
+		if cookie.Path == "" || !strings.HasPrefix(cookie.Path, "/") {
+			cookie.Path = defaultCookiePath(u.Path)
+		}
+
+		var err error
+		switch {
+		case c.MaxAge < 0:
+			err = database.DeleteCookieByName(j.environmentID, cookie.Domain, cookie.Path, cookie.Name)
+		case c.MaxAge > 0:
+			expires := now.Add(time.Duration(c.MaxAge) * time.Second)
+			cookie.Expires = &expires
+			err = database.SaveCookie(cookie)
+		case !c.Expires.IsZero() && !c.Expires.After(now):
+			err = database.DeleteCookieByName(j.environmentID, cookie.Domain, cookie.Path, cookie.Name)
+		default:
+			if !c.Expires.IsZero() {
+				expires := c.Expires
+				cookie.Expires = &expires
+			}
+			err = database.SaveCookie(cookie)
+		}
+
+		if err != nil {
+			log.Printf("failed to store cookie %q: %v", c.Name, err)
+		}
+	}
+}
+
+// Cookies returns the stored cookies to send to u, most specific path first
+func (j *cookieJar) Cookies(u *url.URL) []*http.Cookie {
+	err := database.DeleteExpiredCookies()
+	if err != nil {
+		log.Printf("failed to delete expired cookies: %v", err)
+	}
+
+	stored, err := database.GetCookies(j.environmentID)
+	if err != nil {
+		log.Printf("failed to load cookies: %v", err)
+		return nil
+	}
+
+	host := canonicalHost(u)
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	secure := u.Scheme == "https" || u.Scheme == "wss"
+
+	var matching []*models.Cookie
+	for _, cookie := range stored {
+		if cookie.Secure && !secure {
+			continue
+		}
+		if !domainMatches(host, cookie.Domain, cookie.HostOnly) || !pathMatches(path, cookie.Path) {
+			continue
+		}
+		matching = append(matching, cookie)
+	}
+
+	sort.SliceStable(matching, func(a, b int) bool {
+		return len(matching[a].Path) > len(matching[b].Path)
+	})
+
+	cookies := make([]*http.Cookie, len(matching))
+	for i, cookie := range matching {
+		cookies[i] = &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+	}
+	return cookies
+}
+
+func canonicalHost(u *url.URL) string {
+	return strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+}
+
+// cookieDomain validates the Domain attribute sent by host. Cookies without a Domain attribute
+// are host-only. Cookies for another domain or for a public suffix such as "co.uk" are rejected.
+func cookieDomain(host, attribute string) (domain string, hostOnly bool, ok bool) {
+	attribute = strings.ToLower(strings.TrimPrefix(attribute, "."))
+	if attribute == "" || attribute == host {
+		return host, attribute == "", true
+	}
+
+	if net.ParseIP(host) != nil || !strings.HasSuffix(host, "."+attribute) {
+		return "", false, false
+	}
+
+	if suffix, _ := publicsuffix.PublicSuffix(attribute); suffix == attribute {
+		return "", false, false
+	}
+
+	return attribute, false, true
+}
+
+func domainMatches(host, domain string, hostOnly bool) bool {
+	if host == domain {
+		return true
+	}
+	return !hostOnly && strings.HasSuffix(host, "."+domain)
+}
+
+func pathMatches(requestPath, cookiePath string) bool {
+	if requestPath == cookiePath {
+		return true
+	}
+	if !strings.HasPrefix(requestPath, cookiePath) {
+		return false
+	}
+	return strings.HasSuffix(cookiePath, "/") || requestPath[len(cookiePath)] == '/'
+}
+
+// defaultCookiePath returns the directory of the request path, as cookies without a Path attribute use
+func defaultCookiePath(requestPath string) string {
+	i := strings.LastIndex(requestPath, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return requestPath[:i]
+}
+
+// setCookieHeaders replaces the Cookie headers of header with cookies, dropping the ones an
+// http.Client added from its jar when sending a request with header
+func setCookieHeaders(header http.Header, cookies []string) {
+	header.Del("Cookie")
+	for _, cookie := range cookies {
+		header.Add("Cookie", cookie)
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCookieDomain(t *testing.T) {
+	tests := []struct {
+		host, attribute string
+		domain          string
+		hostOnly, ok    bool
+	}{
+		{"www.example.com", "", "www.example.com", true, true},
+		{"www.example.com", "www.example.com", "www.example.com", false, true},
+		{"www.example.com", "example.com", "example.com", false, true},
+		{"www.example.com", ".Example.COM", "example.com", false, true},
+		{"www.example.com", "other.com", "", false, false},
+		{"www.example.com", "ample.com", "", false, false},
+		{"example.com", "www.example.com", "", false, false},
+		{"www.example.co.uk", "co.uk", "", false, false},
+		{"www.example.com", "com", "", false, false},
+		{"127.0.0.1", "0.0.1", "", false, false},
+		{"127.0.0.1", "", "127.0.0.1", true, true},
+	}
+	for _, tt := range tests {
+		domain, hostOnly, ok := cookieDomain(tt.host, tt.attribute)
+		if domain != tt.domain || hostOnly != tt.hostOnly || ok != tt.ok {
+			t.Errorf("cookieDomain(%q, %q) = %q, %v, %v, want %q, %v, %v", tt.host, tt.attribute, domain, hostOnly, ok, tt.domain, tt.hostOnly, tt.ok)
+		}
+	}
+}
+
+func TestDomainMatches(t *testing.T) {
+	tests := []struct {
+		host, domain string
+		hostOnly     bool
+		want         bool
+	}{
+		{"example.com", "example.com", true, true},
+		{"www.example.com", "example.com", true, false},
+		{"www.example.com", "example.com", false, true},
+		{"wwwexample.com", "example.com", false, false},
+		{"example.com", "www.example.com", false, false},
+	}
+	for _, tt := range tests {
+		if got := domainMatches(tt.host, tt.domain, tt.hostOnly); got != tt.want {
+			t.Errorf("domainMatches(%q, %q, %v) = %v, want %v", tt.host, tt.domain, tt.hostOnly, got, tt.want)
+		}
+	}
+}
+
+func TestPathMatches(t *testing.T) {
+	tests := []struct {
+		requestPath, cookiePath string
+		want                    bool
+	}{
+		{"/", "/", true},
+		{"/docs", "/", true},
+		{"/docs", "/docs", true},
+		{"/docs/page", "/docs", true},
+		{"/docs/page", "/docs/", true},
+		{"/docsearch", "/docs", false},
+		{"/doc", "/docs", false},
+		{"/", "/docs", false},
+	}
+	for _, tt := range tests {
+		if got := pathMatches(tt.requestPath, tt.cookiePath); got != tt.want {
+			t.Errorf("pathMatches(%q, %q) = %v, want %v", tt.requestPath, tt.cookiePath, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultCookiePath(t *testing.T) {
+	tests := []struct {
+		requestPath, want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/login", "/"},
+		{"/docs/page", "/docs"},
+		{"/docs/page/", "/docs/page"},
+		{"relative", "/"},
+	}
+	for _, tt := range tests {
+		if got := defaultCookiePath(tt.requestPath); got != tt.want {
+			t.Errorf("defaultCookiePath(%q) = %q, want %q", tt.requestPath, got, tt.want)
+		}
+	}
+}
+
+func TestCookieJar(t *testing.T) {
+	useTestDatabase(t)
+
+	environment := &models.Environment{Name: "staging"}
+	err := database.CreateEnvironment(environment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jar := &cookieJar{environmentID: &environment.ID}
+	other := &cookieJar{}
+
+	origin, _ := url.Parse("https://www.example.com/docs/page")
+	jar.SetCookies(origin, []*http.Cookie{
+		{Name: "host", Value: "1"},
+		{Name: "domain", Value: "2", Domain: "example.com", Path: "/"},
+		{Name: "secure", Value: "3", Path: "/", Secure: true},
+		{Name: "api", Value: "4", Path: "/api"},
+		{Name: "public", Value: "5", Domain: "com"},
+		{Name: "foreign", Value: "6", Domain: "other.com"},
+		{Name: "expired", Value: "7", Expires: time.Now().Add(-time.Hour)},
+		{Name: "persistent", Value: "8", Path: "/", MaxAge: 3600},
+	})
+
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{"https://www.example.com/docs/page", []string{"host=1", "domain=2", "persistent=8", "secure=3"}},
+		{"https://www.example.com/docs", []string{"host=1", "domain=2", "persistent=8", "secure=3"}},
+		{"http://www.example.com/", []string{"domain=2", "persistent=8"}},
+		{"https://api.example.com/api/users", []string{"domain=2"}},
+		{"https://www.example.com/api/users", []string{"api=4", "domain=2", "persistent=8", "secure=3"}},
+		{"https://other.com/", nil},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		var got []string
+		for _, cookie := range jar.Cookies(u) {
+			got = append(got, cookie.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("cookies for %s = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if cookies := other.Cookies(origin); len(cookies) != 0 {
+		t.Errorf("got %d cookies without an environment, want none", len(cookies))
+	}
+
+	jar.SetCookies(origin, []*http.Cookie{{Name: "persistent", Path: "/", MaxAge: -1}})
+	u, _ := url.Parse("http://www.example.com/")
+	if got := jar.Cookies(u); len(got) != 1 || got[0].Name != "domain" {
+		t.Errorf("got cookies %v after deleting one, want only domain", got)
+	}
+}
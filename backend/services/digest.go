@@ -0,0 +1,298 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// digestAlgorithms are the Digest algorithms supported, weakest first
+var digestAlgorithms = map[string]struct {
+	strength int
+	newHash  func() hash.Hash
+}{
+	"MD5":         {0, md5.New},
+	"SHA-256":     {1, sha256.New},
+	"SHA-512-256": {2, sha512.New512_256},
+}
+
+// digestChallenge is a Digest challenge from a WWW-Authenticate header, as in RFC 7616
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string // without the -sess suffix
+	session   bool
+	qop       []string
+	userhash  bool
+}
+
+// sendWithDigest sends req and, when the server answers with a Digest challenge, repeats it with
+// the credentials of auth. Both exchanges are recorded in result.
+func sendWithDigest(client *http.Client, req *http.Request, auth *models.RequestAuth, result *models.ExecutionResult) (*http.Response, error) {
+	cookies := req.Header.Values("Cookie")
+	resp, err := client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge, ok := strongestDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return resp, nil
+	}
+
+	authorized, err := authorizeDigest(req, auth, challenge, rand.Text())
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	// The client adds the cookies of its jar to the authorized request again
+	setCookieHeaders(authorized.Header, cookies)
+
+	result.AuthExchanges = append(result.AuthExchanges, exchangeRecord(req, resp))
+
+	// Drain the body so the connection can be reused for the authorized request
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	resp.Body.Close()
+
+	resp, err = client.Do(authorized)
+	if err != nil {
+		return nil, err
+	}
+
+	result.AuthExchanges = append(result.AuthExchanges, exchangeRecord(authorized, resp))
+	return resp, nil
+}
+
+// authorizeDigest returns a copy of req answering challenge with the credentials of auth, using
+// cnonce as the client nonce
+func authorizeDigest(req *http.Request, auth *models.RequestAuth, challenge digestChallenge, cnonce string) (*http.Request, error) {
+	newHash := digestAlgorithms[challenge.algorithm].newHash
+	h := func(parts ...string) string {
+		sum := newHash()
+		sum.Write([]byte(strings.Join(parts, ":")))
+		return hex.EncodeToString(sum.Sum(nil))
+	}
+
+	// Prefer qop=auth, which doesn't need the body to be read an extra time
+	qop := ""
+	switch {
+	case slices.Contains(challenge.qop, "auth"):
+		qop = "auth"
+	case slices.Contains(challenge.qop, "auth-int"):
+		qop = "auth-int"
+	case len(challenge.qop) > 0:
+		return nil, fmt.Errorf("unsupported Digest qop %q", strings.Join(challenge.qop, ", "))
+	}
+
+	nc := "00000001"
+	uri := req.URL.RequestURI()
+
+	ha1 := h(auth.Username, challenge.realm, auth.Password)
+	if challenge.session {
+		ha1 = h(ha1, challenge.nonce, cnonce)
+	}
+
+	ha2 := h(req.Method, uri)
+	if qop == "auth-int" {
+		sum := newHash()
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			_, err = io.Copy(sum, body)
+			body.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		ha2 = h(req.Method, uri, hex.EncodeToString(sum.Sum(nil)))
+	}
+
+	var response string
+	if qop == "" {
+		response = h(ha1, challenge.nonce, ha2)
+	} else {
+		response = h(ha1, challenge.nonce, nc, cnonce, qop, ha2)
+	}
+
+	username := auth.Username
+	if challenge.userhash {
+		username = h(auth.Username, challenge.realm)
+	}
+
+	algorithm := challenge.algorithm
+	if challenge.session {
+		algorithm += "-sess"
+	}
+
+	params := []string{
+		"username=" + quoteDigestParam(username),
+		"realm=" + quoteDigestParam(challenge.realm),
+		"nonce=" + quoteDigestParam(challenge.nonce),
+		"uri=" + quoteDigestParam(uri),
+		"algorithm=" + algorithm,
+		"response=" + quoteDigestParam(response),
+	}
+	if qop != "" {
+		params = append(params, "qop="+qop, "nc="+nc, "cnonce="+quoteDigestParam(cnonce))
+	} else if challenge.session {
+		params = append(params, "cnonce="+quoteDigestParam(cnonce))
+	}
+	if challenge.opaque != "" {
+		params = append(params, "opaque="+quoteDigestParam(challenge.opaque))
+	}
+	if challenge.userhash {
+		params = append(params, "userhash=true")
+	}
+
+	authorized := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		authorized.Body = body
+	}
+	authorized.Header.Set("Authorization", "Digest "+strings.Join(params, ", "))
+	return authorized, nil
+}
+
+// strongestDigestChallenge picks the Digest challenge with the strongest supported algorithm
+func strongestDigestChallenge(headers []string) (digestChallenge, bool) {
+	var best digestChallenge
+	found := false
+
+	for _, header := range headers {
+		for _, challenge := range parseChallenges(header) {
+			if !strings.EqualFold(challenge.scheme, "Digest") {
+				continue
+			}
+
+			digest := digestChallenge{
+				realm:     challenge.params["realm"],
+				nonce:     challenge.params["nonce"],
+				opaque:    challenge.params["opaque"],
+				algorithm: strings.ToUpper(challenge.params["algorithm"]),
+				userhash:  strings.EqualFold(challenge.params["userhash"], "true"),
+			}
+			if digest.algorithm == "" {
+				digest.algorithm = "MD5"
+			}
+			digest.algorithm, digest.session = strings.CutSuffix(digest.algorithm, "-SESS")
+			for _, qop := range strings.Split(challenge.params["qop"], ",") {
+				if qop = strings.TrimSpace(qop); qop != "" {
+					digest.qop = append(digest.qop, qop)
+				}
+			}
+
+			algorithm, ok := digestAlgorithms[digest.algorithm]
+			if !ok || digest.nonce == "" {
+				continue
+			}
+			if !found || algorithm.strength > digestAlgorithms[best.algorithm].strength {
+				best = digest
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// authChallenge is one challenge of a WWW-Authenticate header
+type authChallenge struct {
+	scheme string
+	params map[string]string // names are lowercase
+}
+
+// parseChallenges splits a WWW-Authenticate header into its challenges, as a header may list
+// several of them separated by commas, just like their parameters
+func parseChallenges(header string) []authChallenge {
+	var challenges []authChallenge
+	s := header
+
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		token := readToken(&s)
+		if token == "" {
+			return challenges
+		}
+
+		s = strings.TrimLeft(s, " \t")
+		if !strings.HasPrefix(s, "=") {
+			challenges = append(challenges, authChallenge{scheme: token, params: map[string]string{}})
+			continue
+		}
+
+		s = strings.TrimLeft(s[1:], " \t")
+		if strings.HasPrefix(s, "=") || len(challenges) == 0 {
+			// The padding of a token68, such as the ones of Negotiate, which isn't a parameter
+			s = strings.TrimLeft(s, "=")
+			continue
+		}
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			value, s = readQuoted(s)
+		} else {
+			value = readToken(&s)
+		}
+		challenges[len(challenges)-1].params[strings.ToLower(token)] = value
+	}
+}
+
+func readToken(s *string) string {
+	end := strings.IndexAny(*s, " \t,=\"")
+	if end < 0 {
+		end = len(*s)
+	}
+	token := (*s)[:end]
+	*s = (*s)[end:]
+	return token
+}
+
+// readQuoted reads the quoted string s starts with, returning its unescaped value and the rest of s
+func readQuoted(s string) (string, string) {
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				value.WriteByte(s[i])
+			}
+		case '"':
+			return value.String(), s[i+1:]
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+	return value.String(), ""
+}
+
+func quoteDigestParam(value string) string {
+	return `"` + quoteEscaper.Replace(value) + `"`
+}
+
+// exchangeRecord records the exchange of req and resp, including the request headers
+func exchangeRecord(req *http.Request, resp *http.Response) models.ExchangeRecord {
+	return models.ExchangeRecord{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		Status:         resp.StatusCode,
+		StatusText:     resp.Status,
+		Headers:        resp.Header,
+		RequestHeaders: req.Header.Clone(),
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDigestRetrySendsJarCookiesOnce(t *testing.T) {
+	useTestDatabase(t)
+
+	var received [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Values("Cookie"))
+		if r.Header.Get("Authorization") == "" {
+			w.Header().Set("WWW-Authenticate", `Digest realm="test", nonce="abc", qop="auth"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	(&cookieJar{}).SetCookies(serverURL, []*http.Cookie{{Name: "sid", Value: "abc"}})
+
+	s := &APIClientService{}
+	result, err := s.executeRequest(context.Background(), models.RequestSpec{
+		Method:  http.MethodGet,
+		URL:     server.URL,
+		Headers: []models.KeyValue{{Key: "Cookie", Value: "user=1", Enabled: true}},
+		Auth:    &models.RequestAuth{Type: "digest", Username: "user", Password: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != http.StatusOK || len(received) != 2 {
+		t.Fatalf("got status %d after %d requests, want 200 after 2", result.Status, len(received))
+	}
+
+	want := []string{"user=1; sid=abc"}
+	for i, got := range received {
+		if !slices.Equal(got, want) {
+			t.Errorf("request %d sent Cookie %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestAuthorizeDigest(t *testing.T) {
+	// The examples of RFC 7616 section 3.9.1; the others were computed with Python's hashlib
+	const (
+		nonce  = "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v"
+		cnonce = "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"
+		opaque = "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"
+	)
+	auth := &models.RequestAuth{Type: "digest", Username: "Mufasa", Password: "Circle of Life"}
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		auth      *models.RequestAuth
+		challenge digestChallenge
+		want      map[string]string
+	}{
+		{
+			name:      "rfc 7616 md5",
+			method:    http.MethodGet,
+			auth:      auth,
+			challenge: digestChallenge{realm: "http-auth@example.org", nonce: nonce, opaque: opaque, algorithm: "MD5", qop: []string{"auth", "auth-int"}},
+			want: map[string]string{
+				"username": "Mufasa", "realm": "http-auth@example.org", "uri": "/dir/index.html", "algorithm": "MD5",
+				"qop": "auth", "nc": "00000001", "cnonce": cnonce, "opaque": opaque,
+				"response": "8ca523f5e9506fed4657c9700eebdbec",
+			},
+		},
+		{
+			name:      "rfc 7616 sha-256",
+			method:    http.MethodGet,
+			auth:      auth,
+			challenge: digestChallenge{realm: "http-auth@example.org", nonce: nonce, opaque: opaque, algorithm: "SHA-256", qop: []string{"auth", "auth-int"}},
+			want: map[string]string{
+				"algorithm": "SHA-256", "qop": "auth",
+				"response": "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1",
+			},
+		},
+		{
+			name:      "md5 without qop",
+			method:    http.MethodGet,
+			auth:      auth,
+			challenge: digestChallenge{realm: "http-auth@example.org", nonce: nonce, algorithm: "MD5"},
+			want: map[string]string{
+				"qop": "", "nc": "", "cnonce": "",
+				"response": "7b2cc3b30e75b4777ea31027084363fd",
+			},
+		},
+		{
+			name:      "sha-256-sess with auth-int",
+			method:    http.MethodPost,
+			body:      "hello",
+			auth:      auth,
+			challenge: digestChallenge{realm: "http-auth@example.org", nonce: nonce, algorithm: "SHA-256", session: true, qop: []string{"auth-int"}},
+			want: map[string]string{
+				"algorithm": "SHA-256-sess", "qop": "auth-int", "cnonce": cnonce,
+				"response": "b097196f9caf274775b76c5e5ad2221bdf75111e71e6598a385b951f12032e65",
+			},
+		},
+		{
+			name:      "sha-512-256 with userhash",
+			method:    http.MethodGet,
+			auth:      &models.RequestAuth{Type: "digest", Username: "J\u00e4s\u00f8n Doe", Password: "Secret, or not?"},
+			challenge: digestChallenge{realm: "api@example.org", nonce: "5TsQWLVdgBdmrQ0XsxbDODV+57QdFR34I9HAbC/RVvkK", algorithm: "SHA-512-256", qop: []string{"auth"}, userhash: true},
+			want: map[string]string{
+				"username": "793263caabb707a56211940d90411ea4a575adeccb7e360aeb624ed06ece9b0b", "userhash": "true",
+				"response": "feec978fafcedb19692dacc107d17579c74953c9aefbf03f57dec64a386662e7",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "http://www.example.org/dir/index.html", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.challenge.userhash {
+				req.URL.Path = "/doe.json"
+			}
+
+			authorized, err := authorizeDigest(req, tt.auth, tt.challenge, cnonce)
+			if err != nil {
+				t.Fatal(err)
+			}
+			challenges := parseChallenges(authorized.Header.Get("Authorization"))
+			if len(challenges) != 1 || challenges[0].scheme != "Digest" {
+				t.Fatalf("got Authorization %q, want a Digest one", authorized.Header.Get("Authorization"))
+			}
+			for name, want := range tt.want {
+				if got := challenges[0].params[name]; got != want {
+					t.Errorf("got %s %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizeDigestRejectsUnsupportedQop(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://example.org/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = authorizeDigest(req, &models.RequestAuth{}, digestChallenge{nonce: "abc", algorithm: "MD5", qop: []string{"auth-conf"}}, "xyz")
+	if err == nil {
+		t.Error("got no error for qop auth-conf")
+	}
+}
+
+func TestStrongestDigestChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   []string
+		algorithm string
+		session   bool
+		ok        bool
+	}{
+		{"default md5", []string{`Digest realm="a", nonce="n"`}, "MD5", false, true},
+		{"strongest across headers", []string{`Digest realm="a", nonce="n", algorithm=MD5`, `Digest realm="a", nonce="n", algorithm=SHA-256`}, "SHA-256", false, true},
+		{"strongest in one header", []string{`Digest realm="a", nonce="n", algorithm=SHA-512-256, Digest realm="a", nonce="n", algorithm=SHA-256`}, "SHA-512-256", false, true},
+		{"session", []string{`Digest realm="a", nonce="n", algorithm=sha-256-sess`}, "SHA-256", true, true},
+		{"unsupported algorithm", []string{`Digest realm="a", nonce="n", algorithm=SHA-1`}, "", false, false},
+		{"no nonce", []string{`Digest realm="a"`}, "", false, false},
+		{"other scheme", []string{`Basic realm="a"`}, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge, ok := strongestDigestChallenge(tt.headers)
+			if ok != tt.ok || challenge.algorithm != tt.algorithm || challenge.session != tt.session {
+				t.Errorf("got %q, session %v, %v, want %q, session %v, %v", challenge.algorithm, challenge.session, ok, tt.algorithm, tt.session, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseChallenges(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []authChallenge
+	}{
+		{`Basic realm="simple"`, []authChallenge{{"Basic", map[string]string{"realm": "simple"}}}},
+		{
+			`Newauth realm="apps", type=1, title="Login to \"apps\"", Basic realm="simple"`,
+			[]authChallenge{
+				{"Newauth", map[string]string{"realm": "apps", "type": "1", "title": `Login to "apps"`}},
+				{"Basic", map[string]string{"realm": "simple"}},
+			},
+		},
+		{`Negotiate abc123==, Digest Nonce="n", QOP="auth,auth-int"`, []authChallenge{
+			{"Negotiate", map[string]string{}},
+			{"Digest", map[string]string{"nonce": "n", "qop": "auth,auth-int"}},
+		}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got := parseChallenges(tt.header)
+		if len(got) != len(tt.want) {
+			t.Errorf("parseChallenges(%q) returned %d challenges, want %d", tt.header, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if got[i].scheme != tt.want[i].scheme || !maps.Equal(got[i].params, tt.want[i].params) {
+				t.Errorf("parseChallenges(%q)[%d] = %v, want %v", tt.header, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
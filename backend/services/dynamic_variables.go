@@ -0,0 +1,56 @@
+package services
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	firstNames = []string{"Ana", "Bruno", "Carla", "Daniel", "Elena", "Felipe", "Grace", "Hugo", "Isabel", "James", "Karen", "Lucas", "Maria", "Noah", "Olivia", "Pedro", "Rafael", "Sofia", "Thomas", "Yara"}
+	lastNames  = []string{"Almeida", "Brown", "Costa", "Davis", "Ferreira", "Garcia", "Johnson", "Lima", "Martins", "Miller", "Oliveira", "Pereira", "Rodrigues", "Santos", "Silva", "Smith", "Souza", "Taylor", "Williams", "Wilson"}
+	domains    = []string{"example.com", "example.net", "example.org", "test.com", "mail.test"}
+)
+
+const alphaNumeric = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// dynamicVariables are the built-in {{$name}} generators. Each one is evaluated once per execution.
+var dynamicVariables = map[string]func() string{
+	"$guid":       func() string { return uuid.NewString() },
+	"$uuid":       func() string { return uuid.NewString() },
+	"$randomUUID": func() string { return uuid.NewString() },
+	"$timestamp": func() string {
+		return strconv.FormatInt(time.Now().Unix(), 10)
+	},
+	"$isoTimestamp": func() string {
+		return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	},
+	"$isoDate": func() string {
+		return time.Now().UTC().Format("2006-01-02")
+	},
+	"$randomInt": func() string {
+		return strconv.Itoa(rand.IntN(1001))
+	},
+	"$randomBoolean": func() string {
+		return strconv.FormatBool(rand.IntN(2) == 1)
+	},
+	"$randomAlphaNumeric": func() string {
+		return string(alphaNumeric[rand.IntN(len(alphaNumeric))])
+	},
+	"$randomFirstName": func() string { return randomItem(firstNames) },
+	"$randomLastName":  func() string { return randomItem(lastNames) },
+	"$randomFullName": func() string {
+		return randomItem(firstNames) + " " + randomItem(lastNames)
+	},
+	"$randomEmail": func() string {
+		user := strings.ToLower(randomItem(firstNames) + "." + randomItem(lastNames))
+		return user + strconv.Itoa(rand.IntN(100)) + "@" + randomItem(domains)
+	},
+}
+
+func randomItem(items []string) string {
+	return items[rand.IntN(len(items))]
+}
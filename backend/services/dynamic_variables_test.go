@@ -0,0 +1,83 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDynamicVariablesAreGeneratedOncePerExecution(t *testing.T) {
+	useTestDatabase(t)
+
+	// The server records what it got and fails the first attempt of every execution
+	type received struct{ path, header, body string }
+	var mu sync.Mutex
+	var requests []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, received{r.URL.Path, r.Header.Get("X-Request-ID"), string(body)})
+		count := len(requests)
+		mu.Unlock()
+		if count%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	s := &APIClientService{}
+	spec := models.RequestSpec{
+		Method:   http.MethodPost,
+		URL:      server.URL + "/orders/{{$guid}}/{{ $randomInt }}",
+		Headers:  []models.KeyValue{{Key: "X-Request-ID", Value: "{{$guid}}/{{$randomInt}}", Enabled: true}},
+		Body:     "{{ $guid }}/{{$randomInt}}",
+		BodyType: "raw",
+		Settings: models.RequestSettings{
+			Retry: &models.RetryPolicy{MaxAttempts: 2, StatusCodes: []int{http.StatusServiceUnavailable}, InitialDelayMs: 1},
+		},
+	}
+
+	execute := func() string {
+		t.Helper()
+		result, err := s.executeRequest(context.Background(), spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Status != http.StatusOK {
+			t.Fatalf("got status %d, want 200 after a retry", result.Status)
+		}
+		guid, randomInt := result.GeneratedVariables["$guid"], result.GeneratedVariables["$randomInt"]
+		if len(result.GeneratedVariables) != 2 || guid == "" || randomInt == "" {
+			t.Fatalf("got generated variables %v, want $guid and $randomInt", result.GeneratedVariables)
+		}
+		return guid + "/" + randomInt
+	}
+
+	first := execute()
+	second := execute()
+	if first == second {
+		t.Errorf("two executions generated the same values %q", first)
+	}
+
+	// Every part of every attempt of an execution got the values it generated
+	if len(requests) != 4 {
+		t.Fatalf("got %d requests, want 4", len(requests))
+	}
+	for i, request := range requests {
+		want := first
+		if i >= 2 {
+			want = second
+		}
+		if request.path != "/orders/"+want || request.header != want || request.body != want {
+			t.Errorf("attempt %d got path %q, header %q and body %q, want %q in each", i+1, request.path, request.header, request.body, want)
+		}
+		if strings.Contains(request.path+request.header+request.body, "{{") {
+			t.Errorf("attempt %d sent a placeholder", i+1)
+		}
+	}
+}
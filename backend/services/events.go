@@ -0,0 +1,143 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// EventEmitter publishes an event to the frontend, like application.EventManager.Emit does
+type EventEmitter func(name string, data ...any)
+
+// Events emitted to the frontend
+const (
+	EventExecutionProgress   = "execution:progress"
+	EventAuthorizationPrompt = "execution:authorize" // the frontend opens the URL in the browser
+	EventServerSentEvent     = "execution:sse"
+	EventGRPCMessage         = "execution:grpc"    // sent and received messages of gRPC calls
+	EventGraphQLSubscription = "execution:graphql" // events of GraphQL subscriptions
+	EventWebSocketMessage    = "websocket:message" // sent and received messages and control frames
+	EventWebSocketClosed     = "websocket:closed"
+)
+
+// progressInterval is the minimum time between two progress events of a transfer
+const progressInterval = 100 * time.Millisecond
+
+// emit publishes an event if the service was created with an emitter
+func (s *APIClientService) emit(name string, data any) {
+	if s.events != nil {
+		s.events(name, data)
+	}
+}
+
+// progressReader wraps a body and reports how much of it was read through throttled progress events
+type progressReader struct {
+	reader   io.Reader
+	progress models.TransferProgress
+	report   func(models.TransferProgress)
+
+	mu        sync.Mutex
+	start     time.Time
+	lastEmit  time.Time
+	lastBytes int64
+}
+
+func newProgressReader(reader io.Reader, executionID, direction string, total int64, report func(models.TransferProgress)) *progressReader {
+	now := time.Now()
+	return &progressReader{
+		reader: reader,
+		progress: models.TransferProgress{
+			ExecutionID: executionID,
+			Direction:   direction,
+			Total:       total,
+		},
+		report:   report,
+		start:    now,
+		lastEmit: now,
+	}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+
+	r.mu.Lock()
+	r.progress.Bytes += int64(n)
+	// Readers of bodies with a known length may stop before reading EOF
+	done := err == io.EOF || (r.progress.Total > 0 && r.progress.Bytes >= r.progress.Total)
+	if done || time.Since(r.lastEmit) >= progressInterval {
+		r.emitLocked(done)
+	} else {
+		r.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// finish reports the end of a transfer stopped before its end, e.g. a truncated body
+func (r *progressReader) finish() {
+	r.mu.Lock()
+	r.emitLocked(true)
+}
+
+// emitLocked reports the progress and unlocks r.mu. Nothing is reported anymore once done.
+func (r *progressReader) emitLocked(done bool) {
+	if r.progress.Done {
+		r.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	since, bytes := r.lastEmit, r.progress.Bytes-r.lastBytes
+	if done {
+		since, bytes = r.start, r.progress.Bytes
+	}
+	if elapsed := now.Sub(since).Seconds(); elapsed > 0 {
+		r.progress.BytesPerSecond = float64(bytes) / elapsed
+	}
+	r.lastEmit, r.lastBytes = now, r.progress.Bytes
+	r.progress.Done = done
+	progress := r.progress
+	r.mu.Unlock()
+
+	r.report(progress)
+}
+
+// Close closes the wrapped body, so request bodies can be wrapped too
+func (r *progressReader) Close() error {
+	if closer, ok := r.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// uploadProgressTransport reports the progress of the request bodies sent through next. Retries,
+// redirects and authentication challenges send the body again, and report its progress from the
+// start. Bodies read again through GetBody, e.g. to sign them, report nothing.
+type uploadProgressTransport struct {
+	next        http.RoundTripper
+	executionID string
+	report      func(models.TransferProgress)
+}
+
+func (t *uploadProgressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.next.RoundTrip(req)
+	}
+
+	// Round trippers mustn't modify the request, so the body is wrapped in a copy
+	sent := req.Clone(req.Context())
+	sent.Body = newProgressReader(req.Body, t.executionID, "upload", req.ContentLength, t.report)
+	if req.GetBody != nil {
+		// The transport opens the body again to resend it after a connection was lost
+		sent.GetBody = func() (io.ReadCloser, error) {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			return newProgressReader(body, t.executionID, "upload", req.ContentLength, t.report), nil
+		}
+	}
+	return t.next.RoundTrip(sent)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestUploadProgressReportsEverySend(t *testing.T) {
+	useTestDatabase(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		http.Redirect(w, r, "/b", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var uploads []models.TransferProgress
+	s := NewAPIClientService(func(name string, data ...any) {
+		if progress, ok := data[0].(models.TransferProgress); ok && name == EventExecutionProgress && progress.Direction == "upload" {
+			mu.Lock()
+			uploads = append(uploads, progress)
+			mu.Unlock()
+		}
+	})
+
+	// Signing reads the body through GetBody to hash it, which mustn't be reported
+	result, err := s.executeRequest(context.Background(), models.RequestSpec{
+		ExecutionID: "upload",
+		Method:      http.MethodPost,
+		URL:         server.URL + "/a",
+		BodyType:    "raw",
+		Body:        "hello",
+		Auth: &models.RequestAuth{
+			Type: "aws-sigv4",
+			AWS:  &models.AWSConfig{AccessKey: "key", SecretKey: "secret", Region: "us-east-1", Service: "execute-api"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != http.StatusOK {
+		t.Fatalf("got status %d, want 200", result.Status)
+	}
+
+	var done int
+	for _, progress := range uploads {
+		if progress.ExecutionID != "upload" || progress.Total != 5 {
+			t.Errorf("got progress %+v, want the 5 bytes of execution upload", progress)
+		}
+		if progress.Done {
+			done++
+			if progress.Bytes != 5 {
+				t.Errorf("got %d bytes sent when done, want 5", progress.Bytes)
+			}
+		}
+	}
+	if done != 2 {
+		t.Errorf("got %d finished uploads, want one for the request and one for its redirect", done)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultRequestTimeout applies when a request doesn't configure its own timeout
+const defaultRequestTimeout = 30 * time.Second
+
+// errExecutionCancelled is the cancellation cause of executions stopped through CancelRequest
+var errExecutionCancelled = errors.New("execution cancelled")
+
+// executionRegistry tracks in-flight executions so they can be cancelled by ID
+type executionRegistry struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+// start derives the context of an execution from parent and registers it under id, which mustn't
+// be the one of an execution still in flight. The returned function must be called once the
+// execution is over.
+func (r *executionRegistry) start(parent context.Context, id string) (context.Context, func(), error) {
+	r.mu.Lock()
+	if _, ok := r.cancels[id]; ok {
+		r.mu.Unlock()
+		return nil, nil, fmt.Errorf("a request with execution ID %q is already in flight", id)
+	}
+	if r.cancels == nil {
+		r.cancels = map[string]context.CancelCauseFunc{}
+	}
+	ctx, cancelCause := context.WithCancelCause(parent)
+	r.cancels[id] = cancelCause
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.cancels, id)
+		r.mu.Unlock()
+		cancelCause(nil)
+	}, nil
+}
+
+// cancel stops the execution registered under id
+func (r *executionRegistry) cancel(id string) error {
+	r.mu.Lock()
+	cancelCause, ok := r.cancels[id]
+	r.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no request in flight with execution ID %q", id)
+	}
+	cancelCause(errExecutionCancelled)
+	return nil
+}
+
+// withTimeout is like context.WithTimeout, except the timeout can be lifted with stop, as streams
+// outlive the request timeout. Once it fires, context.Cause of the context is context.DeadlineExceeded.
+func withTimeout(parent context.Context, timeout time.Duration) (ctx context.Context, cancel context.CancelFunc, stop func() bool) {
+	ctx, cancelCause := context.WithCancelCause(parent)
+	timer := time.AfterFunc(timeout, func() {
+		cancelCause(context.DeadlineExceeded)
+	})
+
+	return ctx, func() {
+		timer.Stop()
+		cancelCause(nil)
+	}, timer.Stop
+}
+
+// requestTimeout returns the timeout configured in milliseconds, or the default one
+func requestTimeout(timeoutMs int) time.Duration {
+	if timeoutMs <= 0 {
+		return defaultRequestTimeout
+	}
+	return time.Duration(timeoutMs) * time.Millisecond
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExecutionRegistryRejectsDuplicateIDs(t *testing.T) {
+	var registry executionRegistry
+
+	ctx, done, err := registry.start(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = registry.start(context.Background(), "a")
+	if err == nil {
+		t.Fatal("starting an execution with the ID of one in flight succeeded")
+	}
+
+	// The rejected execution doesn't take over the one in flight
+	err = registry.cancel("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(context.Cause(ctx), errExecutionCancelled) {
+		t.Errorf("got cause %v, want errExecutionCancelled", context.Cause(ctx))
+	}
+
+	done()
+	if err := registry.cancel("a"); err == nil {
+		t.Error("cancelling an execution that's over succeeded")
+	}
+
+	// IDs can be used again once their execution is over
+	_, done, err = registry.start(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done()
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultContentTypes maps body types to the Content-Type sent when the request doesn't set one
+var defaultContentTypes = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+}
+
+// executeRequest runs spec against the network and collects the response.
+// Executions stopped by ctx are reported through the result's outcome rather than as an error.
+func (s *APIClientService) executeRequest(ctx context.Context, spec models.RequestSpec) (*models.ExecutionResult, error) {
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := environmentVariables(activeEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newVariableResolver(vars)
+	spec, err = resolver.resolveSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	socket, target, err := unixSocketURL(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	if socket != "" {
+		spec.URL = target
+		spec.Settings.UnixSocket = socket
+	}
+
+	req, err := buildHTTPRequest(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ExecutionResult{
+		ExecutionID:         spec.ExecutionID,
+		UnresolvedVariables: resolver.unresolvedNames(),
+		GeneratedVariables:  resolver.generated,
+	}
+
+	certificates, err := database.GetCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	var environmentID *int
+	if activeEnv != nil {
+		environmentID = &activeEnv.ID
+	}
+	proxy, err := database.GetEffectiveProxy(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := newExecutionTransport(spec.Settings, certificates, proxy)
+	if err != nil {
+		return nil, err
+	}
+	defer transport.close()
+
+	client := &http.Client{
+		Transport: &uploadProgressTransport{
+			next:        transport,
+			executionID: spec.ExecutionID,
+			report: func(progress models.TransferProgress) {
+				s.emit(EventExecutionProgress, progress)
+			},
+		},
+		Jar: &cookieJar{environmentID: environmentID},
+		// Redirects are followed by sendFollowingRedirects so every hop can be recorded
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// The timeout starts once the request is authorized, so it doesn't cover logging in through the browser
+	if spec.Auth != nil && spec.Auth.Type == "oauth2" {
+		// The token endpoint is reached over the network, not through the socket the request is sent to
+		tokenTransport := transport
+		if spec.Settings.UnixSocket != "" {
+			settings := spec.Settings
+			settings.UnixSocket = ""
+			tokenTransport, err = newExecutionTransport(settings, certificates, proxy)
+			if err != nil {
+				return nil, err
+			}
+			defer tokenTransport.close()
+		}
+
+		tokenClient := &http.Client{Transport: tokenTransport, Timeout: requestTimeout(spec.Settings.TimeoutMs)}
+		token, err := s.oauth2Token(ctx, spec.ExecutionID, spec.Auth.OAuth2, tokenClient)
+		if err != nil {
+			return interruptedResult(ctx, result, nil, err)
+		}
+		req.Header.Set("Authorization", token.Type()+" "+token.AccessToken)
+	}
+
+	policy, err := retryPolicy(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := newTimingTrace()
+	resp, ctx, cancel, err := sendWithRetries(ctx, client, req, spec, policy, trace, result)
+	defer cancel()
+	result.Proxy = transport.usedProxy()
+	if err != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+	defer resp.Body.Close()
+
+	result.Status = resp.StatusCode
+	result.StatusText = resp.Status
+	result.Protocol = resp.Proto
+	result.URL = resp.Request.URL.String()
+	result.Headers = resp.Header
+	result.ContentType = resp.Header.Get("Content-Type")
+	result.HeadersSize = headersSize(resp.Header)
+
+	if streamsEvents(resp, spec.Settings) {
+		// Streams usually end by being stopped, which still saves their transcript
+		err = s.readEventStream(resp, spec.Settings, result)
+		result.Timings = trace.timings(time.Now())
+		err = errors.Join(err, saveHistory(spec.RequestID, result, result.Events))
+	} else {
+		err = s.readResponseBody(resp, spec.Settings, result)
+	}
+	if err != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+	result.Timings = trace.timings(time.Now())
+	// Trailers are only known once the body was read
+	if len(resp.Trailer) > 0 {
+		result.Trailers = resp.Trailer
+	}
+	if spec.BodyType == "graphql" {
+		result.GraphQLErrors = graphqlErrors(result)
+	}
+	result.SOAPFault = soapFault(result)
+
+	err = saveHistory(spec.RequestID, result, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Outcome = models.OutcomeCompleted
+	return result, nil
+}
+
+// saveHistory saves the response of result, with its timings, to the history of requestID. transcript
+// holds the messages of streamed responses, the events of an event stream or the messages of a gRPC call,
+// and is nil for others. Requests that weren't saved have no history.
+func saveHistory(requestID *int, result *models.ExecutionResult, transcript any) error {
+	if requestID == nil {
+		return nil
+	}
+
+	headers, err := json.Marshal(result.Headers)
+	if err != nil {
+		return err
+	}
+	timings, err := json.Marshal(result.Timings)
+	if err != nil {
+		return err
+	}
+	var transcriptJSON []byte
+	if transcript != nil {
+		transcriptJSON, err = json.Marshal(transcript)
+		if err != nil {
+			return err
+		}
+	}
+
+	history := &models.RequestHistory{
+		RequestID:       *requestID,
+		ResponseStatus:  result.Status,
+		ResponseTime:    int(result.Timings.Total),
+		ResponseBody:    result.Body,
+		ResponseHeaders: string(headers),
+		Timings:         string(timings),
+		Transcript:      string(transcriptJSON),
+	}
+	return database.CreateRequestHistory(history)
+}
+
+// interruptedResult reports executions stopped by a cancellation or a timeout of ctx.
+// Any other failure is returned as an error. trace is nil when no request was sent yet.
+func interruptedResult(ctx context.Context, result *models.ExecutionResult, trace *timingTrace, err error) (*models.ExecutionResult, error) {
+	switch {
+	case errors.Is(context.Cause(ctx), context.DeadlineExceeded):
+		result.Outcome = models.OutcomeTimedOut
+		result.Error = "request timed out"
+	case ctx.Err() != nil:
+		result.Outcome = models.OutcomeCancelled
+		result.Error = context.Cause(ctx).Error()
+	default:
+		return nil, err
+	}
+
+	if trace != nil {
+		result.Timings = trace.timings(time.Now())
+	}
+	return result, nil
+}
+
+// environmentVariables parses the variables of env, which may be nil
+func environmentVariables(env *models.Environment) (map[string]string, error) {
+	var vars map[string]string
+	if env == nil || env.Variables == "" {
+		return vars, nil
+	}
+
+	err := json.Unmarshal([]byte(env.Variables), &vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// buildHTTPRequest turns spec into an *http.Request bound to ctx
+func buildHTTPRequest(ctx context.Context, spec models.RequestSpec) (*http.Request, error) {
+	target, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	method := strings.ToUpper(spec.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	// Append query params after the ones already present in the URL
+	query := url.Values{}
+	for _, param := range spec.QueryParams {
+		if param.Enabled && param.Key != "" {
+			query.Add(param.Key, param.Value)
+		}
+	}
+	// GraphQL operations sent with GET go in the query string instead of the body
+	if spec.BodyType == "graphql" && method == http.MethodGet {
+		err = addGraphQLQuery(query, spec.GraphQL)
+		if err != nil {
+			return nil, err
+		}
+		spec.BodyType = "none"
+	}
+	if len(query) > 0 {
+		if target.RawQuery != "" {
+			target.RawQuery += "&"
+		}
+		target.RawQuery += query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, header := range spec.Headers {
+		if header.Enabled && header.Key != "" {
+			req.Header.Add(header.Key, header.Value)
+		}
+	}
+
+	err = applyAuth(req, spec.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := newRequestBody(spec)
+	if err != nil || body == nil {
+		return req, err
+	}
+
+	req.Body, err = body.open()
+	if err != nil {
+		return nil, err
+	}
+	req.GetBody = body.open
+	req.ContentLength = body.length
+
+	// The multipart boundary is only known here, so it always overrides the request's Content-Type
+	if spec.BodyType == "form-data" || (req.Header.Get("Content-Type") == "" && body.contentType != "") {
+		req.Header.Set("Content-Type", body.contentType)
+	}
+
+	return req, nil
+}
+
+// headersSize approximates the size of the headers on the wire
+func headersSize(header http.Header) int64 {
+	var size int64
+	for key, values := range header {
+		for _, value := range values {
+			size += int64(len(key) + len(": ") + len(value) + len("\r\n"))
+		}
+	}
+	return size
+}
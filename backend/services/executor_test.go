@@ -0,0 +1,56 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecuteRequestSavesHistoryWithTimings(t *testing.T) {
+	useTestDatabase(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	s := &APIClientService{}
+	spec := models.RequestSpec{Method: http.MethodGet, URL: server.URL}
+	_, err := s.executeRequest(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestID := 3
+	spec.RequestID = &requestID
+	result, err := s.executeRequest(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the execution of the saved request is in its history
+	history, err := database.GetRequestHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("got %d history entries, want 1", len(history))
+	}
+	entry := history[0]
+	if entry.RequestID != requestID || entry.ResponseStatus != http.StatusOK || entry.ResponseBody != "hello" || entry.Transcript != "" {
+		t.Errorf("got history entry %+v, want the 200 response of request %d", entry, requestID)
+	}
+
+	var timings models.ExecutionTimings
+	if err := json.Unmarshal([]byte(entry.Timings), &timings); err != nil {
+		t.Fatalf("invalid timings %q: %v", entry.Timings, err)
+	}
+	if timings != result.Timings || timings.Total <= 0 {
+		t.Errorf("got timings %+v, want %+v", timings, result.Timings)
+	}
+}
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Subprotocols of GraphQL subscriptions over WebSocket, the current graphql-ws protocol first
+const (
+	graphqlTransportWS = "graphql-transport-ws"
+	graphqlWSLegacy    = "graphql-ws" // subscriptions-transport-ws, which reused the graphql-ws name
+)
+
+// graphqlSubscriptionID is the ID of the single operation of a subscription connection
+const graphqlSubscriptionID = "1"
+
+// graphqlRequest is the JSON form of a GraphQL operation, as sent over HTTP and WebSocket
+type graphqlRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	OperationName string          `json:"operationName,omitempty"`
+}
+
+func newGraphQLRequest(body *models.GraphQLBody) (*graphqlRequest, error) {
+	if body == nil {
+		return nil, errors.New("the graphql body type needs a GraphQL operation")
+	}
+
+	request := &graphqlRequest{Query: body.Query, OperationName: body.OperationName}
+	variables, err := graphqlObject("variables", body.Variables)
+	if err != nil {
+		return nil, err
+	}
+	request.Variables = variables
+	return request, nil
+}
+
+// graphqlObject validates the JSON object given for a GraphQL field, returning it compacted,
+// or nil when value is empty
+func graphqlObject(name, value string) (json.RawMessage, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var object map[string]any
+	err := json.Unmarshal([]byte(value), &object)
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL %s must be a JSON object: %w", name, err)
+	}
+
+	var compacted bytes.Buffer
+	err = json.Compact(&compacted, []byte(value))
+	if err != nil {
+		return nil, err
+	}
+	return compacted.Bytes(), nil
+}
+
+// newGraphQLBody encodes a GraphQL operation as a JSON body
+func newGraphQLBody(body *models.GraphQLBody) (*requestBody, error) {
+	request, err := newGraphQLRequest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return newStringBody(string(data), "application/json"), nil
+}
+
+// addGraphQLQuery encodes a GraphQL operation sent with GET as query parameters
+func addGraphQLQuery(query url.Values, body *models.GraphQLBody) error {
+	request, err := newGraphQLRequest(body)
+	if err != nil {
+		return err
+	}
+
+	query.Set("query", request.Query)
+	if request.Variables != nil {
+		query.Set("variables", string(request.Variables))
+	}
+	if request.OperationName != "" {
+		query.Set("operationName", request.OperationName)
+	}
+	return nil
+}
+
+// graphqlErrors returns the errors listed by the GraphQL response in the body of result, if any.
+// Bodies that aren't GraphQL responses have none.
+func graphqlErrors(result *models.ExecutionResult) []models.GraphQLError {
+	if result.BodyEncoding != BodyEncodingText || result.Truncated {
+		return nil
+	}
+
+	var response struct {
+		Errors []models.GraphQLError `json:"errors"`
+	}
+	if json.Unmarshal([]byte(result.Body), &response) != nil {
+		return nil
+	}
+	return response.Errors
+}
+
+// graphqlIntrospectionQuery fetches the types of a schema, with their fields and arguments.
+// Type references are nested deep enough for lists of non-null lists of non-null types.
+const graphqlIntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+          }
+        }
+      }
+    }
+  }
+}`
+
+// IntrospectGraphQL returns the schema of the GraphQL endpoint of spec, as the data of an introspection
+// query. Schemas are cached per endpoint, and only fetched again when refresh is set. The headers, auth
+// and settings of spec are used to send the query.
+func (s *APIClientService) IntrospectGraphQL(ctx context.Context, spec models.RequestSpec, refresh bool) (*models.GraphQLSchema, error) {
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	vars, err := environmentVariables(activeEnv)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := newVariableResolver(vars).resolve(spec.URL)
+
+	if !refresh {
+		schema, err := database.GetGraphQLSchema(endpoint)
+		if err != nil || schema != nil {
+			return schema, err
+		}
+	}
+
+	if spec.ExecutionID == "" {
+		spec.ExecutionID = uuid.NewString()
+	}
+	ctx, done, err := s.executions.start(ctx, spec.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if spec.Method != http.MethodGet {
+		spec.Method = http.MethodPost
+	}
+	spec.BodyType = "graphql"
+	spec.GraphQL = &models.GraphQLBody{Query: graphqlIntrospectionQuery, OperationName: "IntrospectionQuery"}
+	spec.Settings.SaveToFile = ""
+	spec.Settings.Retry = nil
+	spec.RequestID = nil
+
+	result, err := s.executeRequest(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if result.Outcome != models.OutcomeCompleted {
+		return nil, errors.New(result.Error)
+	}
+	if len(result.GraphQLErrors) > 0 {
+		return nil, fmt.Errorf("introspection failed: %s", result.GraphQLErrors[0].Message)
+	}
+	if result.Status < 200 || result.Status >= 300 {
+		return nil, fmt.Errorf("introspection failed with %s", result.StatusText)
+	}
+	if result.Truncated {
+		return nil, errors.New("the schema exceeds the maximum response size")
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err = json.Unmarshal([]byte(result.Body), &response)
+	if err != nil || len(response.Data) == 0 || string(response.Data) == "null" {
+		return nil, errors.New("the endpoint didn't answer with a GraphQL schema")
+	}
+
+	schema := &models.GraphQLSchema{Endpoint: endpoint, Schema: string(response.Data)}
+	err = database.SaveGraphQLSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ClearGraphQLSchema forgets the schema cached for endpoint
+func (s *APIClientService) ClearGraphQLSchema(endpoint string) error {
+	return database.DeleteGraphQLSchema(endpoint)
+}
+
+// graphqlMessage is a message of the GraphQL over WebSocket protocols
+type graphqlMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// graphqlSubscription is a GraphQL operation run over a WebSocket connection, in either protocol
+type graphqlSubscription struct {
+	conn   *websocket.Conn
+	legacy bool // subscriptions-transport-ws rather than graphql-transport-ws
+
+	mu sync.Mutex // serializes writes
+}
+
+func (sub *graphqlSubscription) write(message graphqlMessage) error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	err := sub.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	if err != nil {
+		return err
+	}
+	return sub.conn.WriteJSON(message)
+}
+
+// start sends the operation, ack having been received
+func (sub *graphqlSubscription) start(request *graphqlRequest) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	messageType := "subscribe"
+	if sub.legacy {
+		messageType = "start"
+	}
+	return sub.write(graphqlMessage{ID: graphqlSubscriptionID, Type: messageType, Payload: payload})
+}
+
+// stop tells the server the operation is no longer wanted, then closes the connection
+func (sub *graphqlSubscription) stop() {
+	messageType := "complete"
+	if sub.legacy {
+		messageType = "stop"
+	}
+	sub.write(graphqlMessage{ID: graphqlSubscriptionID, Type: messageType})
+
+	sub.mu.Lock()
+	sub.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(webSocketWriteTimeout))
+	sub.mu.Unlock()
+	sub.conn.Close()
+}
+
+// ExecuteGraphQLSubscription runs the GraphQL subscription of spec over WebSocket, with the graphql-ws
+// protocol or its subscriptions-transport-ws predecessor. Events are published as EventGraphQLSubscription
+// events until the server completes the subscription or it's stopped with CancelRequest.
+func (s *APIClientService) ExecuteGraphQLSubscription(ctx context.Context, spec models.RequestSpec) (*models.ExecutionResult, error) {
+	if spec.ExecutionID == "" {
+		spec.ExecutionID = uuid.NewString()
+	}
+
+	ctx, done, err := s.executions.start(ctx, spec.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return s.executeGraphQLSubscription(ctx, spec)
+}
+
+func (s *APIClientService) executeGraphQLSubscription(ctx context.Context, spec models.RequestSpec) (*models.ExecutionResult, error) {
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	vars, err := environmentVariables(activeEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newVariableResolver(vars)
+	spec, err = resolver.resolveSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := newGraphQLRequest(spec.GraphQL)
+	if err != nil {
+		return nil, err
+	}
+	connectionParams, err := graphqlObject("connection params", spec.GraphQL.ConnectionParams)
+	if err != nil {
+		return nil, err
+	}
+	target, err := webSocketURL(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The headers and auth of the handshake are the ones of an HTTP request
+	spec.BodyType = "none"
+	req, err := buildHTTPRequest(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header.Clone()
+	header.Del("Sec-Websocket-Protocol")
+
+	result := &models.ExecutionResult{
+		ExecutionID:         spec.ExecutionID,
+		URL:                 target.String(),
+		UnresolvedVariables: resolver.unresolvedNames(),
+		GeneratedVariables:  resolver.generated,
+	}
+
+	// The timeout covers the handshake and the server acknowledging the connection
+	ctx, cancel, stopTimeout := withTimeout(ctx, requestTimeout(spec.Settings.TimeoutMs))
+	defer cancel()
+
+	trace := newTimingTrace()
+	traced := httptrace.WithClientTrace(ctx, trace.clientTrace())
+	conn, resp, err := dialWebSocket(traced, activeEnv, target, header, []string{graphqlTransportWS, graphqlWSLegacy}, spec.Settings.TimeoutMs, spec.Settings.SkipTLSVerify)
+	if err != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+
+	result.Status = resp.StatusCode
+	result.StatusText = resp.Status
+	result.Protocol = conn.Subprotocol()
+	result.Headers = resp.Header
+	result.HeadersSize = headersSize(resp.Header)
+
+	sub := &graphqlSubscription{conn: conn, legacy: conn.Subprotocol() == graphqlWSLegacy}
+	if result.Protocol == "" {
+		result.Protocol = graphqlTransportWS
+	}
+
+	// Reads are unblocked by closing the connection once ctx is done
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.stop()
+		case <-finished:
+			conn.Close()
+		}
+	}()
+
+	var payloads []json.RawMessage
+	record := func(eventType string, payload json.RawMessage) {
+		event := models.GraphQLSubscriptionEvent{
+			ExecutionID: spec.ExecutionID,
+			Type:        eventType,
+			Payload:     string(payload),
+			ReceivedAt:  time.Now(),
+		}
+		result.SubscriptionEvents = append(result.SubscriptionEvents, event)
+		s.emit(EventGraphQLSubscription, event)
+	}
+
+	err = sub.write(graphqlMessage{Type: "connection_init", Payload: connectionParams})
+	if err == nil {
+		trace.mark(&trace.wroteRequest)
+		err = sub.run(request, stopTimeout, func(message graphqlMessage) {
+			trace.markFirst(&trace.firstByte)
+			switch message.Type {
+			case "next", "data":
+				payloads = append(payloads, message.Payload)
+				result.GraphQLErrors = append(result.GraphQLErrors, payloadErrors(message.Payload)...)
+				record(models.GraphQLEventNext, message.Payload)
+			case "error":
+				result.GraphQLErrors = append(result.GraphQLErrors, payloadErrors(message.Payload)...)
+				record(models.GraphQLEventError, message.Payload)
+			case "complete":
+				record(models.GraphQLEventComplete, nil)
+			}
+		})
+	}
+	result.Timings = trace.timings(time.Now())
+
+	if payloads == nil {
+		payloads = []json.RawMessage{}
+	}
+	body, marshalErr := json.MarshalIndent(payloads, "", "  ")
+	err = errors.Join(err, marshalErr)
+	result.Body = string(body)
+	result.BodySize = int64(len(body))
+	result.ContentType = "application/json"
+	result.BodyEncoding = BodyEncodingText
+
+	// Subscriptions usually end by being stopped, which still saves their transcript
+	err = errors.Join(err, saveHistory(spec.RequestID, result, result.SubscriptionEvents))
+	if err != nil || ctx.Err() != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+
+	result.Outcome = models.OutcomeCompleted
+	return result, nil
+}
+
+// run waits for the server to acknowledge the connection, then starts request and passes the
+// messages of the operation to handle until it ends
+func (sub *graphqlSubscription) run(request *graphqlRequest, acknowledged func() bool, handle func(graphqlMessage)) error {
+	started := false
+	for {
+		var message graphqlMessage
+		err := sub.conn.ReadJSON(&message)
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return nil
+		}
+		var closeErr *websocket.CloseError
+		if errors.As(err, &closeErr) {
+			return fmt.Errorf("the server closed the connection with %d %s", closeErr.Code, closeErr.Text)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch message.Type {
+		case "connection_ack":
+			if started {
+				continue
+			}
+			acknowledged()
+			err = sub.start(request)
+			if err != nil {
+				return err
+			}
+			started = true
+		case "connection_error":
+			return fmt.Errorf("the server refused the connection: %s", message.Payload)
+		case "ping":
+			err = sub.write(graphqlMessage{Type: "pong", Payload: message.Payload})
+			if err != nil {
+				return err
+			}
+		case "pong", "ka":
+		case "next", "data", "error", "complete":
+			if message.ID != graphqlSubscriptionID {
+				continue
+			}
+			handle(message)
+			if message.Type == "error" || message.Type == "complete" {
+				return nil
+			}
+		}
+	}
+}
+
+// payloadErrors returns the GraphQL errors of a subscription payload: the errors of an execution result,
+// the list of errors of a graphql-transport-ws error message, or the single error of a legacy one
+func payloadErrors(payload json.RawMessage) []models.GraphQLError {
+	var errs []models.GraphQLError
+	if json.Unmarshal(payload, &errs) == nil {
+		return errs
+	}
+
+	var response struct {
+		Errors  []models.GraphQLError `json:"errors"`
+		Message string                `json:"message"`
+	}
+	if json.Unmarshal(payload, &response) != nil {
+		return nil
+	}
+	if response.Errors == nil && response.Message != "" {
+		var single models.GraphQLError
+		json.Unmarshal(payload, &single)
+		return []models.GraphQLError{single}
+	}
+	return response.Errors
+}
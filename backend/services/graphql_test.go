@@ -0,0 +1,241 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		result models.ExecutionResult
+		want   []models.GraphQLError
+	}{
+		{
+			name: "errors with data",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{
+				"data": {"user": null},
+				"errors": [
+					{"message": "Not found", "locations": [{"line": 2, "column": 3}], "path": ["user", 0, "name"], "extensions": {"code": "NOT_FOUND"}},
+					{"message": "Deprecated"}
+				]
+			}`},
+			want: []models.GraphQLError{
+				{
+					Message:    "Not found",
+					Locations:  []models.GraphQLLocation{{Line: 2, Column: 3}},
+					Path:       []any{"user", float64(0), "name"},
+					Extensions: map[string]any{"code": "NOT_FOUND"},
+				},
+				{Message: "Deprecated"},
+			},
+		},
+		{
+			name:   "errors without data",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{"errors": [{"message": "Syntax Error"}]}`},
+			want:   []models.GraphQLError{{Message: "Syntax Error"}},
+		},
+		{
+			name:   "data only",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{"data": {"user": {"name": "Ana"}}}`},
+		},
+		{
+			name:   "null errors",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{"data": null, "errors": null}`},
+		},
+		{
+			name:   "not JSON",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `<html>Bad Gateway</html>`},
+		},
+		{
+			name:   "errors that aren't a list",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{"errors": "nope"}`},
+		},
+		{
+			name:   "truncated",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingText, Body: `{"errors": [{"message": "Not found"}]}`, Truncated: true},
+		},
+		{
+			name:   "binary",
+			result: models.ExecutionResult{BodyEncoding: BodyEncodingBase64, Body: "eyJlcnJvcnMiOiBbXX0="},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphqlErrors(&tt.result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got errors %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayloadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []models.GraphQLError
+	}{
+		{"execution result", `{"data": null, "errors": [{"message": "Unauthorized"}]}`, []models.GraphQLError{{Message: "Unauthorized"}}},
+		{"execution result without errors", `{"data": {"tick": 1}}`, nil},
+		{"graphql-transport-ws error", `[{"message": "Unknown field"}, {"message": "Unknown type"}]`, []models.GraphQLError{{Message: "Unknown field"}, {Message: "Unknown type"}}},
+		{"subscriptions-transport-ws error", `{"message": "Subscription failed", "extensions": {"code": "E1"}}`, []models.GraphQLError{{Message: "Subscription failed", Extensions: map[string]any{"code": "E1"}}}},
+		{"not JSON", `oops`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := payloadErrors(json.RawMessage(tt.payload)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got errors %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// startGraphQLSubscriptionServer serves subscriptions over the subprotocol given, sending two results
+// for the operation, the second with an error, then completing it. The server fails the test when
+// the client doesn't follow the protocol.
+func startGraphQLSubscriptionServer(t *testing.T, subprotocol string) *httptest.Server {
+	t.Helper()
+
+	legacy := subprotocol == graphqlWSLegacy
+	upgrader := websocket.Upgrader{Subprotocols: []string{subprotocol}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		read := func(want string) *graphqlMessage {
+			var message graphqlMessage
+			if err := conn.ReadJSON(&message); err != nil {
+				t.Errorf("reading %s: %v", want, err)
+				return nil
+			}
+			if message.Type != want {
+				t.Errorf("got %s message, want %s", message.Type, want)
+				return nil
+			}
+			return &message
+		}
+		write := func(message graphqlMessage) {
+			if err := conn.WriteJSON(message); err != nil {
+				t.Errorf("writing %s: %v", message.Type, err)
+			}
+		}
+
+		init := read("connection_init")
+		if init == nil {
+			return
+		}
+		if string(init.Payload) != `{"authToken":"secret"}` {
+			t.Errorf("got connection params %s, want the authToken", init.Payload)
+		}
+
+		// The operation is only sent once the connection is acknowledged
+		if legacy {
+			write(graphqlMessage{Type: "connection_ack"})
+			write(graphqlMessage{Type: "ka"})
+		} else {
+			write(graphqlMessage{Type: "ping", Payload: json.RawMessage(`{"n":1}`)})
+			pong := read("pong")
+			if pong == nil {
+				return
+			}
+			if string(pong.Payload) != `{"n":1}` {
+				t.Errorf("got pong payload %s, want the one of the ping", pong.Payload)
+			}
+			write(graphqlMessage{Type: "connection_ack"})
+		}
+
+		startType, nextType := "subscribe", "next"
+		if legacy {
+			startType, nextType = "start", "data"
+		}
+		start := read(startType)
+		if start == nil {
+			return
+		}
+		var operation graphqlRequest
+		json.Unmarshal(start.Payload, &operation)
+		if start.ID != graphqlSubscriptionID || operation.Query != "subscription($room: ID!) { messages(room: $room) }" || string(operation.Variables) != `{"room":"general"}` {
+			t.Errorf("got operation %s with ID %q, want the subscription with its variables", start.Payload, start.ID)
+		}
+
+		// Messages of other operations are ignored
+		write(graphqlMessage{ID: "other", Type: nextType, Payload: json.RawMessage(`{"data":{"messages":"ignored"}}`)})
+		write(graphqlMessage{ID: graphqlSubscriptionID, Type: nextType, Payload: json.RawMessage(`{"data":{"messages":"hi"}}`)})
+		write(graphqlMessage{ID: graphqlSubscriptionID, Type: nextType, Payload: json.RawMessage(`{"data":null,"errors":[{"message":"rate limited"}]}`)})
+		write(graphqlMessage{ID: graphqlSubscriptionID, Type: "complete"})
+
+		// The client closes the connection once the subscription completes
+		if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+			t.Errorf("got %v after completing, want the connection closed", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGraphQLSubscription(t *testing.T) {
+	for _, subprotocol := range []string{graphqlTransportWS, graphqlWSLegacy} {
+		t.Run(subprotocol, func(t *testing.T) {
+			useTestDatabase(t)
+			server := startGraphQLSubscriptionServer(t, subprotocol)
+
+			var events []models.GraphQLSubscriptionEvent
+			s := NewAPIClientService(func(name string, data ...any) {
+				if name == EventGraphQLSubscription {
+					events = append(events, data[0].(models.GraphQLSubscriptionEvent))
+				}
+			})
+
+			result, err := s.ExecuteGraphQLSubscription(context.Background(), models.RequestSpec{
+				ExecutionID: "subscription",
+				Method:      http.MethodPost,
+				URL:         server.URL + "/graphql",
+				BodyType:    "graphql",
+				GraphQL: &models.GraphQLBody{
+					Query:            "subscription($room: ID!) { messages(room: $room) }",
+					Variables:        `{"room": "general"}`,
+					ConnectionParams: `{"authToken": "secret"}`,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Outcome != models.OutcomeCompleted || result.Error != "" {
+				t.Fatalf("got outcome %q with error %q, want a completed subscription", result.Outcome, result.Error)
+			}
+			if result.Status != http.StatusSwitchingProtocols || result.Protocol != subprotocol {
+				t.Errorf("got status %d over %q, want 101 over %q", result.Status, result.Protocol, subprotocol)
+			}
+
+			types := make([]string, len(events))
+			for i, event := range events {
+				types[i] = event.Type
+			}
+			wantTypes := []string{models.GraphQLEventNext, models.GraphQLEventNext, models.GraphQLEventComplete}
+			if !reflect.DeepEqual(types, wantTypes) || events[0].Payload != `{"data":{"messages":"hi"}}` {
+				t.Errorf("got events %+v, want the two results then complete", events)
+			}
+			if !reflect.DeepEqual(result.SubscriptionEvents, events) {
+				t.Errorf("got subscription events %+v, want the emitted ones", result.SubscriptionEvents)
+			}
+
+			if !reflect.DeepEqual(result.GraphQLErrors, []models.GraphQLError{{Message: "rate limited"}}) {
+				t.Errorf("got errors %+v, want the one of the second result", result.GraphQLErrors)
+			}
+			var body []map[string]any
+			if err := json.Unmarshal([]byte(result.Body), &body); err != nil || len(body) != 2 {
+				t.Errorf("got body %s, want the two results", result.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ListGRPCServices describes the services of the target of spec, from its .proto files or else
+// through server reflection
+func (s *APIClientService) ListGRPCServices(ctx context.Context, spec models.GRPCSpec) ([]models.GRPCService, error) {
+	spec, _, err := resolveGRPCSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(spec.TimeoutMs))
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, grpcMetadata(spec.Metadata))
+
+	// The connection is only opened when reflection is used
+	conn, err := dialGRPC(spec, newTimingTrace())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	descriptors, err := loadGRPCDescriptors(ctx, conn, spec)
+	if err != nil {
+		return nil, err
+	}
+	return descriptors.describe(), nil
+}
+
+// ExecuteGRPC calls a gRPC method with the messages of spec, whether it's unary or streams in
+// either direction. Streamed messages are published as EventGRPCMessage events as they're exchanged.
+func (s *APIClientService) ExecuteGRPC(ctx context.Context, spec models.GRPCSpec) (*models.ExecutionResult, error) {
+	if spec.ExecutionID == "" {
+		spec.ExecutionID = uuid.NewString()
+	}
+
+	ctx, done, err := s.executions.start(ctx, spec.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return s.executeGRPC(ctx, spec)
+}
+
+// executeGRPC runs spec and reports the call like an HTTP execution: metadata as headers and
+// trailers, and the response messages as a JSON body. The status is the HTTP one, 200 once the server
+// answered, while the gRPC status the call ended with is reported as GRPCStatus.
+func (s *APIClientService) executeGRPC(ctx context.Context, spec models.GRPCSpec) (*models.ExecutionResult, error) {
+	spec, resolver, err := resolveGRPCSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ExecutionResult{
+		ExecutionID:         spec.ExecutionID,
+		Protocol:            "gRPC",
+		URL:                 spec.Target,
+		UnresolvedVariables: resolver.unresolvedNames(),
+		GeneratedVariables:  resolver.generated,
+	}
+
+	ctx, cancel, stopTimeout := withTimeout(ctx, requestTimeout(spec.TimeoutMs))
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, grpcMetadata(spec.Metadata))
+
+	// The total covers fetching the descriptors through reflection, which opens the connection
+	trace := newTimingTrace()
+	conn, err := dialGRPC(spec, trace)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	descriptors, err := loadGRPCDescriptors(ctx, conn, spec)
+	if err != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+	method, err := descriptors.method(spec.Method)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := grpcRequests(method, spec.Messages, descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := &grpcTranscript{executionID: spec.ExecutionID, types: descriptors.types, emit: func(message models.GRPCMessage) {
+		s.emit(EventGRPCMessage, message)
+	}}
+
+	desc := &grpc.StreamDesc{
+		StreamName:    string(method.Name()),
+		ClientStreams: method.IsStreamingClient(),
+		ServerStreams: method.IsStreamingServer(),
+	}
+	answered := &atomic.Bool{}
+	stream, err := conn.NewStream(context.WithValue(ctx, grpcAnsweredKey{}, answered), desc, grpcMethodPath(method))
+	if err != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+
+	// Requests are sent while responses are received, as bidirectional servers may answer every
+	// message before reading the next one
+	sent := make(chan error, 1)
+	go func() {
+		for _, request := range requests {
+			err := stream.SendMsg(request)
+			if err != nil {
+				sent <- err
+				return
+			}
+			trace.markFirst(&trace.wroteRequest)
+			transcript.record(directionSent, request)
+		}
+		sent <- stream.CloseSend()
+	}()
+
+	header, err := stream.Header()
+	if err == nil {
+		trace.mark(&trace.firstByte)
+		result.Headers = header
+		result.HeadersSize = headersSize(http.Header(header))
+		if method.IsStreamingServer() {
+			// The timeout only covers the server answering a stream
+			stopTimeout()
+		}
+	}
+
+	var responses []json.RawMessage
+	for {
+		response := dynamicpb.NewMessage(method.Output())
+		err = stream.RecvMsg(response)
+		if err != nil {
+			break
+		}
+		responses = append(responses, transcript.record(directionReceived, response))
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	// io.EOF only tells the server ended the call, whose status RecvMsg returned
+	if sendErr := <-sent; err == nil && sendErr != nil && sendErr != io.EOF {
+		err = sendErr
+	}
+	result.Timings = trace.timings(time.Now())
+	result.Messages = transcript.messages
+
+	if answered.Load() {
+		result.Status = http.StatusOK
+		result.StatusText = "200 OK"
+	}
+	if ctx.Err() == nil {
+		result.Trailers = stream.Trailer()
+		err = grpcStatusResult(result, err, method, responses)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		// Streams usually end by being stopped, which still saves their transcript
+		err = errors.Join(err, saveHistory(spec.RequestID, result, result.Messages))
+	}
+	if err != nil || ctx.Err() != nil {
+		return interruptedResult(ctx, result, trace, err)
+	}
+
+	result.Outcome = models.OutcomeCompleted
+	return result, nil
+}
+
+// resolveGRPCSpec expands the placeholders of spec with the variables of the active environment
+func resolveGRPCSpec(spec models.GRPCSpec) (models.GRPCSpec, *variableResolver, error) {
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return spec, nil, err
+	}
+
+	vars, err := environmentVariables(activeEnv)
+	if err != nil {
+		return spec, nil, err
+	}
+
+	resolver := newVariableResolver(vars)
+	spec, err = resolver.resolveGRPCSpec(spec)
+	return spec, resolver, err
+}
+
+// grpcStatusResult fills result with the status the call ended with, as returned by RecvMsg.
+// Failing statuses are part of the result, their JSON form being the body; other errors are returned.
+func grpcStatusResult(result *models.ExecutionResult, err error, method protoreflect.MethodDescriptor, responses []json.RawMessage) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	result.GRPCStatus = &models.GRPCStatus{Code: int(st.Code()), Name: st.Code().String(), Message: st.Message()}
+	result.ContentType = "application/json"
+	result.BodyEncoding = BodyEncodingText
+
+	var body []byte
+	switch {
+	case err != nil:
+		body, err = marshalProtoJSON(protojson.MarshalOptions{}, st.Proto(), "  ")
+		if err != nil {
+			body, err = json.MarshalIndent(map[string]any{"code": st.Code(), "message": st.Message()}, "", "  ")
+		}
+	case method.IsStreamingServer():
+		if responses == nil {
+			responses = []json.RawMessage{}
+		}
+		body, err = json.MarshalIndent(responses, "", "  ")
+	case len(responses) > 0:
+		body, err = json.MarshalIndent(responses[0], "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	result.Body = string(body)
+	result.BodySize = int64(len(body))
+	return nil
+}
+
+// grpcRequests parses the JSON messages sent by a call to method. Only client streams send more than
+// one message, and the others send an empty message when given none.
+func grpcRequests(method protoreflect.MethodDescriptor, messages []string, descriptors *grpcDescriptors) ([]*dynamicpb.Message, error) {
+	if !method.IsStreamingClient() {
+		if len(messages) == 0 {
+			messages = []string{"{}"}
+		}
+		messages = messages[:1]
+	}
+
+	requests := make([]*dynamicpb.Message, 0, len(messages))
+	for i, message := range messages {
+		request := dynamicpb.NewMessage(method.Input())
+		if strings.TrimSpace(message) != "" {
+			err := protojson.UnmarshalOptions{Resolver: descriptors.types}.Unmarshal([]byte(message), request)
+			if err != nil {
+				return nil, fmt.Errorf("message %d is not a valid %s: %w", i+1, method.Input().FullName(), err)
+			}
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// marshalProtoJSON marshals message with options, then formats it with encoding/json, as protojson
+// deliberately varies its whitespace. An empty indent gives compact JSON.
+func marshalProtoJSON(options protojson.MarshalOptions, message proto.Message, indent string) ([]byte, error) {
+	data, err := options.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	var formatted bytes.Buffer
+	if indent == "" {
+		err = json.Compact(&formatted, data)
+	} else {
+		err = json.Indent(&formatted, data, "", indent)
+	}
+	return formatted.Bytes(), err
+}
+
+// grpcTranscript records the messages of a call and publishes them
+type grpcTranscript struct {
+	executionID string
+	types       *dynamicpb.Types
+	emit        func(models.GRPCMessage)
+
+	mu       sync.Mutex
+	messages []models.GRPCMessage
+}
+
+// record adds message to the transcript and returns its JSON form
+func (t *grpcTranscript) record(direction string, message *dynamicpb.Message) json.RawMessage {
+	data, err := marshalProtoJSON(protojson.MarshalOptions{Resolver: t.types, EmitUnpopulated: true}, message, "")
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+
+	recorded := models.GRPCMessage{
+		ExecutionID: t.executionID,
+		Direction:   direction,
+		Data:        string(data),
+		Time:        time.Now(),
+	}
+
+	t.mu.Lock()
+	t.messages = append(t.messages, recorded)
+	t.mu.Unlock()
+
+	t.emit(recorded)
+	return data
+}
+
+// dialGRPC creates the connection to the target of spec. It's only opened by the first call made on it,
+// through a dialer recording the phases of the connection in trace.
+func dialGRPC(spec models.GRPCSpec, trace *timingTrace) (*grpc.ClientConn, error) {
+	address, useTLS, err := grpcTarget(spec.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := insecure.NewCredentials()
+	if useTLS || spec.TLS {
+		certificates, err := database.GetCertificates()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig, err := tlsConfigFor(address, "443", certificates, spec.SkipTLSVerify)
+		if err != nil {
+			return nil, err
+		}
+		creds = &tracedCredentials{TransportCredentials: credentials.NewTLS(tlsConfig), trace: trace}
+	}
+
+	// passthrough hands the address to the dialer as is, so it does the DNS lookup
+	return grpc.NewClient("passthrough:///"+address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return dialTraced(ctx, address, trace)
+		}),
+		grpc.WithStatsHandler(grpcAnswerStats{}),
+	)
+}
+
+// grpcTarget returns the host:port of target and whether its scheme asks for TLS.
+// Targets without scheme are host:port pairs.
+func grpcTarget(target string) (address string, useTLS bool, err error) {
+	if !strings.Contains(target, "://") {
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return "", false, fmt.Errorf("invalid gRPC target %q, expected host:port: %w", target, err)
+		}
+		return target, false, nil
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", false, err
+	}
+
+	defaultPort := "80"
+	switch parsed.Scheme {
+	case "grpc", "http":
+	case "grpcs", "https":
+		useTLS = true
+		defaultPort = "443"
+	default:
+		return "", false, fmt.Errorf("unsupported gRPC target scheme %q", parsed.Scheme)
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), useTLS, nil
+}
+
+// dialTraced connects to address, recording the DNS lookup and the connection in trace
+func dialTraced(ctx context.Context, address string, trace *timingTrace) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	trace.mark(&trace.dnsStart)
+	ips, err := net.DefaultResolver.LookupHost(ctx, host)
+	trace.mark(&trace.dnsDone)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	trace.markFirst(&trace.connectStart)
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip, port))
+		if err == nil {
+			trace.mark(&trace.connectDone)
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// tracedCredentials records the TLS handshake of connections in trace
+type tracedCredentials struct {
+	credentials.TransportCredentials
+	trace *timingTrace
+}
+
+func (c *tracedCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	c.trace.mark(&c.trace.tlsStart)
+	defer c.trace.mark(&c.trace.tlsDone)
+	return c.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+func (c *tracedCredentials) Clone() credentials.TransportCredentials {
+	return &tracedCredentials{TransportCredentials: c.TransportCredentials.Clone(), trace: c.trace}
+}
+
+// grpcAnsweredKey is the context key of the flag a call sets once its server answers
+type grpcAnsweredKey struct{}
+
+// grpcAnswerStats sets the grpcAnsweredKey flag of calls receiving headers or trailers, which grpc-go
+// only accepts from 200 responses. grpc-go doesn't expose the HTTP status otherwise.
+type grpcAnswerStats struct{}
+
+func (grpcAnswerStats) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (grpcAnswerStats) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
+	switch rpcStats.(type) {
+	case *stats.InHeader, *stats.InTrailer:
+		if answered, ok := ctx.Value(grpcAnsweredKey{}).(*atomic.Bool); ok {
+			answered.Store(true)
+		}
+	}
+}
+
+func (grpcAnswerStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (grpcAnswerStats) HandleConn(context.Context, stats.ConnStats) {}
+
+// grpcMetadata turns the enabled pairs into metadata, whose keys are lowercase
+func grpcMetadata(pairs []models.KeyValue) metadata.MD {
+	md := metadata.MD{}
+	for _, pair := range pairs {
+		if pair.Enabled && pair.Key != "" {
+			md.Append(pair.Key, pair.Value)
+		}
+	}
+	return md
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Server reflection methods, newest first. Both versions exchange the same messages, so the
+// v1alpha one, still the only one of older servers, is called with the v1 message types.
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// grpcDescriptors holds the services a call can target and the files describing their messages
+type grpcDescriptors struct {
+	files    *protoregistry.Files
+	types    *dynamicpb.Types
+	services []protoreflect.ServiceDescriptor
+}
+
+func newGRPCDescriptors(files *protoregistry.Files, services []protoreflect.ServiceDescriptor) *grpcDescriptors {
+	slices.SortFunc(services, func(a, b protoreflect.ServiceDescriptor) int {
+		return strings.Compare(string(a.FullName()), string(b.FullName()))
+	})
+	return &grpcDescriptors{files: files, types: dynamicpb.NewTypes(files), services: services}
+}
+
+// loadGRPCDescriptors compiles the .proto files of spec, or asks the server through reflection
+// when it has none
+func loadGRPCDescriptors(ctx context.Context, conn *grpc.ClientConn, spec models.GRPCSpec) (*grpcDescriptors, error) {
+	if len(spec.ProtoFiles) > 0 {
+		return compileProtoFiles(ctx, spec.ProtoFiles, spec.ImportPaths)
+	}
+	return reflectDescriptors(ctx, conn)
+}
+
+// method finds a method given as package.Service/Method or package.Service.Method
+func (d *grpcDescriptors) method(name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.TrimPrefix(name, "/")
+	i := strings.LastIndexAny(name, "/.")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid gRPC method %q, expected package.Service/Method", name)
+	}
+	serviceName, methodName := name[:i], name[i+1:]
+
+	for _, service := range d.services {
+		if string(service.FullName()) != serviceName {
+			continue
+		}
+		method := service.Methods().ByName(protoreflect.Name(methodName))
+		if method == nil {
+			return nil, fmt.Errorf("service %s has no method %s", serviceName, methodName)
+		}
+		return method, nil
+	}
+	return nil, fmt.Errorf("unknown gRPC service %s", serviceName)
+}
+
+// describe lists the services along with their methods
+func (d *grpcDescriptors) describe() []models.GRPCService {
+	services := make([]models.GRPCService, 0, len(d.services))
+	for _, service := range d.services {
+		described := models.GRPCService{Name: string(service.FullName())}
+		methods := service.Methods()
+		for i := range methods.Len() {
+			method := methods.Get(i)
+			described.Methods = append(described.Methods, models.GRPCMethod{
+				Name:            string(method.Name()),
+				FullName:        grpcMethodPath(method)[1:],
+				ClientStreaming: method.IsStreamingClient(),
+				ServerStreaming: method.IsStreamingServer(),
+				InputType:       string(method.Input().FullName()),
+				OutputType:      string(method.Output().FullName()),
+				InputTemplate:   d.template(method.Input()),
+			})
+		}
+		services = append(services, described)
+	}
+	return services
+}
+
+// template returns a JSON message of type desc with every field, nested ones included, set to its zero value
+func (d *grpcDescriptors) template(desc protoreflect.MessageDescriptor) string {
+	message := dynamicpb.NewMessage(desc)
+	fillTemplate(message, nil)
+
+	data, err := marshalProtoJSON(protojson.MarshalOptions{EmitUnpopulated: true, Resolver: d.types}, message, "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// fillTemplate sets the singular message fields of message to empty messages, which are then filled
+// in turn. Well-known types are left unset, as their JSON form isn't an object of their fields, and
+// so are recursive fields, whose type is one of the parents, listed in outer.
+func fillTemplate(message protoreflect.Message, outer []protoreflect.FullName) {
+	outer = append(outer, message.Descriptor().FullName())
+
+	fields := message.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsList() || field.IsMap() || field.ContainingOneof() != nil {
+			continue
+		}
+		name := field.Message().FullName()
+		if strings.HasPrefix(string(name), "google.protobuf.") || slices.Contains(outer, name) {
+			continue
+		}
+
+		child := message.NewField(field)
+		fillTemplate(child.Message(), outer)
+		message.Set(field, child)
+	}
+}
+
+// grpcMethodPath returns the path method is called at, /package.Service/Method
+func grpcMethodPath(method protoreflect.MethodDescriptor) string {
+	return "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+}
+
+// compileProtoFiles parses protoFiles. Imports are looked up in importPaths, then next to the file
+// importing them, and the well-known types are always available.
+func compileProtoFiles(ctx context.Context, protoFiles, importPaths []string) (*grpcDescriptors, error) {
+	paths := slices.Clone(importPaths)
+	names := make([]string, 0, len(protoFiles))
+	for _, file := range protoFiles {
+		name, ok := importName(file, importPaths)
+		if !ok {
+			paths = append(paths, filepath.Dir(file))
+			name = filepath.Base(file)
+		}
+		names = append(names, name)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: paths}),
+	}
+	compiled, err := compiler.Compile(ctx, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := new(protoregistry.Files)
+	var services []protoreflect.ServiceDescriptor
+	for _, file := range compiled {
+		err = registerFile(files, file)
+		if err != nil {
+			return nil, err
+		}
+		for i := range file.Services().Len() {
+			services = append(services, file.Services().Get(i))
+		}
+	}
+	return newGRPCDescriptors(files, services), nil
+}
+
+// importName returns the name of file relative to the first of importPaths containing it
+func importName(file string, importPaths []string) (string, bool) {
+	for _, importPath := range importPaths {
+		rel, err := filepath.Rel(importPath, file)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return "", false
+}
+
+// registerFile adds file and its imports to files, skipping those already registered
+func registerFile(files *protoregistry.Files, file protoreflect.FileDescriptor) error {
+	if _, err := files.FindFileByPath(file.Path()); err == nil {
+		return nil
+	}
+
+	imports := file.Imports()
+	for i := range imports.Len() {
+		err := registerFile(files, imports.Get(i).FileDescriptor)
+		if err != nil {
+			return err
+		}
+	}
+	return files.RegisterFile(file)
+}
+
+// reflectDescriptors asks the server which services it exposes and fetches the files describing them
+func reflectDescriptors(ctx context.Context, conn *grpc.ClientConn) (*grpcDescriptors, error) {
+	client, serviceNames, err := openReflection(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer client.close()
+
+	protos := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, name := range serviceNames {
+		resp, err := client.request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		err = addFileProtos(protos, resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Servers usually send the dependencies along, but aren't required to
+	for {
+		missing := missingDependencies(protos)
+		if len(missing) == 0 {
+			break
+		}
+		for _, path := range missing {
+			if file, err := protoregistry.GlobalFiles.FindFileByPath(path); err == nil {
+				protos[path] = protodesc.ToFileDescriptorProto(file)
+				continue
+			}
+
+			resp, err := client.request(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: path},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("file %s: %w", path, err)
+			}
+			err = addFileProtos(protos, resp)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := protos[path]; !ok {
+				return nil, fmt.Errorf("the server didn't send file %s", path)
+			}
+		}
+	}
+
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: mapValues(protos)})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]protoreflect.ServiceDescriptor, 0, len(serviceNames))
+	for _, name := range serviceNames {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a service", name)
+		}
+		services = append(services, service)
+	}
+	return newGRPCDescriptors(files, services), nil
+}
+
+func addFileProtos(protos map[string]*descriptorpb.FileDescriptorProto, resp *reflectionpb.ServerReflectionResponse) error {
+	for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		err := proto.Unmarshal(data, file)
+		if err != nil {
+			return err
+		}
+		protos[file.GetName()] = file
+	}
+	return nil
+}
+
+// missingDependencies lists the files imported by protos that it doesn't contain
+func missingDependencies(protos map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	for _, file := range protos {
+		for _, dependency := range file.GetDependency() {
+			if _, ok := protos[dependency]; !ok && !slices.Contains(missing, dependency) {
+				missing = append(missing, dependency)
+			}
+		}
+	}
+	return missing
+}
+
+func mapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// reflectionClient exchanges requests and responses over a server reflection stream
+type reflectionClient struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// openReflection opens a reflection stream with the newest version the server supports and lists
+// its services, leaving out the reflection ones
+func openReflection(ctx context.Context, conn *grpc.ClientConn) (*reflectionClient, []string, error) {
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	for i, method := range reflectionMethods {
+		streamCtx, cancel := context.WithCancel(ctx)
+		stream, err := conn.NewStream(streamCtx, desc, method)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		client := &reflectionClient{stream: stream, cancel: cancel}
+		resp, err := client.request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		if status.Code(err) == codes.Unimplemented && i < len(reflectionMethods)-1 {
+			client.close()
+			continue
+		}
+		if status.Code(err) == codes.Unimplemented {
+			client.close()
+			return nil, nil, errors.New("the server doesn't support reflection, import its .proto files instead")
+		}
+		if err != nil {
+			client.close()
+			return nil, nil, err
+		}
+
+		var names []string
+		for _, service := range resp.GetListServicesResponse().GetService() {
+			if !strings.HasPrefix(service.GetName(), "grpc.reflection.") {
+				names = append(names, service.GetName())
+			}
+		}
+		return client, names, nil
+	}
+	return nil, nil, errors.New("no reflection method to call")
+}
+
+func (c *reflectionClient) request(req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+	err := c.stream.SendMsg(req)
+	// On io.EOF the stream ended, for a reason RecvMsg reports
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	resp := &reflectionpb.ServerReflectionResponse{}
+	err = c.stream.RecvMsg(resp)
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp, nil
+}
+
+func (c *reflectionClient) close() {
+	c.stream.CloseSend()
+	c.cancel()
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const ordersProto = `syntax = "proto3";
+
+package shop.v1;
+
+import "common/money.proto";
+import "google/protobuf/timestamp.proto";
+
+service Orders {
+  rpc Get(GetOrderRequest) returns (Order);
+  rpc Watch(GetOrderRequest) returns (stream Order);
+  rpc Import(stream Order) returns (GetOrderRequest);
+  rpc Sync(stream Order) returns (stream Order);
+}
+
+service Admin {
+  rpc Ping(GetOrderRequest) returns (GetOrderRequest);
+}
+
+message GetOrderRequest {
+  string id = 1;
+}
+
+message Order {
+  string id = 1;
+  common.Money total = 2;
+  repeated Item items = 3;
+  map<string, string> labels = 4;
+  google.protobuf.Timestamp created = 5;
+  Order parent = 6;
+  oneof payment {
+    string card = 7;
+    Voucher voucher = 8;
+  }
+}
+
+message Item {
+  string sku = 1;
+  int32 quantity = 2;
+}
+
+message Voucher {
+  string code = 1;
+}
+`
+
+const moneyProto = `syntax = "proto3";
+
+package common;
+
+message Money {
+  string currency = 1;
+  int64 units = 2;
+}
+`
+
+// writeProtoFiles writes the orders and money files under a temporary directory, returning it
+func writeProtoFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{"shop/orders.proto": ordersProto, "common/money.proto": moneyProto} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCompileProtoFiles(t *testing.T) {
+	dir := writeProtoFiles(t)
+	descriptors, err := compileProtoFiles(context.Background(), []string{filepath.Join(dir, "shop", "orders.proto")}, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services := descriptors.describe()
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	if want := []string{"shop.v1.Admin", "shop.v1.Orders"}; !slices.Equal(names, want) {
+		t.Fatalf("got services %q, want %q", names, want)
+	}
+
+	want := []models.GRPCMethod{
+		{Name: "Get", FullName: "shop.v1.Orders/Get", InputType: "shop.v1.GetOrderRequest", OutputType: "shop.v1.Order"},
+		{Name: "Watch", FullName: "shop.v1.Orders/Watch", ServerStreaming: true, InputType: "shop.v1.GetOrderRequest", OutputType: "shop.v1.Order"},
+		{Name: "Import", FullName: "shop.v1.Orders/Import", ClientStreaming: true, InputType: "shop.v1.Order", OutputType: "shop.v1.GetOrderRequest"},
+		{Name: "Sync", FullName: "shop.v1.Orders/Sync", ClientStreaming: true, ServerStreaming: true, InputType: "shop.v1.Order", OutputType: "shop.v1.Order"},
+	}
+	methods := services[1].Methods
+	if len(methods) != len(want) {
+		t.Fatalf("got %d methods, want %d", len(methods), len(want))
+	}
+	for i, method := range methods {
+		method.InputTemplate = ""
+		if method != want[i] {
+			t.Errorf("got method %+v, want %+v", method, want[i])
+		}
+	}
+}
+
+func TestCompileProtoFilesWithoutImportPaths(t *testing.T) {
+	dir := writeProtoFiles(t)
+	orders := filepath.Join(dir, "shop", "orders.proto")
+	_, err := compileProtoFiles(context.Background(), []string{orders}, nil)
+	if err == nil {
+		t.Error("got no error for an import that can't be found")
+	}
+
+	// Imports are then resolved next to the importing file
+	err = os.WriteFile(orders, []byte(strings.Replace(ordersProto, "common/money.proto", "../common/money.proto", 1)), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors, err := compileProtoFiles(context.Background(), []string{orders}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := descriptors.method("shop.v1.Orders/Get"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGRPCTemplate(t *testing.T) {
+	dir := writeProtoFiles(t)
+	descriptors, err := compileProtoFiles(context.Background(), []string{filepath.Join(dir, "shop", "orders.proto")}, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, err := descriptors.method("shop.v1.Orders/Import")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nested messages are filled, except well-known types, recursive fields and oneofs
+	want := `{
+  "id": "",
+  "total": {
+    "currency": "",
+    "units": "0"
+  },
+  "items": [],
+  "labels": {},
+  "created": null,
+  "parent": null
+}`
+	if got := descriptors.template(method.Input()); got != want {
+		t.Errorf("got template %s, want %s", got, want)
+	}
+}
+
+func TestGRPCDescriptorsMethod(t *testing.T) {
+	dir := writeProtoFiles(t)
+	descriptors, err := compileProtoFiles(context.Background(), []string{filepath.Join(dir, "shop", "orders.proto")}, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		err  string
+	}{
+		{"shop.v1.Orders/Get", "/shop.v1.Orders/Get", ""},
+		{"shop.v1.Orders.Get", "/shop.v1.Orders/Get", ""},
+		{"/shop.v1.Admin/Ping", "/shop.v1.Admin/Ping", ""},
+		{"shop.v1.Orders/Delete", "", "has no method Delete"},
+		{"shop.v1.Carts/Get", "", "unknown gRPC service shop.v1.Carts"},
+		{"Get", "", "invalid gRPC method"},
+	}
+	for _, tt := range tests {
+		method, err := descriptors.method(tt.name)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("method(%q) returned error %v, want one about %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("method(%q) returned error %v", tt.name, err)
+			continue
+		}
+		if got := grpcMethodPath(method); got != tt.path {
+			t.Errorf("method(%q) is called at %q, want %q", tt.name, got, tt.path)
+		}
+	}
+}
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		file        string
+		importPaths []string
+		want        string
+		ok          bool
+	}{
+		{"/protos/shop/orders.proto", []string{"/protos"}, "shop/orders.proto", true},
+		{"/protos/shop/orders.proto", []string{"/other", "/protos/shop"}, "orders.proto", true},
+		{"/protos/shop/orders.proto", []string{"/protos/shop/v1"}, "", false},
+		{"/protos-old/orders.proto", []string{"/protos"}, "", false},
+		{"/protos/shop/orders.proto", nil, "", false},
+	}
+	for _, tt := range tests {
+		var importPaths []string
+		for _, path := range tt.importPaths {
+			importPaths = append(importPaths, filepath.FromSlash(path))
+		}
+		got, ok := importName(filepath.FromSlash(tt.file), importPaths)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("importName(%q, %q) = %q, %v, want %q, %v", tt.file, tt.importPaths, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMissingDependencies(t *testing.T) {
+	file := func(name string, dependencies ...string) *descriptorpb.FileDescriptorProto {
+		return &descriptorpb.FileDescriptorProto{Name: &name, Dependency: dependencies}
+	}
+	tests := []struct {
+		name   string
+		protos []*descriptorpb.FileDescriptorProto
+		want   []string
+	}{
+		{"complete", []*descriptorpb.FileDescriptorProto{file("a.proto", "b.proto"), file("b.proto")}, nil},
+		{"missing once", []*descriptorpb.FileDescriptorProto{file("a.proto", "c.proto"), file("b.proto", "c.proto")}, []string{"c.proto"}},
+		{"several", []*descriptorpb.FileDescriptorProto{file("a.proto", "b.proto", "c.proto")}, []string{"b.proto", "c.proto"}},
+	}
+	for _, tt := range tests {
+		protos := map[string]*descriptorpb.FileDescriptorProto{}
+		for _, proto := range tt.protos {
+			protos[proto.GetName()] = proto
+		}
+		got := missingDependencies(protos)
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReflectDescriptors(t *testing.T) {
+	healthServer := func(register func(*grpc.Server)) string {
+		server := grpc.NewServer()
+		healthpb.RegisterHealthServer(server, health.NewServer())
+		register(server)
+		return serveGRPC(t, server)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{"v1", startGRPCServer(t), ""},
+		{"v1alpha only", healthServer(func(server *grpc.Server) {
+			reflectionv1alpha.RegisterServerReflectionServer(server, reflection.NewServer(reflection.ServerOptions{Services: server}))
+		}), ""},
+		{"no reflection", healthServer(func(*grpc.Server) {}), "doesn't support reflection"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := dialGRPC(models.GRPCSpec{Target: tt.target}, newTimingTrace())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			descriptors, err := reflectDescriptors(context.Background(), conn)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got error %v, want one about %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			services := descriptors.describe()
+			if len(services) != 1 || services[0].Name != "grpc.health.v1.Health" {
+				t.Fatalf("got services %+v, want only grpc.health.v1.Health", services)
+			}
+			var methods []string
+			for _, method := range services[0].Methods {
+				methods = append(methods, method.FullName)
+			}
+			if want := []string{"grpc.health.v1.Health/Check", "grpc.health.v1.Health/List", "grpc.health.v1.Health/Watch"}; !slices.Equal(methods, want) {
+				t.Errorf("got methods %q, want %q", methods, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// startGRPCServer serves the health service with reflection, returning its address
+func startGRPCServer(t *testing.T) string {
+	t.Helper()
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	return serveGRPC(t, server)
+}
+
+// serveGRPC serves server until the end of t, returning its address
+func serveGRPC(t *testing.T, server *grpc.Server) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestExecuteGRPCReportsStatusApartFromHTTPStatus(t *testing.T) {
+	useTestDatabase(t)
+	address := startGRPCServer(t)
+	s := NewAPIClientService(func(string, ...any) {})
+
+	tests := []struct {
+		name    string
+		message string
+		want    models.GRPCStatus
+	}{
+		{"ok", `{}`, models.GRPCStatus{Code: 0, Name: "OK"}},
+		// Sent as a trailers-only response
+		{"failed", `{"service": "unknown"}`, models.GRPCStatus{Code: 5, Name: "NotFound", Message: "unknown service"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.ExecuteGRPC(context.Background(), models.GRPCSpec{
+				Target:   address,
+				Method:   "grpc.health.v1.Health/Check",
+				Messages: []string{tt.message},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != 200 || result.StatusText != "200 OK" {
+				t.Errorf("got status %d %q, want 200", result.Status, result.StatusText)
+			}
+			if result.GRPCStatus == nil || *result.GRPCStatus != tt.want {
+				t.Errorf("got gRPC status %+v, want %+v", result.GRPCStatus, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signOAuth1 signs req with OAuth 1.0a as described in RFC 5849. The signature base string
+// is built from the final method, URL and form body, so it must run once the request is final.
+// Previous oauth_ parameters of the query or body are replaced, which re-signs redirected requests.
+func signOAuth1(req *http.Request, config *models.OAuth1Config, nonce string, now time.Time) error {
+	if config == nil || config.ConsumerKey == "" {
+		return errors.New("OAuth 1.0 auth requires a consumer key")
+	}
+
+	method := config.SignatureMethod
+	if method == "" {
+		method = "HMAC-SHA1"
+	}
+
+	oauthParams := url.Values{
+		"oauth_consumer_key":     {config.ConsumerKey},
+		"oauth_signature_method": {method},
+		"oauth_timestamp":        {strconv.FormatInt(now.Unix(), 10)},
+		"oauth_nonce":            {nonce},
+		"oauth_version":          {"1.0"},
+	}
+	if config.Token != "" {
+		oauthParams.Set("oauth_token", config.Token)
+	}
+
+	query := withoutOAuthParams(req.URL.Query())
+	var body url.Values
+	if isFormBody(req) {
+		raw, err := readBody(req)
+		if err != nil {
+			return err
+		}
+		body, err = url.ParseQuery(string(raw))
+		if err != nil {
+			return fmt.Errorf("invalid x-www-form-urlencoded body: %w", err)
+		}
+		body = withoutOAuthParams(body)
+	} else if config.AddTo == "body" {
+		return errors.New("OAuth 1.0 parameters can only be added to an x-www-form-urlencoded body")
+	}
+
+	signature, err := oauth1Signature(req, config, method, []url.Values{oauthParams, query, body})
+	if err != nil {
+		return err
+	}
+	oauthParams.Set("oauth_signature", signature)
+
+	switch config.AddTo {
+	case "query":
+		for name, values := range oauthParams {
+			query[name] = values
+		}
+		req.URL.RawQuery = encodeOAuthParams(query, "&", false)
+	case "body":
+		for name, values := range oauthParams {
+			body[name] = values
+		}
+		setBody(req, encodeOAuthParams(body, "&", false))
+	default:
+		header := "OAuth "
+		if config.Realm != "" {
+			header += `realm="` + quoteEscaper.Replace(config.Realm) + `", `
+		}
+		req.Header.Set("Authorization", header+encodeOAuthParams(oauthParams, ", ", true))
+	}
+
+	return nil
+}
+
+// oauth1Signature signs the base string of req made of params
+func oauth1Signature(req *http.Request, config *models.OAuth1Config, method string, params []url.Values) (string, error) {
+	var pairs []string
+	for _, values := range params {
+		for name, list := range values {
+			for _, value := range list {
+				pairs = append(pairs, percentEncode(name)+"="+percentEncode(value))
+			}
+		}
+	}
+	sort.Strings(pairs)
+
+	baseURL := &url.URL{
+		Scheme: strings.ToLower(req.URL.Scheme),
+		Host:   strings.ToLower(req.URL.Host),
+		Path:   req.URL.Path,
+	}
+	if port := baseURL.Port(); (baseURL.Scheme == "http" && port == "80") || (baseURL.Scheme == "https" && port == "443") {
+		baseURL.Host = baseURL.Hostname()
+	}
+	if baseURL.Path == "" {
+		baseURL.Path = "/"
+	}
+
+	baseString := strings.Join([]string{
+		percentEncode(strings.ToUpper(req.Method)),
+		percentEncode(baseURL.String()),
+		percentEncode(strings.Join(pairs, "&")),
+	}, "&")
+	key := percentEncode(config.ConsumerSecret) + "&" + percentEncode(config.TokenSecret)
+
+	switch method {
+	case "HMAC-SHA1":
+		mac := hmac.New(sha1.New, []byte(key))
+		mac.Write([]byte(baseString))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+	case "RSA-SHA1":
+		privateKey, err := parseRSAPrivateKey(config.PrivateKey)
+		if err != nil {
+			return "", err
+		}
+		digest := sha1.Sum([]byte(baseString))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA1, digest[:])
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(signature), nil
+	case "PLAINTEXT":
+		return key, nil
+	default:
+		return "", fmt.Errorf("unsupported OAuth 1.0 signature method %q", method)
+	}
+}
+
+// parseRSAPrivateKey decodes a PKCS#1 or PKCS#8 PEM RSA private key
+func parseRSAPrivateKey(keyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// encodeOAuthParams percent-encodes params sorted by name, quoting the values for the Authorization header
+func encodeOAuthParams(params url.Values, separator string, quote bool) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		for _, value := range params[name] {
+			value = percentEncode(value)
+			if quote {
+				value = `"` + value + `"`
+			}
+			pairs = append(pairs, percentEncode(name)+"="+value)
+		}
+	}
+	return strings.Join(pairs, separator)
+}
+
+func withoutOAuthParams(values url.Values) url.Values {
+	filtered := url.Values{}
+	for name, list := range values {
+		if !strings.HasPrefix(name, "oauth_") {
+			filtered[name] = list
+		}
+	}
+	return filtered
+}
+
+func isFormBody(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+// readBody returns the body of req, reading it through GetBody so it can still be sent
+func readBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// setBody replaces the body of req, which must not have been sent yet
+func setBody(req *http.Request, body string) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	replacement := newStringBody(body, "")
+	req.Body, _ = replacement.open()
+	req.GetBody = replacement.open
+	req.ContentLength = replacement.length
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The example of RFC 5849 section 1.2
+var (
+	photosConfig = &models.OAuth1Config{
+		ConsumerKey:    "dpf43f3p2l4k3l03",
+		ConsumerSecret: "kd94hf93k423kf44",
+		Token:          "nnch734d00sl2jdk",
+		TokenSecret:    "pfkkdhi9sl3r4s00",
+	}
+	photosParams = url.Values{
+		"oauth_consumer_key":     {"dpf43f3p2l4k3l03"},
+		"oauth_token":            {"nnch734d00sl2jdk"},
+		"oauth_signature_method": {"HMAC-SHA1"},
+		"oauth_timestamp":        {"137131202"},
+		"oauth_nonce":            {"chapoH"},
+	}
+)
+
+// The example of RFC 5849 section 3.1, with the secrets of its errata, as the RFC doesn't give them
+var (
+	requestConfig = &models.OAuth1Config{
+		ConsumerKey:    "9djdj82h48djs9d2",
+		ConsumerSecret: "j49sk3j29djd",
+		Token:          "kkk9d7dh3k39sjv7",
+		TokenSecret:    "dh893hdasih9",
+	}
+	requestParams = url.Values{
+		"oauth_consumer_key":     {"9djdj82h48djs9d2"},
+		"oauth_token":            {"kkk9d7dh3k39sjv7"},
+		"oauth_signature_method": {"HMAC-SHA1"},
+		"oauth_timestamp":        {"137131201"},
+		"oauth_nonce":            {"7d8f3e4a"},
+	}
+)
+
+const (
+	rfc5849RequestURL  = "http://example.com/request?b5=%3D%253D&a3=a&c%40=&a2=r%20b"
+	rfc5849RequestBody = "c2&a3=2+q"
+)
+
+func TestOAuth1Signature(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		config *models.OAuth1Config
+		params url.Values
+		want   string
+	}{
+		{"rfc 5849 section 1.2", http.MethodGet, "http://photos.example.net/photos?file=vacation.jpg&size=original", "", photosConfig, photosParams, "MdpQcU8iPSUjWoN/UDMsK2sui9I="},
+		{"default port and case", http.MethodGet, "HTTP://Photos.Example.NET:80/photos?size=original&file=vacation.jpg", "", photosConfig, photosParams, "MdpQcU8iPSUjWoN/UDMsK2sui9I="},
+		{"rfc 5849 section 3.1", http.MethodPost, rfc5849RequestURL, rfc5849RequestBody, requestConfig, requestParams, "r6/TJjbCOr97/+UU0NsvSne7s5g="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := url.ParseQuery(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := oauth1Signature(req, tt.config, "HMAC-SHA1", []url.Values{tt.params, req.URL.Query(), body})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got signature %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuth1SignatureMethods(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://photos.example.net/photos?file=vacation.jpg&size=original", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := oauth1Signature(req, photosConfig, "PLAINTEXT", []url.Values{photosParams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "kd94hf93k423kf44&pfkkdhi9sl3r4s00"; plaintext != want {
+		t.Errorf("got PLAINTEXT signature %q, want %q", plaintext, want)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	config := *photosConfig
+	config.PrivateKey = string(keyPEM)
+	params := url.Values{"oauth_consumer_key": {"dpf43f3p2l4k3l03"}, "oauth_signature_method": {"RSA-SHA1"}}
+
+	signature, err := oauth1Signature(req, &config, "RSA-SHA1", []url.Values{params, req.URL.Query()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseString := "GET&http%3A%2F%2Fphotos.example.net%2Fphotos&file%3Dvacation.jpg%26oauth_consumer_key%3Ddpf43f3p2l4k3l03%26oauth_signature_method%3DRSA-SHA1%26size%3Doriginal"
+	digest := sha1.Sum([]byte(baseString))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], decoded); err != nil {
+		t.Errorf("RSA-SHA1 signature doesn't verify: %v", err)
+	}
+
+	_, err = oauth1Signature(req, photosConfig, "HMAC-SHA256", nil)
+	if err == nil {
+		t.Error("got no error for an unsupported signature method")
+	}
+}
+
+func TestSignOAuth1(t *testing.T) {
+	// The examples above, signed along with oauth_version=1.0; computed with Python's hmac
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		body      string
+		form      bool
+		config    models.OAuth1Config
+		nonce     string
+		timestamp int64
+		header    string
+		wantURL   string
+		wantBody  string
+		errSubstr string
+	}{
+		{
+			name:      "header",
+			method:    http.MethodGet,
+			url:       "http://photos.example.net/photos?file=vacation.jpg&size=original",
+			config:    func() models.OAuth1Config { c := *photosConfig; c.Realm = "Photos"; return c }(),
+			nonce:     "chapoH",
+			timestamp: 137131202,
+			header: `OAuth realm="Photos", oauth_consumer_key="dpf43f3p2l4k3l03", oauth_nonce="chapoH", ` +
+				`oauth_signature="1IAE9RzK%2BDqSqVTdQ%2F0zWANXVzs%3D", oauth_signature_method="HMAC-SHA1", ` +
+				`oauth_timestamp="137131202", oauth_token="nnch734d00sl2jdk", oauth_version="1.0"`,
+			wantURL: "http://photos.example.net/photos?file=vacation.jpg&size=original",
+		},
+		{
+			name:      "query replacing previous parameters",
+			method:    http.MethodGet,
+			url:       "http://photos.example.net/photos?file=vacation.jpg&size=original&oauth_nonce=old&oauth_signature=old",
+			config:    func() models.OAuth1Config { c := *photosConfig; c.AddTo = "query"; return c }(),
+			nonce:     "chapoH",
+			timestamp: 137131202,
+			wantURL: "http://photos.example.net/photos?file=vacation.jpg&oauth_consumer_key=dpf43f3p2l4k3l03&oauth_nonce=chapoH" +
+				"&oauth_signature=1IAE9RzK%2BDqSqVTdQ%2F0zWANXVzs%3D&oauth_signature_method=HMAC-SHA1&oauth_timestamp=137131202" +
+				"&oauth_token=nnch734d00sl2jdk&oauth_version=1.0&size=original",
+		},
+		{
+			name:      "body",
+			method:    http.MethodPost,
+			url:       rfc5849RequestURL,
+			body:      rfc5849RequestBody,
+			form:      true,
+			config:    func() models.OAuth1Config { c := *requestConfig; c.AddTo = "body"; return c }(),
+			nonce:     "7d8f3e4a",
+			timestamp: 137131201,
+			wantURL:   rfc5849RequestURL,
+			wantBody: "a3=2%20q&c2=&oauth_consumer_key=9djdj82h48djs9d2&oauth_nonce=7d8f3e4a" +
+				"&oauth_signature=OB33pYjWAnf%2BxtOHN4Gmbdil168%3D&oauth_signature_method=HMAC-SHA1&oauth_timestamp=137131201" +
+				"&oauth_token=kkk9d7dh3k39sjv7&oauth_version=1.0",
+		},
+		{
+			name:      "body without form",
+			method:    http.MethodPost,
+			url:       rfc5849RequestURL,
+			body:      `{"a": 1}`,
+			config:    func() models.OAuth1Config { c := *requestConfig; c.AddTo = "body"; return c }(),
+			errSubstr: "x-www-form-urlencoded",
+		},
+		{
+			name:      "no consumer key",
+			method:    http.MethodGet,
+			url:       rfc5849RequestURL,
+			errSubstr: "consumer key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.form {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			err = signOAuth1(req, &tt.config, tt.nonce, time.Unix(tt.timestamp, 0))
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("got error %v, want one about %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := req.Header.Get("Authorization"); got != tt.header {
+				t.Errorf("got Authorization %q, want %q", got, tt.header)
+			}
+			if got := req.URL.String(); got != tt.wantURL {
+				t.Errorf("got URL %q, want %q", got, tt.wantURL)
+			}
+			if tt.wantBody != "" {
+				body, err := readBody(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != tt.wantBody {
+					t.Errorf("got body %q, want %q", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
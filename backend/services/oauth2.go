@@ -0,0 +1,215 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// authorizationTimeout bounds how long an authorization code grant waits for the user to log in
+const authorizationTimeout = 5 * time.Minute
+
+// oauth2Token returns a token for config, reusing the cached one while it is valid and renewing
+// it with its refresh token once it expires. Token endpoints are called through client.
+func (s *APIClientService) oauth2Token(ctx context.Context, executionID string, config *models.OAuth2Config, client *http.Client) (*oauth2.Token, error) {
+	if config == nil {
+		return nil, errors.New("OAuth 2.0 auth requires its settings")
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+	endpoint := oauth2Endpoint(config)
+	cacheKey := oauth2CacheKey(config)
+
+	cached, err := database.GetOAuth2Token(cacheKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil {
+		token := &oauth2.Token{
+			AccessToken:  cached.AccessToken,
+			TokenType:    cached.TokenType,
+			RefreshToken: cached.RefreshToken,
+		}
+		if cached.Expires != nil {
+			token.Expiry = *cached.Expires
+		}
+		if token.Valid() {
+			return token, nil
+		}
+
+		if token.RefreshToken != "" {
+			refreshed, err := endpoint.TokenSource(ctx, token).Token()
+			if err == nil {
+				return refreshed, saveOAuth2Token(cacheKey, refreshed)
+			}
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			// The refresh token expired or was revoked, so fall back to a new grant
+		}
+	}
+
+	var token *oauth2.Token
+	switch config.GrantType {
+	case "client_credentials":
+		credentials := clientcredentials.Config{
+			ClientID:     endpoint.ClientID,
+			ClientSecret: endpoint.ClientSecret,
+			TokenURL:     endpoint.Endpoint.TokenURL,
+			Scopes:       endpoint.Scopes,
+			AuthStyle:    endpoint.Endpoint.AuthStyle,
+		}
+		token, err = credentials.Token(ctx)
+	case "password":
+		token, err = endpoint.PasswordCredentialsToken(ctx, config.Username, config.Password)
+	case "authorization_code":
+		token, err = s.authorizationCodeToken(ctx, executionID, endpoint, config.RedirectPort)
+	default:
+		return nil, fmt.Errorf("unsupported OAuth 2.0 grant type %q", config.GrantType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, saveOAuth2Token(cacheKey, token)
+}
+
+// authorizationCodeToken runs the authorization code grant with PKCE. The user logs in through the
+// browser, which the provider then redirects to a listener on the loopback interface.
+func (s *APIClientService) authorizationCodeToken(ctx context.Context, executionID string, endpoint *oauth2.Config, port int) (*oauth2.Token, error) {
+	if endpoint.Endpoint.AuthURL == "" {
+		return nil, errors.New("the authorization code grant requires an authorization URL")
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the OAuth 2.0 redirect listener: %w", err)
+	}
+	defer listener.Close()
+
+	endpoint.RedirectURL = fmt.Sprintf("http://127.0.0.1:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+	state := rand.Text()
+	verifier := oauth2.GenerateVerifier()
+
+	// Only the first callback counts, reloading the page mustn't block the handler
+	results := make(chan authorizationResult, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+
+			query := r.URL.Query()
+			if query.Get("state") != state {
+				http.Error(w, "Invalid state, please retry the request.", http.StatusBadRequest)
+				return
+			}
+
+			var result authorizationResult
+			if query.Get("error") != "" {
+				result.err = fmt.Errorf("OAuth 2.0 authorization failed: %s", strings.TrimSpace(query.Get("error")+" "+query.Get("error_description")))
+				http.Error(w, result.err.Error(), http.StatusBadRequest)
+			} else {
+				result.code = query.Get("code")
+				fmt.Fprintln(w, "Authorization complete, you can close this window and return to the app.")
+			}
+
+			select {
+			case results <- result:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	s.emit(EventAuthorizationPrompt, models.AuthorizationPrompt{
+		ExecutionID: executionID,
+		URL:         endpoint.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)),
+	})
+
+	timer := time.NewTimer(authorizationTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return endpoint.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	case <-timer.C:
+		return nil, errors.New("timed out waiting for the OAuth 2.0 authorization")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// authorizationResult is what the provider redirected the browser back with
+type authorizationResult struct {
+	code string
+	err  error
+}
+
+// oauth2Endpoint converts config to the settings of the oauth2 package
+func oauth2Endpoint(config *models.OAuth2Config) *oauth2.Config {
+	authStyle := oauth2.AuthStyleAutoDetect
+	switch config.ClientAuth {
+	case "header":
+		authStyle = oauth2.AuthStyleInHeader
+	case "body":
+		authStyle = oauth2.AuthStyleInParams
+	}
+
+	return &oauth2.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Scopes:       strings.Fields(config.Scope),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   config.AuthURL,
+			TokenURL:  config.TokenURL,
+			AuthStyle: authStyle,
+		},
+	}
+}
+
+// oauth2CacheKey identifies the tokens issued for config. Secrets are left out, so changing a
+// password doesn't throw away a token that is still valid.
+func oauth2CacheKey(config *models.OAuth2Config) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		config.GrantType,
+		config.TokenURL,
+		config.AuthURL,
+		config.ClientID,
+		strings.Join(strings.Fields(config.Scope), " "),
+		config.Username,
+	}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func saveOAuth2Token(cacheKey string, token *oauth2.Token) error {
+	cached := &models.OAuth2Token{
+		CacheKey:     cacheKey,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+	}
+	if !token.Expiry.IsZero() {
+		expires := token.Expiry
+		cached.Expires = &expires
+	}
+	return database.SaveOAuth2Token(cached)
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenEndpoint is an OAuth 2.0 token endpoint issuing numbered access tokens
+type tokenEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []url.Values
+	// expiresIn is sent with the tokens, and refreshToken is issued alongside them when set
+	expiresIn    int
+	refreshToken string
+	// rejectRefresh fails refresh token grants with invalid_grant
+	rejectRefresh bool
+}
+
+func startTokenEndpoint(t *testing.T) *tokenEndpoint {
+	t.Helper()
+
+	endpoint := &tokenEndpoint{expiresIn: 3600}
+	endpoint.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if id, secret, ok := r.BasicAuth(); ok {
+			r.PostForm.Set("client_id", id)
+			r.PostForm.Set("client_secret", secret)
+		}
+
+		endpoint.mu.Lock()
+		endpoint.requests = append(endpoint.requests, r.PostForm)
+		count := len(endpoint.requests)
+		expiresIn, refreshToken, rejectRefresh := endpoint.expiresIn, endpoint.refreshToken, endpoint.rejectRefresh
+		endpoint.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") == "refresh_token" && rejectRefresh {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "token-" + string(rune('0'+count)),
+			"token_type":    "Bearer",
+			"expires_in":    expiresIn,
+			"refresh_token": refreshToken,
+		})
+	}))
+	t.Cleanup(endpoint.Close)
+	return endpoint
+}
+
+// grants returns the grant types of the requests the endpoint got
+func (e *tokenEndpoint) grants() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	grants := make([]string, len(e.requests))
+	for i, request := range e.requests {
+		grants[i] = request.Get("grant_type")
+	}
+	return grants
+}
+
+func (e *tokenEndpoint) lastRequest() url.Values {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requests[len(e.requests)-1]
+}
+
+func TestOAuth2TokenIsCached(t *testing.T) {
+	useTestDatabase(t)
+	endpoint := startTokenEndpoint(t)
+
+	s := &APIClientService{}
+	config := &models.OAuth2Config{
+		GrantType:    "client_credentials",
+		TokenURL:     endpoint.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scope:        "read  write",
+	}
+
+	for range 2 {
+		token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token-1" || token.Type() != "Bearer" {
+			t.Errorf("got token %q of type %q, want the cached token-1", token.AccessToken, token.Type())
+		}
+	}
+	if grants := endpoint.grants(); len(grants) != 1 || grants[0] != "client_credentials" {
+		t.Fatalf("got grants %q, want a single client_credentials grant", grants)
+	}
+	if form := endpoint.lastRequest(); form.Get("scope") != "read write" || form.Get("client_id") != "client" || form.Get("client_secret") != "secret" {
+		t.Errorf("got token request %v, want the client credentials and scopes", form)
+	}
+
+	// Changing the scopes asks for a token of its own, changing the secret doesn't
+	config.ClientSecret = "rotated"
+	if _, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client()); err != nil {
+		t.Fatal(err)
+	}
+	config.Scope = "read"
+	token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "token-2" || len(endpoint.grants()) != 2 {
+		t.Errorf("got token %q after %d grants, want token-2 after 2", token.AccessToken, len(endpoint.grants()))
+	}
+}
+
+func TestOAuth2TokenExpiry(t *testing.T) {
+	config := func(endpoint *tokenEndpoint) *models.OAuth2Config {
+		return &models.OAuth2Config{
+			GrantType:  "password",
+			TokenURL:   endpoint.URL,
+			ClientID:   "client",
+			Username:   "ana",
+			Password:   "hunter2",
+			ClientAuth: "body", // auto-detection retries failed grants with the other style
+		}
+	}
+	cache := func(t *testing.T, config *models.OAuth2Config, refreshToken string) {
+		t.Helper()
+		expired := time.Now().Add(-time.Minute)
+		err := database.SaveOAuth2Token(&models.OAuth2Token{
+			CacheKey:     oauth2CacheKey(config),
+			AccessToken:  "expired",
+			RefreshToken: refreshToken,
+			TokenType:    "Bearer",
+			Expires:      &expired,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("renewed with the refresh token", func(t *testing.T) {
+		useTestDatabase(t)
+		endpoint := startTokenEndpoint(t)
+		endpoint.refreshToken = "refresh-2"
+		config := config(endpoint)
+		cache(t, config, "refresh-1")
+
+		s := &APIClientService{}
+		token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token-1" || token.RefreshToken != "refresh-2" {
+			t.Errorf("got token %q with refresh token %q, want token-1 with refresh-2", token.AccessToken, token.RefreshToken)
+		}
+		if grants := endpoint.grants(); len(grants) != 1 || grants[0] != "refresh_token" || endpoint.lastRequest().Get("refresh_token") != "refresh-1" {
+			t.Fatalf("got grants %q, want a refresh_token grant with refresh-1", grants)
+		}
+
+		// The renewed token is cached
+		cached, err := database.GetOAuth2Token(oauth2CacheKey(config))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cached.AccessToken != "token-1" || cached.RefreshToken != "refresh-2" || cached.Expires == nil || !cached.Expires.After(time.Now()) {
+			t.Errorf("got cached token %+v, want token-1 expiring in an hour", cached)
+		}
+	})
+
+	t.Run("rejected refresh token", func(t *testing.T) {
+		useTestDatabase(t)
+		endpoint := startTokenEndpoint(t)
+		endpoint.rejectRefresh = true
+		config := config(endpoint)
+		cache(t, config, "revoked")
+
+		s := &APIClientService{}
+		token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token-2" {
+			t.Errorf("got token %q, want token-2 from a new grant", token.AccessToken)
+		}
+		if grants := endpoint.grants(); len(grants) != 2 || grants[0] != "refresh_token" || grants[1] != "password" {
+			t.Fatalf("got grants %q, want a refresh_token grant then a password one", grants)
+		}
+		if form := endpoint.lastRequest(); form.Get("username") != "ana" || form.Get("password") != "hunter2" {
+			t.Errorf("got token request %v, want the resource owner credentials", form)
+		}
+	})
+
+	t.Run("without a refresh token", func(t *testing.T) {
+		useTestDatabase(t)
+		endpoint := startTokenEndpoint(t)
+		config := config(endpoint)
+		cache(t, config, "")
+
+		s := &APIClientService{}
+		token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if grants := endpoint.grants(); token.AccessToken != "token-1" || len(grants) != 1 || grants[0] != "password" {
+			t.Errorf("got token %q after grants %q, want token-1 from a password grant", token.AccessToken, grants)
+		}
+	})
+
+	t.Run("expiring within the expiry delta", func(t *testing.T) {
+		useTestDatabase(t)
+		endpoint := startTokenEndpoint(t)
+		endpoint.expiresIn = 5
+		config := config(endpoint)
+
+		// Tokens about to expire aren't reused, so they can't expire while the request is sent
+		s := &APIClientService{}
+		for range 2 {
+			if _, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if grants := endpoint.grants(); len(grants) != 2 {
+			t.Errorf("got grants %q, want a grant for each token", grants)
+		}
+	})
+}
+
+func TestOAuth2AuthorizationCodeWithPKCE(t *testing.T) {
+	useTestDatabase(t)
+	endpoint := startTokenEndpoint(t)
+
+	// The browser logs in and is redirected back with a code
+	prompted := make(chan *url.URL, 1)
+	redirected := make(chan error, 1)
+	s := NewAPIClientService(func(name string, data ...any) {
+		if name != EventAuthorizationPrompt {
+			return
+		}
+		authURL, err := url.Parse(data[0].(models.AuthorizationPrompt).URL)
+		if err != nil {
+			redirected <- err
+			return
+		}
+		prompted <- authURL
+
+		query := authURL.Query()
+		callback := query.Get("redirect_uri") + "?" + url.Values{"code": {"code-1"}, "state": {query.Get("state")}}.Encode()
+		go func() {
+			resp, err := http.Get(callback)
+			if err == nil {
+				resp.Body.Close()
+			}
+			redirected <- err
+		}()
+	})
+
+	config := &models.OAuth2Config{
+		GrantType: "authorization_code",
+		AuthURL:   "https://auth.example.com/authorize",
+		TokenURL:  endpoint.URL,
+		ClientID:  "client",
+		Scope:     "openid",
+	}
+	token, err := s.oauth2Token(context.Background(), "execution", config, endpoint.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-redirected; err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "token-1" {
+		t.Errorf("got token %q, want token-1", token.AccessToken)
+	}
+
+	authURL := <-prompted
+	query := authURL.Query()
+	if authURL.Host != "auth.example.com" || query.Get("response_type") != "code" || query.Get("client_id") != "client" || query.Get("scope") != "openid" {
+		t.Errorf("got authorization URL %s, want a code request for the client", authURL)
+	}
+	if query.Get("code_challenge_method") != "S256" {
+		t.Errorf("got code challenge method %q, want S256", query.Get("code_challenge_method"))
+	}
+
+	// The verifier sent with the code hashes to the challenge sent to the browser
+	form := endpoint.lastRequest()
+	if form.Get("grant_type") != "authorization_code" || form.Get("code") != "code-1" || form.Get("redirect_uri") != query.Get("redirect_uri") {
+		t.Errorf("got token request %v, want code-1 with the redirect URI", form)
+	}
+	verifier := form.Get("code_verifier")
+	if len(verifier) < 43 || len(verifier) > 128 {
+		t.Fatalf("got code verifier %q, want 43 to 128 characters", verifier)
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	if challenge := base64.RawURLEncoding.EncodeToString(sum[:]); query.Get("code_challenge") != challenge {
+		t.Errorf("got code challenge %q, want %q", query.Get("code_challenge"), challenge)
+	}
+}
+
+func TestOAuth2TokenIsNotRequestedThroughUnixSocket(t *testing.T) {
+	useTestDatabase(t)
+	endpoint := startTokenEndpoint(t)
+
+	// Socket paths are limited to about a hundred bytes, which test directories can exceed
+	dir, err := os.MkdirTemp("", "apiclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	})}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	s := &APIClientService{}
+	result, err := s.executeRequest(context.Background(), models.RequestSpec{
+		Method: http.MethodGet,
+		URL:    "http://localhost/containers",
+		Auth: &models.RequestAuth{Type: "oauth2", OAuth2: &models.OAuth2Config{
+			GrantType: "client_credentials",
+			TokenURL:  endpoint.URL,
+			ClientID:  "client",
+		}},
+		Settings: models.RequestSettings{UnixSocket: socket},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Error != "" || result.Body != "Bearer token-1" {
+		t.Errorf("got body %q and error %q, want the token from the endpoint", result.Body, result.Error)
+	}
+}
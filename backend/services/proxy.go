@@ -0,0 +1,48 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyFunc returns the function choosing the proxy of each request, or nil to connect directly
+func proxyFunc(proxy *models.Proxy) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == nil {
+		return nil, nil
+	}
+
+	if proxy.Type == "system" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	switch proxy.Type {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy type %q", proxy.Type)
+	}
+
+	proxyURL := &url.URL{
+		Scheme: proxy.Type,
+		Host:   net.JoinHostPort(proxy.Host, strconv.Itoa(proxy.Port)),
+	}
+	if proxy.Username != "" {
+		proxyURL.User = url.UserPassword(proxy.Username, proxy.Password)
+	}
+
+	config := httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    proxy.Bypass,
+	}
+	choose := config.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return choose(req.URL)
+	}, nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// defaultMaxRedirects applies when a request doesn't configure its own hop limit
+const defaultMaxRedirects = 10
+
+// sendFollowingRedirects sends req and follows the redirects it answers with according to settings.
+// Every redirect response is recorded in result; the last response is returned to the caller.
+// auth is only applied to the hops to the original host.
+func sendFollowingRedirects(client *http.Client, req *http.Request, settings models.RequestSettings, auth *models.RequestAuth, result *models.ExecutionResult) (*http.Response, error) {
+	maxRedirects := settings.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	// The client adds the cookies of its jar to the headers of the requests it sends, so every hop
+	// starts over from the credentials of the request itself
+	credentials := newRedirectCredentials(req, auth)
+
+	for {
+		var resp *http.Response
+		var err error
+		if req.URL.Host == credentials.host {
+			resp, err = sendAuthorized(client, req, auth, result)
+		} else {
+			resp, err = client.Do(req)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if settings.DisableRedirects || !isRedirect(resp.StatusCode) || resp.Header.Get("Location") == "" {
+			return resp, nil
+		}
+
+		if len(result.Redirects) >= maxRedirects {
+			result.Error = fmt.Sprintf("stopped after %d redirects", maxRedirects)
+			return resp, nil
+		}
+
+		next, err := redirectRequest(req, resp, settings.RewriteMethodOn307, credentials)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		result.Redirects = append(result.Redirects, models.ExchangeRecord{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			Status:     resp.StatusCode,
+			StatusText: resp.Status,
+			Headers:    resp.Header,
+		})
+
+		// Drain the body so the connection can be reused for the next hop
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+
+		req = next
+	}
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// redirectCredentials are the credentials a request was built with, which its redirects only send
+// to its own host
+type redirectCredentials struct {
+	host   string
+	names  []string    // headers holding credentials
+	header http.Header // their values in the request, before the client added any cookies
+	auth   *models.RequestAuth
+}
+
+func newRedirectCredentials(req *http.Request, auth *models.RequestAuth) *redirectCredentials {
+	names := []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Amz-Security-Token"}
+	if auth != nil && auth.Type == "api-key" && auth.AddTo != "query" && auth.Key != "" {
+		names = append(names, auth.Key)
+	}
+
+	header := http.Header{}
+	for _, name := range names {
+		if values := req.Header.Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = slices.Clone(values)
+		}
+	}
+	return &redirectCredentials{host: req.URL.Host, names: names, header: header, auth: auth}
+}
+
+// redirectRequest builds the request that follows resp, which answered req with a redirect.
+// 301, 302 and 303 switch to GET without a body, like browsers do. 307 and 308 repeat the
+// method and body unless rewriteOn307 is set. The headers of credentials replace the ones
+// req was sent with.
+func redirectRequest(req *http.Request, resp *http.Response, rewriteOn307 bool, credentials *redirectCredentials) (*http.Request, error) {
+	location, err := req.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect location: %w", err)
+	}
+
+	method := req.Method
+	keepBody := true
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther:
+		keepBody = false
+	case http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		keepBody = !rewriteOn307
+	}
+	if !keepBody && method != http.MethodHead {
+		method = http.MethodGet
+	}
+
+	var body io.ReadCloser
+	if keepBody && req.GetBody != nil {
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	next, err := http.NewRequestWithContext(req.Context(), method, location.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	next.Header = req.Header.Clone()
+	if keepBody {
+		next.GetBody = req.GetBody
+		next.ContentLength = req.ContentLength
+	} else {
+		next.Header.Del("Content-Type")
+		next.Header.Del("Content-Length")
+	}
+
+	// Don't leak credentials to another host. Hops back to the original host get them again,
+	// along with the auth that was removed from the hops to other hosts.
+	for _, name := range credentials.names {
+		next.Header.Del(name)
+	}
+	if location.Host == credentials.host {
+		for name, values := range credentials.header {
+			next.Header[name] = slices.Clone(values)
+		}
+		if req.URL.Host != credentials.host {
+			err = applyAuth(next, credentials.auth)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return next, nil
+}
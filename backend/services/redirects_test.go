@@ -0,0 +1,154 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedirectsSendJarCookiesOnce(t *testing.T) {
+	useTestDatabase(t)
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc", Path: "/"})
+		http.Redirect(w, r, "/next", http.StatusFound)
+	})
+	mux.HandleFunc("/next", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.Header.Values("Cookie")
+		mu.Unlock()
+		http.Redirect(w, r, "/final", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.Header.Values("Cookie")
+		mu.Unlock()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := &APIClientService{}
+	result, err := s.executeRequest(context.Background(), models.RequestSpec{
+		Method:  http.MethodGet,
+		URL:     server.URL + "/login",
+		Headers: []models.KeyValue{{Key: "Cookie", Value: "user=1", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != http.StatusOK || len(result.Redirects) != 2 {
+		t.Fatalf("got status %d after %d redirects, want 200 after 2", result.Status, len(result.Redirects))
+	}
+
+	want := []string{"user=1; sid=abc"}
+	for _, path := range []string{"/next", "/final"} {
+		if got := received[path]; !slices.Equal(got, want) {
+			t.Errorf("%s received Cookie %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRedirectRequestReplacesCookies(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://example.com/a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Cookie", "user=1")
+	credentials := newRedirectCredentials(req, nil)
+	// The client added the cookies of its jar when sending the request
+	req.Header.Set("Cookie", "user=1; sid=abc")
+
+	tests := []struct {
+		location string
+		want     []string
+	}{
+		{"/b", []string{"user=1"}},
+		{"http://other.example.com/b", nil},
+	}
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: http.StatusFound, Header: http.Header{"Location": {test.location}}}
+		next, err := redirectRequest(req, resp, false, credentials)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := next.Header.Values("Cookie"); !slices.Equal(got, test.want) {
+			t.Errorf("redirect to %s sends Cookie %q, want %q", test.location, got, test.want)
+		}
+	}
+}
+
+func TestRedirectsOnlySendAuthToTheOriginalHost(t *testing.T) {
+	useTestDatabase(t)
+
+	// received maps the paths of both servers to the credentials they got
+	var mu sync.Mutex
+	received := map[string]string{}
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = strings.Join([]string{r.Header.Get("Authorization"), r.Header.Get("X-Api-Key"), r.URL.Query().Get("api_key"), r.Header.Get("Cookie")}, "|")
+	}
+
+	var original *httptest.Server
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if r.URL.Path == "/elsewhere" {
+			http.Redirect(w, r, "/bounce", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, original.URL+"/final", http.StatusFound)
+	}))
+	defer other.Close()
+	original = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if r.URL.Path == "/start" {
+			http.Redirect(w, r, other.URL+"/elsewhere", http.StatusFound)
+		}
+	}))
+	defer original.Close()
+
+	tests := []struct {
+		name string
+		auth *models.RequestAuth
+		want string
+	}{
+		{"bearer", &models.RequestAuth{Type: "bearer", Token: "t0ken"}, "Bearer t0ken|||user=1"},
+		{"basic", &models.RequestAuth{Type: "basic", Username: "ana", Password: "pw"}, "Basic YW5hOnB3|||user=1"},
+		{"api key header", &models.RequestAuth{Type: "api-key", Key: "X-API-Key", Value: "k3y"}, "|k3y||user=1"},
+		{"api key query", &models.RequestAuth{Type: "api-key", Key: "api_key", Value: "k3y", AddTo: "query"}, "||k3y|user=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(received)
+
+			s := &APIClientService{}
+			result, err := s.executeRequest(context.Background(), models.RequestSpec{
+				Method:  http.MethodGet,
+				URL:     original.URL + "/start",
+				Headers: []models.KeyValue{{Key: "Cookie", Value: "user=1", Enabled: true}},
+				Auth:    tt.auth,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != http.StatusOK || len(result.Redirects) != 3 {
+				t.Fatalf("got status %d after %d redirects, want 200 after 3", result.Status, len(result.Redirects))
+			}
+
+			want := map[string]string{"/start": tt.want, "/elsewhere": "|||", "/bounce": "|||", "/final": tt.want}
+			for path, credentials := range want {
+				if received[path] != credentials {
+					t.Errorf("%s received credentials %q, want %q", path, received[path], credentials)
+				}
+			}
+		})
+	}
+}
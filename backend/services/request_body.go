@@ -0,0 +1,181 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// requestBody is a body that can be opened again, e.g. to repeat it after a redirect
+type requestBody struct {
+	contentType string
+	length      int64
+	open        func() (io.ReadCloser, error)
+}
+
+// newRequestBody builds the body of spec, or returns nil when the request has none.
+// form-data bodies are encoded from the form fields, streaming files from disk.
+func newRequestBody(spec models.RequestSpec) (*requestBody, error) {
+	switch spec.BodyType {
+	case "none":
+		return nil, nil
+	case "form-data":
+		return newMultipartBody(spec.FormFields)
+	case "graphql":
+		return newGraphQLBody(spec.GraphQL)
+	case "x-www-form-urlencoded":
+		if len(spec.FormFields) > 0 {
+			return newURLEncodedBody(spec.FormFields)
+		}
+	}
+
+	if spec.Body == "" {
+		return nil, nil
+	}
+	return newStringBody(spec.Body, defaultContentTypes[spec.BodyType]), nil
+}
+
+func newStringBody(body, contentType string) *requestBody {
+	return &requestBody{
+		contentType: contentType,
+		length:      int64(len(body)),
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(body)), nil
+		},
+	}
+}
+
+func newURLEncodedBody(fields []models.FormField) (*requestBody, error) {
+	values := url.Values{}
+	for _, field := range fields {
+		if !field.Enabled || field.Key == "" {
+			continue
+		}
+		if field.Type == "file" {
+			return nil, fmt.Errorf("form field %q: files can't be sent in an x-www-form-urlencoded body", field.Key)
+		}
+		values.Add(field.Key, field.Value)
+	}
+
+	return newStringBody(values.Encode(), "application/x-www-form-urlencoded"), nil
+}
+
+// newMultipartBody builds a multipart/form-data body. Its length is computed up front from the
+// size of the files so the request doesn't need chunked encoding.
+func newMultipartBody(fields []models.FormField) (*requestBody, error) {
+	var enabled []models.FormField
+	var filesSize int64
+	for _, field := range fields {
+		if !field.Enabled || field.Key == "" {
+			continue
+		}
+		if field.Type == "file" {
+			info, err := os.Stat(field.FilePath)
+			if err != nil {
+				return nil, fmt.Errorf("form field %q: %w", field.Key, err)
+			}
+			if info.IsDir() {
+				return nil, fmt.Errorf("form field %q: %s is a directory", field.Key, field.FilePath)
+			}
+			filesSize += info.Size()
+		}
+		enabled = append(enabled, field)
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	structure := &countingWriter{}
+	err := writeMultipart(structure, boundary, enabled, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &requestBody{
+		contentType: "multipart/form-data; boundary=" + boundary,
+		length:      structure.n + filesSize,
+		open: func() (io.ReadCloser, error) {
+			reader, writer := io.Pipe()
+			go func() {
+				writer.CloseWithError(writeMultipart(writer, boundary, enabled, true))
+			}()
+			return reader, nil
+		},
+	}, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// writeMultipart writes fields as multipart/form-data to w. When withFiles is false the file
+// contents are left out, which measures everything but the files.
+func writeMultipart(w io.Writer, boundary string, fields []models.FormField, withFiles bool) error {
+	writer := multipart.NewWriter(w)
+	err := writer.SetBoundary(boundary)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field.Type != "file" {
+			err = writer.WriteField(field.Key, field.Value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		contentType := field.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(field.FilePath))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(field.Key), quoteEscaper.Replace(filepath.Base(field.FilePath))))
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		if withFiles {
+			err = copyFile(part, field.FilePath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Close()
+}
+
+func copyFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// countingWriter counts the bytes written to it and discards them
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMultipartBodyLength(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	photo := writeFile("photo.png", []byte{0x89, 'P', 'N', 'G', 0, 1, 2, 0xff})
+	notes := writeFile("notes", bytes.Repeat([]byte("line\r\n"), 5000))
+	data := writeFile("data.bin", []byte("custom"))
+	empty := writeFile("empty.txt", nil)
+	quoted := writeFile(`my "report".csv`, []byte("a,b\n1,2\n"))
+
+	text := func(key, value string) models.FormField {
+		return models.FormField{Key: key, Value: value, Type: "text", Enabled: true}
+	}
+	file := func(key, path, contentType string) models.FormField {
+		return models.FormField{Key: key, Type: "file", FilePath: path, ContentType: contentType, Enabled: true}
+	}
+
+	// part is what a field is expected to be received as
+	type part struct {
+		name, filename, contentType, content string
+	}
+	read := func(path string) string {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	tests := []struct {
+		name   string
+		fields []models.FormField
+		want   []part
+	}{
+		{"no fields", nil, nil},
+		{
+			"text fields",
+			[]models.FormField{text("name", "Ana"), text("bio", "línea 1\nline 2"), text("empty", "")},
+			[]part{{"name", "", "", "Ana"}, {"bio", "", "", "línea 1\nline 2"}, {"empty", "", "", ""}},
+		},
+		{
+			"file fields",
+			[]models.FormField{file("avatar", photo, ""), file("notes", notes, ""), file("empty", empty, "")},
+			[]part{
+				{"avatar", "photo.png", "image/png", read(photo)},
+				{"notes", "notes", "application/octet-stream", read(notes)},
+				{"empty", "empty.txt", "text/plain; charset=utf-8", ""},
+			},
+		},
+		{
+			"custom part content type",
+			[]models.FormField{file("data", data, "application/vnd.example+json; version=2")},
+			[]part{{"data", "data.bin", "application/vnd.example+json; version=2", "custom"}},
+		},
+		{
+			"mixed fields",
+			[]models.FormField{
+				text("title", "Quarterly"),
+				file(`report "q1"`, quoted, "text/csv"),
+				{Key: "skipped", Value: "disabled", Type: "text"},
+				{Key: "", Value: "no key", Type: "text", Enabled: true},
+				file("avatar", photo, "image/x-custom"),
+			},
+			[]part{
+				{"title", "", "", "Quarterly"},
+				{`report "q1"`, `my "report".csv`, "text/csv", read(quoted)},
+				{"avatar", "photo.png", "image/x-custom", read(photo)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := newMultipartBody(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The body is the same every time it's opened, and as long as announced
+			var written []byte
+			for range 2 {
+				reader, err := body.open()
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := io.ReadAll(reader)
+				reader.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if int64(len(data)) != body.length {
+					t.Fatalf("wrote %d bytes, want the Content-Length of %d", len(data), body.length)
+				}
+				if written != nil && !bytes.Equal(data, written) {
+					t.Fatal("opening the body again wrote different bytes")
+				}
+				written = data
+			}
+
+			mediaType, params, err := mime.ParseMediaType(body.contentType)
+			if err != nil || mediaType != "multipart/form-data" {
+				t.Fatalf("got content type %q, want multipart/form-data", body.contentType)
+			}
+			reader := multipart.NewReader(bytes.NewReader(written), params["boundary"])
+			var got []part
+			for {
+				p, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				content, err := io.ReadAll(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(content)})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d parts, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("part %d: got %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMultipartBodyErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name  string
+		field models.FormField
+	}{
+		{"missing file", models.FormField{Key: "upload", Type: "file", FilePath: filepath.Join(dir, "missing.txt"), Enabled: true}},
+		{"directory", models.FormField{Key: "upload", Type: "file", FilePath: dir, Enabled: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newMultipartBody([]models.FormField{tt.field}); err == nil {
+				t.Error("building the body succeeded")
+			}
+		})
+	}
+
+	// Disabled file fields aren't read
+	_, err := newMultipartBody([]models.FormField{{Key: "upload", Type: "file", FilePath: filepath.Join(dir, "missing.txt")}})
+	if err != nil {
+		t.Errorf("got error %v for a disabled file field", err)
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultMaxResponseBytes applies when a request doesn't configure how much of a body to keep in memory
+const defaultMaxResponseBytes = 50 << 20
+
+// Response body encodings
+const (
+	BodyEncodingText   = "text"
+	BodyEncodingBase64 = "base64"
+)
+
+// readResponseBody stores the body of resp in result, reporting progress to the frontend. Binary bodies
+// are base64 encoded and bodies larger than the configured limit are truncated, unless the body is
+// streamed to a file.
+func (s *APIClientService) readResponseBody(resp *http.Response, settings models.RequestSettings, result *models.ExecutionResult) error {
+	if settings.SaveToFile != "" {
+		return s.saveResponseBody(resp, settings.SaveToFile, result)
+	}
+
+	maxBytes := settings.MaxResponseBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxResponseBytes
+	}
+
+	progress := newProgressReader(resp.Body, result.ExecutionID, "download", resp.ContentLength, func(progress models.TransferProgress) {
+		s.emit(EventExecutionProgress, progress)
+	})
+	body, err := io.ReadAll(io.LimitReader(progress, maxBytes+1))
+	if err != nil {
+		return err
+	}
+	progress.finish()
+	if int64(len(body)) > maxBytes {
+		body = body[:maxBytes]
+		result.Truncated = true
+	}
+
+	result.BodySize = int64(len(body))
+	result.IsBinary = isBinaryBody(resp.Header.Get("Content-Type"), body, result.Truncated)
+	if result.IsBinary {
+		result.BodyEncoding = BodyEncodingBase64
+		result.Body = base64.StdEncoding.EncodeToString(body)
+	} else {
+		result.BodyEncoding = BodyEncodingText
+		result.Body = string(body)
+	}
+
+	return nil
+}
+
+// saveResponseBody streams the body of resp to path, reporting progress to the frontend.
+// Relative paths are saved in the user's Downloads folder.
+func (s *APIClientService) saveResponseBody(resp *http.Response, path string, result *models.ExecutionResult) error {
+	if !filepath.IsAbs(path) {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(homeDir, "Downloads", path)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	body := newProgressReader(resp.Body, result.ExecutionID, "download", resp.ContentLength, func(progress models.TransferProgress) {
+		s.emit(EventExecutionProgress, progress)
+	})
+
+	written, err := io.Copy(file, body)
+	if err != nil {
+		return err
+	}
+	body.finish()
+
+	result.BodySize = written
+	result.SavedTo = path
+	result.IsBinary = isBinaryBody(resp.Header.Get("Content-Type"), nil, false)
+	return nil
+}
+
+// isBinaryBody tells whether body can't be shown as text, based on its content type and,
+// when the content type isn't conclusive, on its first bytes
+func isBinaryBody(contentType string, body []byte, truncated bool) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case isTextMediaType(mediaType):
+		return !validUTF8(body, truncated)
+	case mediaType != "" && mediaType != "application/octet-stream":
+		return true
+	}
+
+	if len(body) == 0 {
+		return mediaType != ""
+	}
+
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+	return !isTextMediaType(sniffed) || !validUTF8(body, truncated)
+}
+
+func isTextMediaType(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/ecmascript",
+		"application/x-www-form-urlencoded", "application/graphql", "application/yaml", "application/x-yaml",
+		"application/x-ndjson", "image/svg+xml":
+		return true
+	}
+
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// validUTF8 checks body, ignoring a multi-byte character cut in half by truncation
+func validUTF8(body []byte, truncated bool) bool {
+	if truncated {
+		start := max(len(body)-utf8.UTFMax, 0)
+		for i := len(body) - 1; i >= start; i-- {
+			if utf8.RuneStart(body[i]) {
+				if !utf8.FullRune(body[i:]) {
+					body = body[:i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(body)
+}
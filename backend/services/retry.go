@@ -0,0 +1,212 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Defaults of a RetryPolicy that leaves its delays unset
+const (
+	defaultRetryInitialDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+)
+
+// maxRetryAttempts caps MaxAttempts so a mistyped policy doesn't hammer a server
+const maxRetryAttempts = 10
+
+// retryPolicy returns the policy spec is retried with: its own, or else the one of its collection.
+// nil means the request is only sent once.
+func retryPolicy(spec models.RequestSpec) (*models.RetryPolicy, error) {
+	if spec.Settings.Retry != nil {
+		return spec.Settings.Retry, nil
+	}
+	if spec.CollectionID == nil {
+		return nil, nil
+	}
+
+	collection, err := database.GetCollection(*spec.CollectionID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return collection.RetryPolicy, nil
+}
+
+// sendWithRetries sends req through sendFollowingRedirects, attempting it again as policy allows.
+// Every attempt gets its own timeout, and retried executions record each attempt in result.
+// The returned context is the one the returned response or error belongs to: it bounds reading
+// the response, and cancel must be called once that's done.
+func sendWithRetries(ctx context.Context, client *http.Client, req *http.Request, spec models.RequestSpec, policy *models.RetryPolicy, trace *timingTrace, result *models.ExecutionResult) (*http.Response, context.Context, context.CancelFunc, error) {
+	attempts := 1
+	if policy != nil && policy.MaxAttempts > 1 {
+		attempts = min(policy.MaxAttempts, maxRetryAttempts)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel, stopTimeout := withTimeout(ctx, requestTimeout(spec.Settings.TimeoutMs))
+		attemptReq, err := attemptRequest(attemptCtx, req, attempt)
+		if err != nil {
+			return nil, attemptCtx, cancel, err
+		}
+		if attempt > 1 {
+			trace.restart()
+		}
+		attemptReq = attemptReq.WithContext(httptrace.WithClientTrace(attemptCtx, trace.clientTrace()))
+
+		// Redirects and auth exchanges are recorded again by every attempt
+		result.Redirects = nil
+		result.AuthExchanges = nil
+
+		started := time.Now()
+		resp, err := sendFollowingRedirects(client, attemptReq, spec.Settings, spec.Auth, result)
+		if err == nil && streamsEvents(resp, spec.Settings) {
+			// The timeout only covers receiving the headers of a stream
+			stopTimeout()
+		}
+		if attempts == 1 {
+			return resp, attemptCtx, cancel, err
+		}
+
+		record := models.AttemptRecord{
+			Attempt:    attempt,
+			DurationMs: durationMs(time.Since(started)),
+		}
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			record.Status = resp.StatusCode
+			record.StatusText = resp.Status
+		}
+
+		retry := attempt < attempts && ctx.Err() == nil && shouldRetry(policy, resp, err, context.Cause(attemptCtx))
+		if !retry {
+			result.Attempts = append(result.Attempts, record)
+			return resp, attemptCtx, cancel, err
+		}
+
+		delay, fromRetryAfter := retryDelay(policy, attempt, resp, time.Now())
+		record.DelayMs = durationMs(delay)
+		record.RetryAfter = fromRetryAfter
+		result.Attempts = append(result.Attempts, record)
+
+		if resp != nil {
+			// Drain the body so the connection can be reused for the next attempt
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+			resp.Body.Close()
+		}
+		cancel()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx, func() {}, ctx.Err()
+		}
+	}
+}
+
+// attemptRequest returns a copy of req bound to ctx. Attempts after the first one get a fresh body.
+func attemptRequest(ctx context.Context, req *http.Request, attempt int) (*http.Request, error) {
+	next := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// shouldRetry reports whether policy retries an attempt that got resp, or failed with err.
+// cause is the cancellation cause of the context of the attempt, set when it timed out.
+func shouldRetry(policy *models.RetryPolicy, resp *http.Response, err error, cause error) bool {
+	if err != nil {
+		kind := networkErrorKind(err)
+		if errors.Is(cause, context.DeadlineExceeded) {
+			kind = "timeout"
+		}
+		return kind != "" && slices.Contains(policy.NetworkErrors, kind)
+	}
+	return slices.Contains(policy.StatusCodes, resp.StatusCode)
+}
+
+// networkErrorKind classifies err into the network errors a RetryPolicy can retry.
+// Other failures, such as invalid auth settings, return an empty string.
+func networkErrorKind(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection_refused"
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNABORTED), errors.Is(err, syscall.EPIPE):
+		return "connection_reset"
+	case errors.As(err, &dnsErr):
+		return "dns"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return "eof"
+	}
+	return ""
+}
+
+// retryDelay returns how long to wait before the attempt following attempt, and whether it came
+// from the Retry-After header of resp. Retry-After is capped at the maximum delay too, so a server
+// asking for hours doesn't hold the execution that long.
+func retryDelay(policy *models.RetryPolicy, attempt int, resp *http.Response, now time.Time) (delay time.Duration, fromRetryAfter bool) {
+	maxDelay := defaultRetryMaxDelay
+	if policy.MaxDelayMs > 0 {
+		maxDelay = time.Duration(policy.MaxDelayMs) * time.Millisecond
+	}
+
+	if resp != nil && !policy.IgnoreRetryAfter {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return min(delay, maxDelay), true
+		}
+	}
+
+	delay = defaultRetryInitialDelay
+	if policy.InitialDelayMs > 0 {
+		delay = time.Duration(policy.InitialDelayMs) * time.Millisecond
+	}
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	// Equal jitter: keep half of the delay and randomize the other half, so clients that failed
+	// together don't retry together
+	half := delay / 2
+	return half + rand.N(delay-half+1), false
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an HTTP date
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+
+	tests := []struct {
+		name           string
+		policy         models.RetryPolicy
+		attempt        int
+		resp           *http.Response
+		min, max       time.Duration
+		fromRetryAfter bool
+	}{
+		{"first backoff", models.RetryPolicy{}, 1, nil, 250 * time.Millisecond, 500 * time.Millisecond, false},
+		{"doubled backoff", models.RetryPolicy{InitialDelayMs: 100}, 3, nil, 200 * time.Millisecond, 400 * time.Millisecond, false},
+		{"backoff capped", models.RetryPolicy{InitialDelayMs: 1000, MaxDelayMs: 1500}, 5, nil, 750 * time.Millisecond, 1500 * time.Millisecond, false},
+		{"retry-after seconds", models.RetryPolicy{}, 1, retryAfter("3"), 3 * time.Second, 3 * time.Second, true},
+		{"retry-after date", models.RetryPolicy{}, 1, retryAfter("Mon, 01 Jan 2024 12:00:05 GMT"), 5 * time.Second, 5 * time.Second, true},
+		{"retry-after past date", models.RetryPolicy{}, 1, retryAfter("Mon, 01 Jan 2024 11:00:00 GMT"), 0, 0, true},
+		{"retry-after capped", models.RetryPolicy{MaxDelayMs: 2000}, 1, retryAfter("3600"), 2 * time.Second, 2 * time.Second, true},
+		{"retry-after capped by default", models.RetryPolicy{}, 1, retryAfter("3600"), 30 * time.Second, 30 * time.Second, true},
+		{"retry-after ignored", models.RetryPolicy{IgnoreRetryAfter: true}, 1, retryAfter("3"), 250 * time.Millisecond, 500 * time.Millisecond, false},
+		{"retry-after invalid", models.RetryPolicy{}, 1, retryAfter("soon"), 250 * time.Millisecond, 500 * time.Millisecond, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, fromRetryAfter := retryDelay(&tt.policy, tt.attempt, tt.resp, now)
+			if delay < tt.min || delay > tt.max {
+				t.Errorf("got delay %v, want between %v and %v", delay, tt.min, tt.max)
+			}
+			if fromRetryAfter != tt.fromRetryAfter {
+				t.Errorf("got fromRetryAfter %v, want %v", fromRetryAfter, tt.fromRetryAfter)
+			}
+		})
+	}
+}
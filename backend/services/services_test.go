@@ -0,0 +1,16 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"testing"
+)
+
+// useTestDatabase points the database at a fresh file for the duration of t
+func useTestDatabase(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	database.InitDB()
+	t.Cleanup(func() {
+		database.DB.Close()
+	})
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sigV4TestConfig holds the credentials and scope of the AWS Signature Version 4 test suite
+var sigV4TestConfig = &models.AWSConfig{
+	AccessKey: "AKIDEXAMPLE",
+	SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	Region:    "us-east-1",
+	Service:   "service",
+}
+
+func TestSignAWSV4(t *testing.T) {
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		header        http.Header
+		body          string
+		signedHeaders string
+		signature     string
+	}{
+		// Cases of the AWS test suite
+		{
+			name:          "get-vanilla",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "get-vanilla-query-order-key-case",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			signedHeaders: "host;x-amz-date",
+			signature:     "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:          "get-vanilla-query-order-value",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?Param1=value2&Param1=Value1",
+			signedHeaders: "host;x-amz-date",
+			signature:     "eedbc4e291e521cf13422ffca22be7d2eb8146eecf653089df300a15b2382bd1",
+		},
+		{
+			name:          "get-vanilla-query-unreserved",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz=-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+			signedHeaders: "host;x-amz-date",
+			signature:     "9c3e54bfcdf0b19771a7f523ee5669cdf59bc7cc0884027167c21bb143a40197",
+		},
+		{
+			name:          "get-vanilla-utf8-query",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?ሴ=bar",
+			signedHeaders: "host;x-amz-date",
+			signature:     "2cdec8eed098649ff3a119c94853b13c643bcf08f8b0a1d91e12c9027818dd04",
+		},
+		{
+			name:          "get-utf8",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/ሴ",
+			signedHeaders: "host;x-amz-date",
+			signature:     "697b34846207a3f72246f99d74ae1ee4fe54f44bb06730c58a0d339eb079596d",
+		},
+		{
+			name:          "get-unreserved",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+			signedHeaders: "host;x-amz-date",
+			signature:     "07ef7494c76fa4850883e2b006601f940f8a34d404d0cfa977f52a65bbf5f24f",
+		},
+		{
+			name:          "post-vanilla",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:          "post-header-key-sort",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			header:        http.Header{"My-Header1": {"value1"}},
+			signedHeaders: "host;my-header1;x-amz-date",
+			signature:     "c5410059b04c1ee005303aed430f6e6645f61f4dc9e1461ec8f8916fdf18852c",
+		},
+		{
+			name:          "post-x-www-form-urlencoded",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			header:        http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+			body:          "Param1=value1",
+			signedHeaders: "content-type;host;x-amz-date",
+			signature:     "ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+
+		// Paths and queries the suite leaves out, checked against the AWS SDK for Go
+		{
+			name:          "reserved characters in the path",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/a,b:c@d=e!$(f)*+;g",
+			signedHeaders: "host;x-amz-date",
+			signature:     "00eb7240a8278a2e5d061468b2df56d74e0dc19bbae8120b5f9b02c8898c5afc",
+		},
+		{
+			name:          "escaped path",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/a%20b/c%2Fd",
+			signedHeaders: "host;x-amz-date",
+			signature:     "a015660cab6eca543492741e8b7debfa9e32f17beca099c5272132c01cdde558",
+		},
+		{
+			name:          "query name prefixing another",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?a-b=1&a=2",
+			signedHeaders: "host;x-amz-date",
+			signature:     "3195c10f6c70f9392a7764f6f83099349c32cf39a12222f775fca70b6227a5a4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.url, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			err = signAWSV4(req, sigV4TestConfig, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=" +
+				test.signedHeaders + ", Signature=" + test.signature
+			if got := req.Header.Get("Authorization"); got != want {
+				t.Errorf("got Authorization\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestSigV4Path(t *testing.T) {
+	tests := []struct {
+		url     string
+		service string
+		want    string
+	}{
+		{"https://example.com", "execute-api", "/"},
+		{"https://example.com/a,b:c@d", "execute-api", "/a%2Cb%3Ac%40d"},
+		{"https://example.com/a%20b/c%2Fd", "execute-api", "/a%2520b/c%252Fd"},
+		{"https://bucket.s3.amazonaws.com", "s3", "/"},
+		{"https://bucket.s3.amazonaws.com/a,b:c@d", "s3", "/a%2Cb%3Ac%40d"},
+		{"https://bucket.s3.amazonaws.com/photos/a%20b.jpg", "s3", "/photos/a%20b.jpg"},
+	}
+
+	for _, test := range tests {
+		u, err := url.Parse(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sigV4Path(u, test.service); got != test.want {
+			t.Errorf("sigV4Path(%s, %s) = %s, want %s", test.url, test.service, got, test.want)
+		}
+	}
+}
+
+func TestSigV4Query(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"b=2&a=1", "a=1&b=2"},
+		{"a=2&a=1&a=10", "a=1&a=10&a=2"},
+		{"a=2&a-b=1", "a=2&a-b=1"},
+		{"a b=c d&e=%2F", "a%20b=c%20d&e=%2F"},
+	}
+
+	for _, test := range tests {
+		if got := sigV4Query(&url.URL{RawQuery: test.query}); got != test.want {
+			t.Errorf("sigV4Query(%q) = %q, want %q", test.query, got, test.want)
+		}
+	}
+}
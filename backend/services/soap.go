@@ -0,0 +1,96 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"encoding/xml"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Namespaces of the SOAP envelopes
+const (
+	soap11EnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12EnvelopeNamespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// soapFaultElement is the Fault of either SOAP version. Fields of SOAP 1.1 faults are unqualified,
+// while SOAP 1.2 ones are in the envelope namespace, so they're matched by local name.
+type soapFaultElement struct {
+	// SOAP 1.1
+	FaultCode   string       `xml:"faultcode"`
+	FaultString string       `xml:"faultstring"`
+	FaultActor  string       `xml:"faultactor"`
+	FaultDetail *xmlInnerXML `xml:"detail"`
+
+	// SOAP 1.2
+	Code   soapFaultCode `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Node   string       `xml:"Node"`
+	Role   string       `xml:"Role"`
+	Detail *xmlInnerXML `xml:"Detail"`
+}
+
+type soapFaultCode struct {
+	Value   string         `xml:"Value"`
+	Subcode *soapFaultCode `xml:"Subcode"`
+}
+
+type xmlInnerXML struct {
+	Content string `xml:",innerxml"`
+}
+
+// soapFault parses the fault of the SOAP envelope in the body of result. Bodies that aren't SOAP
+// envelopes, or whose envelope holds no fault, have none.
+func soapFault(result *models.ExecutionResult) *models.SOAPFault {
+	if result.BodyEncoding != BodyEncodingText || result.Truncated || !strings.Contains(result.ContentType, "xml") {
+		return nil
+	}
+
+	var envelope struct {
+		XMLName xml.Name
+		Body    struct {
+			Fault *soapFaultElement `xml:"Fault"`
+		} `xml:"Body"`
+	}
+	decoder := xml.NewDecoder(strings.NewReader(result.Body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	if decoder.Decode(&envelope) != nil || envelope.XMLName.Local != "Envelope" || envelope.Body.Fault == nil {
+		return nil
+	}
+
+	fault := envelope.Body.Fault
+	switch envelope.XMLName.Space {
+	case soap11EnvelopeNamespace:
+		parsed := &models.SOAPFault{
+			Version: "1.1",
+			Code:    strings.TrimSpace(fault.FaultCode),
+			Reason:  strings.TrimSpace(fault.FaultString),
+			Actor:   strings.TrimSpace(fault.FaultActor),
+		}
+		if fault.FaultDetail != nil {
+			parsed.Detail = strings.TrimSpace(fault.FaultDetail.Content)
+		}
+		return parsed
+	case soap12EnvelopeNamespace:
+		parsed := &models.SOAPFault{
+			Version: "1.2",
+			Code:    strings.TrimSpace(fault.Code.Value),
+			Actor:   strings.TrimSpace(fault.Role),
+			Node:    strings.TrimSpace(fault.Node),
+		}
+		for subcode := fault.Code.Subcode; subcode != nil; subcode = subcode.Subcode {
+			parsed.Subcodes = append(parsed.Subcodes, strings.TrimSpace(subcode.Value))
+		}
+		if len(fault.Reason.Text) > 0 {
+			parsed.Reason = strings.TrimSpace(fault.Reason.Text[0])
+		}
+		if fault.Detail != nil {
+			parsed.Detail = strings.TrimSpace(fault.Detail.Content)
+		}
+		return parsed
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"bufio"
+	"bytes"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxEventStreamLine caps the length of a single line of an event stream
+const maxEventStreamLine = 1 << 20
+
+// streamsEvents tells whether the body of resp is read as an event stream rather than all at once
+func streamsEvents(resp *http.Response, settings models.RequestSettings) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "text/event-stream" && settings.SaveToFile == "" && resp.Request.Method != http.MethodHead
+}
+
+// readEventStream reads the event stream of resp until the server ends it or the execution is
+// stopped, emitting every event to the frontend as soon as it's complete. The events are kept in
+// result along with the raw stream, which is cut at the configured response size limit.
+func (s *APIClientService) readEventStream(resp *http.Response, settings models.RequestSettings, result *models.ExecutionResult) error {
+	maxBytes := settings.MaxResponseBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxResponseBytes
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, maxEventStreamLine)
+	scanner.Split(scanEventStreamLines)
+
+	var raw strings.Builder
+	parser := eventStreamParser{executionID: result.ExecutionID}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if int64(raw.Len()+len(line)+1) <= maxBytes {
+			raw.WriteString(line + "\n")
+		} else {
+			result.Truncated = true
+		}
+
+		if event, ok := parser.parseLine(line); ok {
+			result.Events = append(result.Events, event)
+			s.emit(EventServerSentEvent, event)
+		}
+	}
+
+	result.Body = raw.String()
+	result.BodySize = int64(raw.Len())
+	result.BodyEncoding = BodyEncodingText
+	return scanner.Err()
+}
+
+// eventStreamParser turns the lines of an event stream into events, following the
+// interpretation of the HTML Living Standard
+type eventStreamParser struct {
+	executionID string
+	lastID      string
+	event       string
+	data        strings.Builder
+	retry       int
+}
+
+// parseLine processes a line and returns the event it completes, if any
+func (p *eventStreamParser) parseLine(line string) (models.ServerSentEvent, bool) {
+	if line == "" {
+		return p.dispatch()
+	}
+	if strings.HasPrefix(line, ":") {
+		// Comment, often sent to keep the connection alive
+		return models.ServerSentEvent{}, false
+	}
+
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "event":
+		p.event = value
+	case "data":
+		p.data.WriteString(value + "\n")
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			p.lastID = value
+		}
+	case "retry":
+		if retry, err := strconv.Atoi(value); err == nil && retry >= 0 && !strings.HasPrefix(value, "+") {
+			p.retry = retry
+		}
+	}
+	return models.ServerSentEvent{}, false
+}
+
+// dispatch completes the pending event. Events without data are dropped, as browsers do.
+func (p *eventStreamParser) dispatch() (models.ServerSentEvent, bool) {
+	data := p.data.String()
+	event := models.ServerSentEvent{
+		ExecutionID: p.executionID,
+		Event:       p.event,
+		ID:          p.lastID,
+		Data:        strings.TrimSuffix(data, "\n"),
+		Retry:       p.retry,
+		ReceivedAt:  time.Now(),
+	}
+
+	p.event = ""
+	p.data.Reset()
+	p.retry = 0
+
+	if data == "" {
+		return models.ServerSentEvent{}, false
+	}
+	if event.Event == "" {
+		event.Event = "message"
+	}
+	return event, true
+}
+
+// scanEventStreamLines is a bufio.SplitFunc for the lines of an event stream, which may end with
+// CRLF, LF or a lone CR
+func scanEventStreamLines(data []byte, atEOF bool) (int, []byte, error) {
+	i := bytes.IndexAny(data, "\r\n")
+	if i < 0 {
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+
+	if data[i] == '\r' {
+		if i+1 < len(data) && data[i+1] == '\n' {
+			return i + 2, data[:i], nil
+		}
+		if i+1 == len(data) && !atEOF {
+			// Wait to know whether the CR is followed by a LF
+			return 0, nil, nil
+		}
+	}
+	return i + 1, data[:i], nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"bufio"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSaveHistoryOnlyForSavedRequests(t *testing.T) {
+	useTestDatabase(t)
+
+	result := &models.ExecutionResult{Status: 200}
+	events := []models.ServerSentEvent{{Data: "hello"}}
+
+	err := saveHistory(nil, result, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requestID := 7
+	err = saveHistory(&requestID, result, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := database.GetRequestHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].RequestID != requestID {
+		t.Fatalf("got %d history entries, want one of request %d", len(history), requestID)
+	}
+}
+
+func TestScanEventStreamLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   []string
+	}{
+		{"lf", "data: a\n\ndata: b\n", []string{"data: a", "", "data: b"}},
+		{"crlf", "data: a\r\n\r\ndata: b\r\n", []string{"data: a", "", "data: b"}},
+		{"cr", "data: a\r\rdata: b\r", []string{"data: a", "", "data: b"}},
+		{"mixed", "a\rb\nc\r\n\r\nd", []string{"a", "b", "c", "", "d"}},
+		{"no final newline", "data: a", []string{"data: a"}},
+		{"cr at the end", "data: a\r", []string{"data: a"}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reading a byte at a time splits CRLF across reads
+			for _, reader := range []io.Reader{strings.NewReader(tt.stream), iotest.OneByteReader(strings.NewReader(tt.stream))} {
+				scanner := bufio.NewScanner(reader)
+				scanner.Split(scanEventStreamLines)
+				var got []string
+				for scanner.Scan() {
+					got = append(got, scanner.Text())
+				}
+				if err := scanner.Err(); err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("got lines %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStreamParser(t *testing.T) {
+	type event struct {
+		event, id, data string
+		retry           int
+	}
+	tests := []struct {
+		name   string
+		stream string // lines end with LF, and are also tested with CRLF and CR
+		want   []event
+	}{
+		{"dispatch on blank line", "data: hello\n\n", []event{{"message", "", "hello", 0}}},
+		{"no blank line", "data: hello\n", nil},
+		{"multi-line data", "data: a\ndata: b\ndata\n\n", []event{{"message", "", "a\nb\n", 0}}},
+		{"named event", "event: update\ndata: {}\n\ndata: next\n\n", []event{{"update", "", "{}", 0}, {"message", "", "next", 0}}},
+		{"only one space is stripped", "data:x\n\ndata:  y\n\n", []event{{"message", "", "x", 0}, {"message", "", " y", 0}}},
+		{"field without colon", "data\n\n", []event{{"message", "", "", 0}}},
+		{"comments", ": keep-alive\ndata: a\n:\n\n", []event{{"message", "", "a", 0}}},
+		{"unknown fields", "foo: bar\ndata: a\n\n", []event{{"message", "", "a", 0}}},
+		{"events without data are dropped", "event: ping\n\ndata: a\n\n", []event{{"message", "", "a", 0}}},
+		{
+			"id persists",
+			"id: 1\ndata: a\n\ndata: b\n\nid: 2\n\ndata: c\n\nid\ndata: d\n\n",
+			[]event{{"message", "1", "a", 0}, {"message", "1", "b", 0}, {"message", "2", "c", 0}, {"message", "", "d", 0}},
+		},
+		{"id with null is ignored", "id: 1\ndata: a\n\nid: 2\x003\ndata: b\n\n", []event{{"message", "1", "a", 0}, {"message", "1", "b", 0}}},
+		{
+			"retry",
+			"retry: 3000\ndata: a\n\ndata: b\n\nretry: soon\ndata: c\n\nretry: +5\ndata: d\n\nretry: -1\ndata: e\n\n",
+			[]event{{"message", "", "a", 3000}, {"message", "", "b", 0}, {"message", "", "c", 0}, {"message", "", "d", 0}, {"message", "", "e", 0}},
+		},
+	}
+	for _, tt := range tests {
+		for _, ending := range []string{"\n", "\r\n", "\r"} {
+			t.Run(tt.name+strconv.Quote(ending), func(t *testing.T) {
+				scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(tt.stream, "\n", ending)))
+				scanner.Split(scanEventStreamLines)
+				parser := eventStreamParser{executionID: "exec"}
+
+				var got []event
+				for scanner.Scan() {
+					if e, ok := parser.parseLine(scanner.Text()); ok {
+						if e.ExecutionID != "exec" || e.ReceivedAt.IsZero() {
+							t.Errorf("got event %+v without its execution ID and time", e)
+						}
+						got = append(got, event{e.Event, e.ID, e.Data, e.Retry})
+					}
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("got events %+v, want %+v", got, tt.want)
+				}
+			})
+		}
+	}
+}
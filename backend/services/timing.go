@@ -0,0 +1,105 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// timingTrace records when each phase of an HTTP exchange starts and ends
+type timingTrace struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+func newTimingTrace() *timingTrace {
+	return &timingTrace{start: time.Now()}
+}
+
+// clientTrace returns the httptrace hooks that feed t
+func (t *timingTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart: func(string, string) {
+			t.markFirst(&t.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			t.mark(&t.connectDone)
+		},
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+}
+
+// restart forgets the phases recorded so far but not the start, so a retried execution reports
+// the phases of its last attempt and a total covering all of them
+func (t *timingTrace) restart() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, at := range []*time.Time{&t.dnsStart, &t.dnsDone, &t.connectStart, &t.connectDone,
+		&t.tlsStart, &t.tlsDone, &t.wroteRequest, &t.firstByte} {
+		*at = time.Time{}
+	}
+}
+
+func (t *timingTrace) mark(at *time.Time) {
+	t.mu.Lock()
+	*at = time.Now()
+	t.mu.Unlock()
+}
+
+// markFirst only records the first occurrence, as several dials may race for one connection
+func (t *timingTrace) markFirst(at *time.Time) {
+	t.mu.Lock()
+	if at.IsZero() {
+		*at = time.Now()
+	}
+	t.mu.Unlock()
+}
+
+// timings breaks the exchange down into phases, given the moment the body was fully read.
+// Phases that didn't happen, such as DNS on a reused connection, are reported as 0.
+func (t *timingTrace) timings(end time.Time) models.ExecutionTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := models.ExecutionTimings{
+		DNSLookup:    phaseMs(t.dnsStart, t.dnsDone),
+		TCPConnect:   phaseMs(t.connectStart, t.connectDone),
+		TLSHandshake: phaseMs(t.tlsStart, t.tlsDone),
+		Total:        durationMs(end.Sub(t.start)),
+	}
+
+	if !t.firstByte.IsZero() {
+		timings.FirstByte = phaseMs(t.wroteRequest, t.firstByte)
+		timings.Download = phaseMs(t.firstByte, end)
+	}
+
+	return timings
+}
+
+func phaseMs(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return durationMs(end.Sub(start))
+}
+
+// durationMs converts d to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"net"
+	"strings"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// matchesHost reports whether pattern applies to host. Patterns may start with a *. wildcard
+// matching any subdomain, and may include a port that must then match too.
+func matchesHost(pattern, host, defaultPort string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return false
+	}
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname, port = host, defaultPort
+	}
+	hostname = strings.ToLower(hostname)
+
+	patternHost, patternPort, err := net.SplitHostPort(pattern)
+	if err != nil {
+		patternHost, patternPort = pattern, ""
+	}
+	if patternPort != "" && patternPort != port {
+		return false
+	}
+
+	if suffix, ok := strings.CutPrefix(patternHost, "*."); ok {
+		return strings.HasSuffix(hostname, "."+suffix)
+	}
+	return patternHost == hostname
+}
+
+// tlsConfigFor builds the TLS configuration for host out of the certificates matching it
+func tlsConfigFor(host, defaultPort string, certificates []*models.Certificate, skipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: skipVerify,
+	}
+
+	for _, certificate := range certificates {
+		if !certificate.Enabled || !matchesHost(certificate.HostPattern, host, defaultPort) {
+			continue
+		}
+
+		if strings.TrimSpace(certificate.CA) != "" {
+			if config.RootCAs == nil {
+				pool, err := x509.SystemCertPool()
+				if err != nil {
+					pool = x509.NewCertPool()
+				}
+				config.RootCAs = pool
+			}
+			if !config.RootCAs.AppendCertsFromPEM([]byte(certificate.CA)) {
+				return nil, fmt.Errorf("certificate %q: no valid CA certificates found", certificate.Name)
+			}
+		}
+
+		if strings.TrimSpace(certificate.Cert) != "" {
+			keyPair, err := loadKeyPair(certificate)
+			if err != nil {
+				return nil, fmt.Errorf("certificate %q: %w", certificate.Name, err)
+			}
+			config.Certificates = append(config.Certificates, keyPair)
+		}
+	}
+
+	return config, nil
+}
+
+// loadKeyPair decodes the client certificate and private key of certificate
+func loadKeyPair(certificate *models.Certificate) (tls.Certificate, error) {
+	if certificate.Format == "pkcs12" {
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(certificate.Cert))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("invalid base64 PKCS#12 bundle: %w", err)
+		}
+
+		key, first, rest, err := pkcs12.DecodeChain(data, certificate.Passphrase)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+
+		var certPEM []byte
+		for _, cert := range leafFirst(key, append([]*x509.Certificate{first}, rest...)) {
+			certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+		}
+		return tls.X509KeyPair(certPEM, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+	}
+
+	keyPEM, err := decryptKey([]byte(certificate.Key), certificate.Passphrase)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair([]byte(certificate.Cert), keyPEM)
+}
+
+// leafFirst moves the certificate of key in front of the others, as TLS sends the leaf first.
+// Bundles don't all order their certificates that way.
+func leafFirst(key any, certs []*x509.Certificate) []*x509.Certificate {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return certs
+	}
+	public, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return certs
+	}
+
+	for i, cert := range certs {
+		if public.Equal(cert.PublicKey) {
+			ordered := append([]*x509.Certificate{cert}, certs[:i]...)
+			return append(ordered, certs[i+1:]...)
+		}
+	}
+	return certs
+}
+
+// decryptKey returns keyPEM with its private key decrypted when it is protected by a passphrase.
+// Only PKCS#8 encryption is supported: the legacy PEM encryption of OpenSSL is insecure by design.
+func decryptKey(keyPEM []byte, passphrase string) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM private key found")
+	}
+
+	if _, ok := block.Headers["DEK-Info"]; ok {
+		return nil, errors.New("legacy encrypted PEM private keys are not supported, convert the key to encrypted PKCS#8, e.g. with openssl pkcs8 -topk8, or use a PKCS#12 bundle")
+	}
+	if block.Type != "ENCRYPTED PRIVATE KEY" {
+		return keyPEM, nil
+	}
+	if passphrase == "" {
+		return nil, errors.New("private key is encrypted but no passphrase was given")
+	}
+
+	der, err := decryptPKCS8(block.Bytes, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// Object identifiers of the PKCS#8 encryption supported by decryptPKCS8 (RFC 8018)
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts an EncryptedPrivateKeyInfo protected with PBES2, using PBKDF2 and AES-CBC as
+// OpenSSL does by default, into the DER of a PKCS#8 private key
+func decryptPKCS8(der []byte, passphrase string) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid encrypted PKCS#8 private key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("encrypted PKCS#8 private keys using %s are not supported, only PBES2", info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBES2 parameters: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("PBES2 key derivation %s is not supported, only PBKDF2", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("invalid PBKDF2 parameters: %w", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdf.PRF.Algorithm) == 0, kdf.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("PBKDF2 function %s is not supported", kdf.PRF.Algorithm)
+	}
+
+	var keyLength int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("PBES2 cipher %s is not supported, only AES-CBC", params.EncryptionScheme.Algorithm)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid AES-CBC initialization vector")
+	}
+
+	key, err := pbkdf2.Key(prf, passphrase, kdf.Salt, kdf.IterationCount, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data := info.EncryptedData
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted PKCS#8 private key length")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// A wrong passphrase shows as invalid padding, or else as a key that doesn't parse
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("incorrect passphrase for the private key")
+	}
+	plain = plain[:len(plain)-padding]
+	if _, err := x509.ParsePKCS8PrivateKey(plain); err != nil {
+		return nil, errors.New("incorrect passphrase for the private key")
+	}
+	return plain, nil
+}
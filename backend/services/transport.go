@@ -0,0 +1,143 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// unixSocketScheme prefixes URLs sent through a Unix domain socket, written as
+// unix://<socket path>:<request path>, e.g. unix:///var/run/docker.sock:/v1.43/containers/json
+const unixSocketScheme = "unix://"
+
+// executionTransport sends each request through an *http.Transport configured for its host,
+// so per-host settings such as client certificates still apply after a redirect to another host
+type executionTransport struct {
+	settings     models.RequestSettings
+	certificates []*models.Certificate
+	proxy        func(*http.Request) (*url.URL, error)
+
+	mu         sync.Mutex
+	transports map[string]*http.Transport
+	lastProxy  *url.URL
+}
+
+func newExecutionTransport(settings models.RequestSettings, certificates []*models.Certificate, proxy *models.Proxy) (*executionTransport, error) {
+	// Requests sent through a Unix socket never go through a proxy
+	if settings.UnixSocket != "" {
+		proxy = nil
+	}
+	choose, err := proxyFunc(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &executionTransport{
+		settings:     settings,
+		certificates: certificates,
+		proxy:        choose,
+		transports:   map[string]*http.Transport{},
+	}, nil
+}
+
+func (t *executionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport, err := t.transportFor(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.proxy != nil {
+		proxyURL, err := t.proxy(req)
+		if err != nil {
+			return nil, err
+		}
+		t.mu.Lock()
+		t.lastProxy = proxyURL
+		t.mu.Unlock()
+	}
+
+	return transport.RoundTrip(req)
+}
+
+// usedProxy returns the proxy the last request went through, without credentials
+func (t *executionTransport) usedProxy() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.lastProxy == nil {
+		return ""
+	}
+	return t.lastProxy.Redacted()
+}
+
+func (t *executionTransport) transportFor(req *http.Request) (*http.Transport, error) {
+	key := req.URL.Scheme + "://" + req.URL.Host
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if transport, ok := t.transports[key]; ok {
+		return transport, nil
+	}
+
+	defaultPort := "443"
+	if req.URL.Scheme == "http" {
+		defaultPort = "80"
+	}
+
+	tlsConfig, err := tlsConfigFor(req.URL.Host, defaultPort, t.certificates, t.settings.SkipTLSVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = t.proxy
+	transport.TLSClientConfig = tlsConfig
+	if t.settings.UnixSocket != "" {
+		socket := t.settings.UnixSocket
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+	t.transports[key] = transport
+	return transport, nil
+}
+
+// close releases the connections kept open by the transports of the execution
+func (t *executionTransport) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, transport := range t.transports {
+		transport.CloseIdleConnections()
+	}
+}
+
+// unixSocketURL splits a unix:// URL into the path of its socket and the HTTP URL requested
+// through it. Other URLs are returned unchanged, without a socket.
+func unixSocketURL(rawURL string) (socket, target string, err error) {
+	if len(rawURL) < len(unixSocketScheme) || !strings.EqualFold(rawURL[:len(unixSocketScheme)], unixSocketScheme) {
+		return "", rawURL, nil
+	}
+
+	rest := rawURL[len(unixSocketScheme):]
+	socket, path, found := strings.Cut(rest, ":/")
+	if !found {
+		// Without a request path, a query string still belongs to the request rather than the socket
+		socket, path, found = strings.Cut(rest, "?")
+		if found {
+			path = "?" + path
+		}
+	}
+	if socket == "" {
+		return "", "", errors.New("the unix URL has no socket path")
+	}
+	// The Host header of the requests is localhost, as curl --unix-socket sends
+	return socket, "http://localhost/" + path, nil
+}
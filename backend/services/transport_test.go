@@ -0,0 +1,38 @@
+package services
+
+import "testing"
+
+func TestUnixSocketURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		socket string
+		target string
+		err    bool
+	}{
+		{"http URL", "http://localhost:8080/ping", "", "http://localhost:8080/ping", false},
+		{"short URL", "unix", "", "unix", false},
+		{"request path", "unix:///var/run/docker.sock:/v1.43/containers/json", "/var/run/docker.sock", "http://localhost/v1.43/containers/json", false},
+		{"uppercase scheme", "UNIX:///var/run/docker.sock:/_ping", "/var/run/docker.sock", "http://localhost/_ping", false},
+		{"relative socket", "unix://api.sock:/health", "api.sock", "http://localhost/health", false},
+		{"no path", "unix:///var/run/docker.sock", "/var/run/docker.sock", "http://localhost/", false},
+		{"root path", "unix:///var/run/docker.sock:/", "/var/run/docker.sock", "http://localhost/", false},
+		{"query string", "unix:///var/run/docker.sock:/containers/json?all=1&limit=5", "/var/run/docker.sock", "http://localhost/containers/json?all=1&limit=5", false},
+		{"query string without path", "unix:///tmp/api.sock?verbose=1", "/tmp/api.sock", "http://localhost/?verbose=1", false},
+		{"colon without separator", "unix:///tmp/api.sock:v1/info", "/tmp/api.sock:v1/info", "http://localhost/", false},
+		{"no socket", "unix://", "", "", true},
+		{"no socket with path", "unix://:/ping", "", "", true},
+		{"no socket with query", "unix://?all=1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socket, target, err := unixSocketURL(tt.url)
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want error %v", err, tt.err)
+			}
+			if socket != tt.socket || target != tt.target {
+				t.Errorf("got socket %q and target %q, want %q and %q", socket, target, tt.socket, tt.target)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// variablePattern matches {{name}} placeholders, allowing whitespace around the name
+var variablePattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// variableResolver expands {{name}} placeholders, including placeholders nested inside variable values
+// and the built-in {{$name}} dynamic variables
+type variableResolver struct {
+	vars       map[string]string
+	resolved   map[string]string
+	generated  map[string]string
+	unresolved map[string]bool
+	err        error
+}
+
+func newVariableResolver(vars map[string]string) *variableResolver {
+	return &variableResolver{
+		vars:       vars,
+		resolved:   map[string]string{},
+		generated:  map[string]string{},
+		unresolved: map[string]bool{},
+	}
+}
+
+// resolve expands every placeholder in s. Unknown placeholders are left as they are.
+func (r *variableResolver) resolve(s string) string {
+	return r.expand(s, nil)
+}
+
+func (r *variableResolver) expand(s string, chain []string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+
+		if value, ok := r.resolved[name]; ok {
+			return value
+		}
+
+		if slices.Contains(chain, name) {
+			if r.err == nil {
+				r.err = fmt.Errorf("variable cycle detected: %s -> %s", strings.Join(chain, " -> "), name)
+			}
+			return match
+		}
+
+		value, ok := r.vars[name]
+		if !ok {
+			generate, isDynamic := dynamicVariables[name]
+			if !isDynamic {
+				r.unresolved[name] = true
+				return match
+			}
+			value = generate()
+			r.generated[name] = value
+			r.resolved[name] = value
+			return value
+		}
+
+		value = r.expand(value, append(chain, name))
+		if r.err == nil {
+			r.resolved[name] = value
+		}
+		return value
+	})
+}
+
+// unresolvedNames returns the sorted names of placeholders that had no matching variable
+func (r *variableResolver) unresolvedNames() []string {
+	names := make([]string, 0, len(r.unresolved))
+	for name := range r.unresolved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveKeyValues returns a copy of pairs with placeholders expanded in keys and values
+func (r *variableResolver) resolveKeyValues(pairs []models.KeyValue) []models.KeyValue {
+	resolved := make([]models.KeyValue, len(pairs))
+	for i, pair := range pairs {
+		resolved[i] = pair
+		if pair.Enabled {
+			resolved[i].Key = r.resolve(pair.Key)
+			resolved[i].Value = r.resolve(pair.Value)
+		}
+	}
+	return resolved
+}
+
+// resolveSpec returns a copy of spec with placeholders expanded in every part of the request
+func (r *variableResolver) resolveSpec(spec models.RequestSpec) (models.RequestSpec, error) {
+	spec.URL = r.resolve(spec.URL)
+	spec.Headers = r.resolveKeyValues(spec.Headers)
+	spec.QueryParams = r.resolveKeyValues(spec.QueryParams)
+	spec.Body = r.resolve(spec.Body)
+
+	fields := make([]models.FormField, len(spec.FormFields))
+	for i, field := range spec.FormFields {
+		fields[i] = field
+		if field.Enabled {
+			fields[i].Key = r.resolve(field.Key)
+			fields[i].Value = r.resolve(field.Value)
+			fields[i].FilePath = r.resolve(field.FilePath)
+		}
+	}
+	spec.FormFields = fields
+
+	if spec.GraphQL != nil {
+		graphql := *spec.GraphQL
+		graphql.Query = r.resolve(graphql.Query)
+		graphql.Variables = r.resolve(graphql.Variables)
+		graphql.OperationName = r.resolve(graphql.OperationName)
+		graphql.ConnectionParams = r.resolve(graphql.ConnectionParams)
+		spec.GraphQL = &graphql
+	}
+
+	if spec.Auth != nil {
+		auth := *spec.Auth
+		auth.Token = r.resolve(auth.Token)
+		auth.Username = r.resolve(auth.Username)
+		auth.Password = r.resolve(auth.Password)
+		auth.Key = r.resolve(auth.Key)
+		auth.Value = r.resolve(auth.Value)
+		if auth.OAuth1 != nil {
+			oauth1 := *auth.OAuth1
+			oauth1.ConsumerKey = r.resolve(oauth1.ConsumerKey)
+			oauth1.ConsumerSecret = r.resolve(oauth1.ConsumerSecret)
+			oauth1.Token = r.resolve(oauth1.Token)
+			oauth1.TokenSecret = r.resolve(oauth1.TokenSecret)
+			oauth1.PrivateKey = r.resolve(oauth1.PrivateKey)
+			oauth1.Realm = r.resolve(oauth1.Realm)
+			auth.OAuth1 = &oauth1
+		}
+		if auth.OAuth2 != nil {
+			oauth2 := *auth.OAuth2
+			oauth2.TokenURL = r.resolve(oauth2.TokenURL)
+			oauth2.AuthURL = r.resolve(oauth2.AuthURL)
+			oauth2.ClientID = r.resolve(oauth2.ClientID)
+			oauth2.ClientSecret = r.resolve(oauth2.ClientSecret)
+			oauth2.Scope = r.resolve(oauth2.Scope)
+			oauth2.Username = r.resolve(oauth2.Username)
+			oauth2.Password = r.resolve(oauth2.Password)
+			auth.OAuth2 = &oauth2
+		}
+		if auth.AWS != nil {
+			aws := *auth.AWS
+			aws.AccessKey = r.resolve(aws.AccessKey)
+			aws.SecretKey = r.resolve(aws.SecretKey)
+			aws.SessionToken = r.resolve(aws.SessionToken)
+			aws.Region = r.resolve(aws.Region)
+			aws.Service = r.resolve(aws.Service)
+			auth.AWS = &aws
+		}
+		if auth.JWT != nil {
+			jwt := *auth.JWT
+			jwt.Secret = r.resolve(jwt.Secret)
+			jwt.PrivateKey = r.resolve(jwt.PrivateKey)
+			jwt.KeyID = r.resolve(jwt.KeyID)
+			jwt.Claims = r.resolve(jwt.Claims)
+			auth.JWT = &jwt
+		}
+		spec.Auth = &auth
+	}
+
+	return spec, r.err
+}
+
+// resolveWebSocketSpec returns a copy of spec with placeholders expanded in its URL, headers and subprotocols
+func (r *variableResolver) resolveWebSocketSpec(spec models.WebSocketSpec) (models.WebSocketSpec, error) {
+	spec.URL = r.resolve(spec.URL)
+	spec.Headers = r.resolveKeyValues(spec.Headers)
+
+	subprotocols := make([]string, len(spec.Subprotocols))
+	for i, subprotocol := range spec.Subprotocols {
+		subprotocols[i] = r.resolve(subprotocol)
+	}
+	spec.Subprotocols = subprotocols
+
+	return spec, r.err
+}
+
+// resolveGRPCSpec returns a copy of spec with placeholders expanded in its target, method, messages and metadata
+func (r *variableResolver) resolveGRPCSpec(spec models.GRPCSpec) (models.GRPCSpec, error) {
+	spec.Target = r.resolve(spec.Target)
+	spec.Method = r.resolve(spec.Method)
+	spec.Metadata = r.resolveKeyValues(spec.Metadata)
+
+	messages := make([]string, len(spec.Messages))
+	for i, message := range spec.Messages {
+		messages[i] = r.resolve(message)
+	}
+	spec.Messages = messages
+
+	return spec, r.err
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"apiclient/backend/models"
+	"reflect"
+	"testing"
+)
+
+func TestVariableResolver(t *testing.T) {
+	vars := map[string]string{
+		"host":    "api.example.com",
+		"version": "v2",
+		"base":    "https://{{host}}/{{ version }}",
+		"users":   "{{base}}/users",
+		"token":   "Bearer {{secret}}",
+		"empty":   "",
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		want       string
+		unresolved []string
+	}{
+		{"plain text", "no placeholders", "no placeholders", []string{}},
+		{"variable", "{{host}}", "api.example.com", []string{}},
+		{"whitespace", "{{ host }}|{{\thost\t}}|{{host }}", "api.example.com|api.example.com|api.example.com", []string{}},
+		{"nested", "{{users}}/1", "https://api.example.com/v2/users/1", []string{}},
+		{"empty value", "[{{empty}}]", "[]", []string{}},
+		{"unresolved", "{{host}}/{{ missing }}", "api.example.com/{{ missing }}", []string{"missing"}},
+		{"unresolved nested", "{{token}}", "Bearer {{secret}}", []string{"secret"}},
+		{"unresolved sorted", "{{b}}{{a}}{{b}}", "{{b}}{{a}}{{b}}", []string{"a", "b"}},
+		{"whitespace inside name", "{{ho st}}", "{{ho st}}", []string{}},
+		{"single braces", "{host}", "{host}", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newVariableResolver(vars)
+			if got := r.resolve(tt.input); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got := r.unresolvedNames(); !reflect.DeepEqual(got, tt.unresolved) {
+				t.Errorf("got unresolved names %q, want %q", got, tt.unresolved)
+			}
+			if r.err != nil {
+				t.Errorf("got error %v", r.err)
+			}
+		})
+	}
+}
+
+func TestVariableResolverCycles(t *testing.T) {
+	vars := map[string]string{
+		"self":  "{{self}}",
+		"a":     "{{b}}",
+		"b":     "x{{ c }}",
+		"c":     "{{a}}",
+		"outer": "{{a}}",
+		"fine":  "ok",
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"self reference", "{{self}}", "variable cycle detected: self -> self"},
+		{"indirect", "{{a}}", "variable cycle detected: a -> b -> c -> a"},
+		{"reached through another variable", "{{fine}} {{outer}}", "variable cycle detected: outer -> a -> b -> c -> a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newVariableResolver(vars)
+			r.resolve(tt.input)
+			if r.err == nil || r.err.Error() != tt.err {
+				t.Errorf("got error %v, want %q", r.err, tt.err)
+			}
+		})
+	}
+
+	// The error of a cycle anywhere in the request fails its resolution
+	r := newVariableResolver(vars)
+	_, err := r.resolveSpec(models.RequestSpec{
+		URL:     "https://{{fine}}",
+		Headers: []models.KeyValue{{Key: "X-Loop", Value: "{{a}}", Enabled: true}},
+	})
+	if err == nil {
+		t.Error("resolving a request with a variable cycle succeeded")
+	}
+
+	// Disabled headers aren't resolved, so their cycles don't matter
+	r = newVariableResolver(vars)
+	spec, err := r.resolveSpec(models.RequestSpec{
+		URL:     "https://{{fine}}",
+		Headers: []models.KeyValue{{Key: "X-Loop", Value: "{{a}}", Enabled: false}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.URL != "https://ok" || spec.Headers[0].Value != "{{a}}" {
+		t.Errorf("got URL %q and header %q, want https://ok and {{a}}", spec.URL, spec.Headers[0].Value)
+	}
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// webSocketWriteTimeout bounds writing a single frame
+const webSocketWriteTimeout = 10 * time.Second
+
+// webSocketCloseTimeout bounds the wait for the server to answer a close frame before dropping the connection
+const webSocketCloseTimeout = 5 * time.Second
+
+// Directions of WebSocket messages
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+// webSocketRegistry tracks the open WebSocket sessions by ID
+type webSocketRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*webSocketSession
+}
+
+// add registers session, failing when its ID is already in use
+func (r *webSocketRegistry) add(session *webSocketSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sessions == nil {
+		r.sessions = map[string]*webSocketSession{}
+	}
+	if _, ok := r.sessions[session.id]; ok {
+		return fmt.Errorf("a WebSocket session with ID %q is already open", session.id)
+	}
+	r.sessions[session.id] = session
+	return nil
+}
+
+func (r *webSocketRegistry) get(id string) (*webSocketSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("no open WebSocket session with ID %q", id)
+	}
+	return session, nil
+}
+
+func (r *webSocketRegistry) remove(id string) {
+	r.mu.Lock()
+	delete(r.sessions, id)
+	r.mu.Unlock()
+}
+
+// webSocketSession is an open WebSocket connection along with the log of its messages
+type webSocketSession struct {
+	id        string
+	conn      *websocket.Conn
+	handshake *http.Response
+	requestID *int
+	started   time.Time
+	emit      func(models.WebSocketMessage)
+
+	writeMu sync.Mutex // messages can only be written one at a time
+
+	mu      sync.Mutex
+	log     []models.WebSocketMessage
+	closing bool // a close frame was sent
+}
+
+// record adds a message to the log of the session and publishes it
+func (session *webSocketSession) record(direction, messageType, data string, closeCode int) {
+	message := models.WebSocketMessage{
+		SessionID: session.id,
+		Direction: direction,
+		Type:      messageType,
+		Data:      data,
+		CloseCode: closeCode,
+		Time:      time.Now(),
+	}
+
+	session.mu.Lock()
+	session.log = append(session.log, message)
+	session.mu.Unlock()
+
+	session.emit(message)
+}
+
+// ConnectWebSocket opens a WebSocket session. Messages are then exchanged through SendWebSocketMessage
+// and the EventWebSocketMessage events, until CloseWebSocket or the server ends the session.
+func (s *APIClientService) ConnectWebSocket(ctx context.Context, spec models.WebSocketSpec) (*models.WebSocketSession, error) {
+	if spec.SessionID == "" {
+		spec.SessionID = uuid.NewString()
+	}
+
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := environmentVariables(activeEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newVariableResolver(vars)
+	spec, err = resolver.resolveWebSocketSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := webSocketURL(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	subprotocols := spec.Subprotocols
+	for _, pair := range spec.Headers {
+		if !pair.Enabled || pair.Key == "" {
+			continue
+		}
+		// The dialer sends the subprotocols itself and rejects the header
+		if http.CanonicalHeaderKey(pair.Key) == "Sec-Websocket-Protocol" {
+			for _, subprotocol := range strings.Split(pair.Value, ",") {
+				if subprotocol = strings.TrimSpace(subprotocol); subprotocol != "" {
+					subprotocols = append(subprotocols, subprotocol)
+				}
+			}
+			continue
+		}
+		header.Add(pair.Key, pair.Value)
+	}
+
+	conn, resp, err := dialWebSocket(ctx, activeEnv, target, header, subprotocols, spec.TimeoutMs, spec.SkipTLSVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &webSocketSession{
+		id:        spec.SessionID,
+		conn:      conn,
+		handshake: resp,
+		requestID: spec.RequestID,
+		started:   time.Now(),
+		emit: func(message models.WebSocketMessage) {
+			s.emit(EventWebSocketMessage, message)
+		},
+	}
+	err = s.websockets.add(session)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetPingHandler(func(data string) error {
+		session.record(directionReceived, models.WebSocketPing, data, 0)
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(webSocketWriteTimeout))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			// Closing, the server doesn't expect a pong anymore
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		session.record(directionSent, models.WebSocketPong, data, 0)
+		return nil
+	})
+	conn.SetPongHandler(func(data string) error {
+		session.record(directionReceived, models.WebSocketPong, data, 0)
+		return nil
+	})
+	conn.SetCloseHandler(func(code int, reason string) error {
+		session.record(directionReceived, models.WebSocketClose, reason, code)
+
+		session.mu.Lock()
+		closing := session.closing
+		session.closing = true
+		session.mu.Unlock()
+		if closing {
+			return nil
+		}
+
+		// Echo the close code, as the protocol requires
+		err := conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(webSocketWriteTimeout))
+		if err == nil {
+			session.record(directionSent, models.WebSocketClose, "", code)
+		}
+		return nil
+	})
+
+	go s.readWebSocket(session)
+
+	return &models.WebSocketSession{
+		SessionID:           session.id,
+		URL:                 target.String(),
+		Subprotocol:         conn.Subprotocol(),
+		Status:              resp.StatusCode,
+		Headers:             resp.Header,
+		UnresolvedVariables: resolver.unresolvedNames(),
+	}, nil
+}
+
+// SendWebSocketMessage sends a text or binary message; binary data is given base64 encoded
+func (s *APIClientService) SendWebSocketMessage(sessionID, messageType, data string) error {
+	session, err := s.websockets.get(sessionID)
+	if err != nil {
+		return err
+	}
+
+	var frameType int
+	var payload []byte
+	switch messageType {
+	case models.WebSocketText:
+		frameType = websocket.TextMessage
+		payload = []byte(data)
+	case models.WebSocketBinary:
+		frameType = websocket.BinaryMessage
+		payload, err = base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return fmt.Errorf("binary messages must be base64 encoded: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported WebSocket message type %q", messageType)
+	}
+
+	session.writeMu.Lock()
+	defer session.writeMu.Unlock()
+
+	session.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	err = session.conn.WriteMessage(frameType, payload)
+	if err != nil {
+		return err
+	}
+
+	session.record(directionSent, messageType, data, 0)
+	return nil
+}
+
+// PingWebSocket sends a ping frame, which the server should answer with a pong carrying the same data
+func (s *APIClientService) PingWebSocket(sessionID, data string) error {
+	session, err := s.websockets.get(sessionID)
+	if err != nil {
+		return err
+	}
+
+	err = session.conn.WriteControl(websocket.PingMessage, []byte(data), time.Now().Add(webSocketWriteTimeout))
+	if err != nil {
+		return err
+	}
+
+	session.record(directionSent, models.WebSocketPing, data, 0)
+	return nil
+}
+
+// CloseWebSocket starts the closing handshake of a session with code, 1000 when 0. The session ends
+// once the server answers, or after a few seconds when it doesn't.
+func (s *APIClientService) CloseWebSocket(sessionID string, code int, reason string) error {
+	session, err := s.websockets.get(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if code == 0 {
+		code = websocket.CloseNormalClosure
+	}
+
+	session.mu.Lock()
+	session.closing = true
+	session.mu.Unlock()
+
+	err = session.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(webSocketWriteTimeout))
+	if err != nil {
+		session.conn.Close()
+		return err
+	}
+	session.record(directionSent, models.WebSocketClose, reason, code)
+
+	time.AfterFunc(webSocketCloseTimeout, func() {
+		session.conn.Close()
+	})
+	return nil
+}
+
+// readWebSocket receives the messages of session until it ends, then saves its message log
+func (s *APIClientService) readWebSocket(session *webSocketSession) {
+	closed := models.WebSocketClosed{SessionID: session.id}
+	for {
+		frameType, payload, err := session.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				closed.Code = closeErr.Code
+				closed.Reason = closeErr.Text
+			} else {
+				closed.Code = websocket.CloseAbnormalClosure
+				closed.Error = err.Error()
+				if errors.Is(err, net.ErrClosed) {
+					closed.Error = "the server didn't answer the close frame"
+				}
+			}
+			break
+		}
+
+		if frameType == websocket.BinaryMessage {
+			session.record(directionReceived, models.WebSocketBinary, base64.StdEncoding.EncodeToString(payload), 0)
+		} else {
+			session.record(directionReceived, models.WebSocketText, string(payload), 0)
+		}
+	}
+
+	s.websockets.remove(session.id)
+	session.conn.Close()
+
+	err := saveWebSocketHistory(session)
+	if err != nil && closed.Error == "" {
+		closed.Error = fmt.Sprintf("the message log couldn't be saved: %v", err)
+	}
+	s.emit(EventWebSocketClosed, closed)
+}
+
+// dialWebSocket opens a WebSocket connection to target, with the certificates, proxy and cookies of activeEnv,
+// which may be nil. timeoutMs bounds the handshake.
+func dialWebSocket(ctx context.Context, activeEnv *models.Environment, target *url.URL, header http.Header, subprotocols []string, timeoutMs int, skipTLSVerify bool) (*websocket.Conn, *http.Response, error) {
+	certificates, err := database.GetCertificates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var environmentID *int
+	if activeEnv != nil {
+		environmentID = &activeEnv.ID
+	}
+	proxy, err := database.GetEffectiveProxy(environmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	choose, err := proxyFunc(proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defaultPort := "443"
+	if target.Scheme == "ws" {
+		defaultPort = "80"
+	}
+	tlsConfig, err := tlsConfigFor(target.Host, defaultPort, certificates, skipTLSVerify)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            choose,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: requestTimeout(timeoutMs),
+		Subprotocols:     subprotocols,
+		Jar:              &cookieJar{environmentID: environmentID},
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, target.String(), header)
+	if err != nil {
+		if resp != nil {
+			return nil, nil, fmt.Errorf("WebSocket handshake failed with %s: %w", resp.Status, err)
+		}
+		return nil, nil, err
+	}
+	return conn, resp, nil
+}
+
+// webSocketURL parses rawURL, accepting http and https URLs for ws and wss ones
+func webSocketURL(rawURL string) (*url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch target.Scheme {
+	case "ws", "wss":
+	case "http":
+		target.Scheme = "ws"
+	case "https":
+		target.Scheme = "wss"
+	default:
+		return nil, fmt.Errorf("unsupported WebSocket URL scheme %q", target.Scheme)
+	}
+	return target, nil
+}
+
+// saveWebSocketHistory saves the message log of session as a history entry of its saved request, if any
+func saveWebSocketHistory(session *webSocketSession) error {
+	if session.requestID == nil {
+		return nil
+	}
+
+	headers, err := json.Marshal(session.handshake.Header)
+	if err != nil {
+		return err
+	}
+
+	session.mu.Lock()
+	transcript, err := json.Marshal(session.log)
+	session.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	history := &models.RequestHistory{
+		RequestID:       *session.requestID,
+		ResponseStatus:  session.handshake.StatusCode,
+		ResponseTime:    int(time.Since(session.started).Milliseconds()),
+		ResponseHeaders: string(headers),
+		Transcript:      string(transcript),
+	}
+	return database.CreateRequestHistory(history)
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// startWebSocketServer serves an echo WebSocket endpoint. It pings the client when sent "ping me"
+// and closes the session with 4001 when sent "bye".
+func startWebSocketServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{Subprotocols: []string{"chat"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			frameType, payload, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			switch string(payload) {
+			case "ping me":
+				err = conn.WriteControl(websocket.PingMessage, []byte("p"), time.Now().Add(time.Second))
+			case "bye":
+				err = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4001, "done"))
+			default:
+				err = conn.WriteMessage(frameType, payload)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// webSocketEvents collects the events of WebSocket sessions
+type webSocketEvents struct {
+	messages chan models.WebSocketMessage
+	closed   chan models.WebSocketClosed
+}
+
+func newWebSocketEvents() (*webSocketEvents, EventEmitter) {
+	events := &webSocketEvents{
+		messages: make(chan models.WebSocketMessage, 100),
+		closed:   make(chan models.WebSocketClosed, 1),
+	}
+	return events, func(name string, data ...any) {
+		switch event := data[0].(type) {
+		case models.WebSocketMessage:
+			events.messages <- event
+		case models.WebSocketClosed:
+			events.closed <- event
+		}
+	}
+}
+
+// next returns the next message, failing the test when none comes
+func (e *webSocketEvents) next(t *testing.T) models.WebSocketMessage {
+	t.Helper()
+	select {
+	case message := <-e.messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a WebSocket message")
+		return models.WebSocketMessage{}
+	}
+}
+
+func (e *webSocketEvents) waitClosed(t *testing.T) models.WebSocketClosed {
+	t.Helper()
+	select {
+	case closed := <-e.closed:
+		return closed
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the WebSocket session to close")
+		return models.WebSocketClosed{}
+	}
+}
+
+// expect checks that the next messages are the wanted ones, ignoring their time
+func (e *webSocketEvents) expect(t *testing.T, want ...models.WebSocketMessage) {
+	t.Helper()
+	for _, message := range want {
+		got := e.next(t)
+		got.Time = time.Time{}
+		if got != message {
+			t.Fatalf("got message %+v, want %+v", got, message)
+		}
+	}
+}
+
+func TestWebSocketSession(t *testing.T) {
+	useTestDatabase(t)
+	server := startWebSocketServer(t)
+
+	events, emitter := newWebSocketEvents()
+	s := NewAPIClientService(emitter)
+
+	requestID := 4
+	session, err := s.ConnectWebSocket(context.Background(), models.WebSocketSpec{
+		SessionID:    "ws",
+		URL:          server.URL, // http URLs are accepted for ws ones
+		Subprotocols: []string{"chat"},
+		RequestID:    &requestID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Status != http.StatusSwitchingProtocols || session.Subprotocol != "chat" || session.URL[:5] != "ws://" {
+		t.Errorf("got session %+v, want a ws:// session with the chat subprotocol", session)
+	}
+
+	message := func(direction, messageType, data string, closeCode int) models.WebSocketMessage {
+		return models.WebSocketMessage{SessionID: "ws", Direction: direction, Type: messageType, Data: data, CloseCode: closeCode}
+	}
+
+	if err := s.SendWebSocketMessage("ws", models.WebSocketText, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t,
+		message(directionSent, models.WebSocketText, "hello", 0),
+		message(directionReceived, models.WebSocketText, "hello", 0),
+	)
+
+	if err := s.SendWebSocketMessage("ws", models.WebSocketBinary, "AQI="); err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t,
+		message(directionSent, models.WebSocketBinary, "AQI=", 0),
+		message(directionReceived, models.WebSocketBinary, "AQI=", 0),
+	)
+	if err := s.SendWebSocketMessage("ws", models.WebSocketBinary, "not base64"); err == nil {
+		t.Error("sending a binary message that isn't base64 succeeded")
+	}
+
+	// The server answers pings with pongs, and pings are answered with pongs
+	if err := s.PingWebSocket("ws", "are you there"); err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t,
+		message(directionSent, models.WebSocketPing, "are you there", 0),
+		message(directionReceived, models.WebSocketPong, "are you there", 0),
+	)
+	if err := s.SendWebSocketMessage("ws", models.WebSocketText, "ping me"); err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t,
+		message(directionSent, models.WebSocketText, "ping me", 0),
+		message(directionReceived, models.WebSocketPing, "p", 0),
+		message(directionSent, models.WebSocketPong, "p", 0),
+	)
+
+	// The close code of the server is echoed and ends the session
+	if err := s.SendWebSocketMessage("ws", models.WebSocketText, "bye"); err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t,
+		message(directionSent, models.WebSocketText, "bye", 0),
+		message(directionReceived, models.WebSocketClose, "done", 4001),
+		message(directionSent, models.WebSocketClose, "", 4001),
+	)
+	closed := events.waitClosed(t)
+	if closed != (models.WebSocketClosed{SessionID: "ws", Code: 4001, Reason: "done"}) {
+		t.Errorf("got closed event %+v, want code 4001 with reason done", closed)
+	}
+	if err := s.SendWebSocketMessage("ws", models.WebSocketText, "hello"); err == nil {
+		t.Error("sending a message to a closed session succeeded")
+	}
+
+	// The message log is saved to the history of the request
+	history, err := database.GetRequestHistoryByRequest(requestID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].ResponseStatus != http.StatusSwitchingProtocols {
+		t.Fatalf("got history %+v, want one entry with status 101", history)
+	}
+	var transcript []models.WebSocketMessage
+	if err := json.Unmarshal([]byte(history[0].Transcript), &transcript); err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript) != 12 {
+		t.Errorf("got %d messages in the transcript, want 12", len(transcript))
+	}
+}
+
+func TestCloseWebSocket(t *testing.T) {
+	useTestDatabase(t)
+	server := startWebSocketServer(t)
+
+	events, emitter := newWebSocketEvents()
+	s := NewAPIClientService(emitter)
+
+	_, err := s.ConnectWebSocket(context.Background(), models.WebSocketSpec{SessionID: "ws", URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.ConnectWebSocket(context.Background(), models.WebSocketSpec{SessionID: "ws", URL: server.URL})
+	if err == nil {
+		t.Error("connecting a session with the ID of an open one succeeded")
+	}
+
+	err = s.CloseWebSocket("ws", 4000, "leaving")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events.expect(t, models.WebSocketMessage{SessionID: "ws", Direction: directionSent, Type: models.WebSocketClose, Data: "leaving", CloseCode: 4000})
+
+	// The server echoes the close code, which ends the session
+	closed := events.waitClosed(t)
+	if closed.Code != 4000 || closed.Error != "" {
+		t.Errorf("got closed event %+v, want code 4000 without error", closed)
+	}
+}
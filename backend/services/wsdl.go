@@ -0,0 +1,917 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Namespaces of WSDL 1.1 documents, their SOAP bindings and the schemas of their types
+const (
+	wsdlNamespace       = "http://schemas.xmlsoap.org/wsdl/"
+	wsdl2Namespace      = "http://www.w3.org/ns/wsdl"
+	wsdlSOAP11Namespace = "http://schemas.xmlsoap.org/wsdl/soap/"
+	wsdlSOAP12Namespace = "http://schemas.xmlsoap.org/wsdl/soap12/"
+	xsdNamespace        = "http://www.w3.org/2001/XMLSchema"
+)
+
+// maxEnvelopeDepth bounds the nesting of generated envelopes, for schemas recursing through
+// several types
+const maxEnvelopeDepth = 16
+
+// ImportWSDL creates a request per operation of the SOAP services described by a WSDL, with a skeleton
+// envelope, the SOAPAction and the endpoint of its port. The WSDL is read from location, a URL or a file
+// path, unless document is given; imports of WSDL and schema documents are resolved relative to location.
+// Documents are fetched with the proxy, certificates and settings requests would be sent with.
+// Requests are added to the collection, or to a new one named after the service when collectionID is nil,
+// in a folder per port when the services have several.
+func (s *APIClientService) ImportWSDL(ctx context.Context, location, document string, settings models.RequestSettings, collectionID *int) ([]*models.Request, error) {
+	client, closeClient, err := wsdlClient(settings)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+
+	definitions := newWSDLDefinitions(client)
+	data := []byte(document)
+	if document == "" {
+		data, err = loadWSDLDocument(ctx, client, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = definitions.load(ctx, location, data)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := definitions.operations()
+	if err != nil {
+		return nil, err
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("the WSDL has no SOAP operations")
+	}
+
+	if collectionID == nil {
+		collection := &models.Collection{Name: definitions.serviceName(), Description: "Imported from " + location}
+		if location == "" {
+			collection.Description = "Imported from a WSDL"
+		}
+		err = database.CreateCollection(collection)
+		if err != nil {
+			return nil, err
+		}
+		collectionID = &collection.ID
+	}
+
+	folders := map[string]*int{}
+	for _, operation := range operations {
+		folders[operation.port] = nil
+	}
+	if len(folders) > 1 {
+		for port := range folders {
+			folder := &models.Folder{Name: port, CollectionID: *collectionID}
+			err = database.CreateFolder(folder)
+			if err != nil {
+				return nil, err
+			}
+			folders[port] = &folder.ID
+		}
+	}
+
+	requests := make([]*models.Request, 0, len(operations))
+	for _, operation := range operations {
+		headers, err := json.Marshal(operation.headers())
+		if err != nil {
+			return nil, err
+		}
+
+		request := &models.Request{
+			Name:         operation.name,
+			Type:         models.RequestTypeHTTP,
+			Method:       http.MethodPost,
+			URL:          operation.endpoint,
+			Headers:      string(headers),
+			Body:         operation.envelope,
+			BodyType:     "xml",
+			CollectionID: collectionID,
+			FolderID:     folders[operation.port],
+		}
+		err = database.CreateRequest(request)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// soapOperation is an operation of a SOAP port, ready to be saved as a request
+type soapOperation struct {
+	name        string
+	port        string
+	endpoint    string
+	soapVersion string // 1.1 or 1.2
+	action      string
+	envelope    string
+}
+
+// headers returns the headers SOAP requires to call the operation
+func (o soapOperation) headers() map[string]string {
+	if o.soapVersion == "1.2" {
+		contentType := "application/soap+xml; charset=utf-8"
+		if o.action != "" {
+			contentType += fmt.Sprintf("; action=%q", o.action)
+		}
+		return map[string]string{"Content-Type": contentType}
+	}
+
+	// SOAP 1.1 requires the header even without an action
+	return map[string]string{
+		"Content-Type": "text/xml; charset=utf-8",
+		"SOAPAction":   fmt.Sprintf("%q", o.action),
+	}
+}
+
+// wsdlClient returns the client WSDL documents are fetched with, going through the proxy of the active
+// environment and presenting the client certificates of their host, and a function releasing its connections
+func wsdlClient(settings models.RequestSettings) (*http.Client, func(), error) {
+	activeEnv, err := database.GetActiveEnvironment()
+	if err != nil {
+		return nil, nil, err
+	}
+	var environmentID *int
+	if activeEnv != nil {
+		environmentID = &activeEnv.ID
+	}
+
+	certificates, err := database.GetCertificates()
+	if err != nil {
+		return nil, nil, err
+	}
+	proxy, err := database.GetEffectiveProxy(environmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transport, err := newExecutionTransport(settings, certificates, proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: requestTimeout(settings.TimeoutMs)}, transport.close, nil
+}
+
+// loadWSDLDocument reads the WSDL or schema document at location, a URL fetched with client or a file path
+func loadWSDLDocument(ctx context.Context, client *http.Client, location string) ([]byte, error) {
+	if location == "" {
+		return nil, errors.New("no WSDL location given")
+	}
+
+	if !isRemoteWSDLLocation(location) {
+		return os.ReadFile(location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s failed with %s", location, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// resolveWSDLLocation resolves the location of an imported document against the document importing it.
+// Documents fetched over HTTP can only import other URLs, so they can't make the app read local files.
+func resolveWSDLLocation(base, ref string) (string, error) {
+	refURL, err := url.Parse(ref)
+
+	if isRemoteWSDLLocation(base) {
+		if err != nil {
+			return "", fmt.Errorf("invalid import location %s: %w", ref, err)
+		}
+		baseURL, _ := url.Parse(base)
+		resolved := baseURL.ResolveReference(refURL).String()
+		if !isRemoteWSDLLocation(resolved) {
+			return "", fmt.Errorf("%s imports %s, which isn't an http or https URL", base, ref)
+		}
+		return resolved, nil
+	}
+
+	if err == nil && refURL.IsAbs() || filepath.IsAbs(ref) {
+		return ref, nil
+	}
+	if base == "" {
+		return "", fmt.Errorf("can't resolve %s without the location of the WSDL", ref)
+	}
+	return filepath.Join(filepath.Dir(base), filepath.FromSlash(ref)), nil
+}
+
+// isRemoteWSDLLocation tells whether location is an http or https URL rather than a file path
+func isRemoteWSDLLocation(location string) bool {
+	target, err := url.Parse(location)
+	return err == nil && (target.Scheme == "http" || target.Scheme == "https")
+}
+
+// xmlNode is an element of a parsed XML document. It keeps the namespace prefixes in scope, so QName
+// attribute values like tns:GetQuote can be resolved.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	parent   *xmlNode
+	children []*xmlNode
+	prefixes map[string]string
+}
+
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	var root, current *xmlNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: token.Name, attrs: token.Attr, parent: current, prefixes: map[string]string{}}
+			if current != nil {
+				for prefix, namespace := range current.prefixes {
+					node.prefixes[prefix] = namespace
+				}
+				current.children = append(current.children, node)
+			} else {
+				root = node
+			}
+			for _, attr := range token.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					node.prefixes[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					node.prefixes[""] = attr.Value
+				}
+			}
+			current = node
+		case xml.EndElement:
+			current = current.parent
+		}
+	}
+	if root == nil {
+		return nil, errors.New("the document is empty")
+	}
+	return root, nil
+}
+
+// attr returns the value of the unqualified attribute name
+func (n *xmlNode) attr(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// qname resolves the QName value of the attribute name, e.g. tns:GetQuote
+func (n *xmlNode) qname(name string) xml.Name {
+	value := n.attr(name)
+	prefix, local, found := strings.Cut(value, ":")
+	if !found {
+		return xml.Name{Space: n.prefixes[""], Local: value}
+	}
+	return xml.Name{Space: n.prefixes[prefix], Local: local}
+}
+
+// childrenNamed returns the child elements in namespace with the given local name, or all of them
+// in namespace when local is empty
+func (n *xmlNode) childrenNamed(namespace, local string) []*xmlNode {
+	var children []*xmlNode
+	for _, child := range n.children {
+		if child.name.Space == namespace && (local == "" || child.name.Local == local) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (n *xmlNode) child(namespace, local string) *xmlNode {
+	for _, child := range n.children {
+		if child.name.Space == namespace && child.name.Local == local {
+			return child
+		}
+	}
+	return nil
+}
+
+// wsdlDefinitions gathers the declarations of a WSDL document and of the documents it imports
+type wsdlDefinitions struct {
+	name      string
+	messages  map[xml.Name]*xmlNode
+	portTypes map[xml.Name]*xmlNode
+	bindings  map[xml.Name]*xmlNode
+	services  []*xmlNode
+	schemas   *xsdSchemas
+
+	client *http.Client    // fetches imported documents
+	loaded map[string]bool // locations of the documents already loaded
+}
+
+func newWSDLDefinitions(client *http.Client) *wsdlDefinitions {
+	return &wsdlDefinitions{
+		messages:  map[xml.Name]*xmlNode{},
+		portTypes: map[xml.Name]*xmlNode{},
+		bindings:  map[xml.Name]*xmlNode{},
+		schemas:   newXSDSchemas(client),
+		client:    client,
+		loaded:    map[string]bool{},
+	}
+}
+
+// load adds the declarations of the WSDL document at location, whose content is data
+func (d *wsdlDefinitions) load(ctx context.Context, location string, data []byte) error {
+	d.loaded[location] = true
+
+	root, err := parseXMLTree(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", wsdlDocumentName(location), err)
+	}
+	switch root.name {
+	case xml.Name{Space: wsdlNamespace, Local: "definitions"}:
+	case xml.Name{Space: wsdl2Namespace, Local: "description"}:
+		return errors.New("WSDL 2.0 documents aren't supported, only WSDL 1.1")
+	default:
+		return fmt.Errorf("%s is not a WSDL document", wsdlDocumentName(location))
+	}
+
+	targetNamespace := root.attr("targetNamespace")
+	if d.name == "" {
+		d.name = root.attr("name")
+	}
+
+	for _, child := range root.children {
+		if child.name.Space != wsdlNamespace {
+			continue
+		}
+		name := xml.Name{Space: targetNamespace, Local: child.attr("name")}
+		switch child.name.Local {
+		case "import":
+			err = d.loadImport(ctx, location, child.attr("location"))
+		case "types":
+			for _, schema := range child.childrenNamed(xsdNamespace, "schema") {
+				err = d.schemas.add(ctx, location, schema, schema.attr("targetNamespace"))
+				if err != nil {
+					break
+				}
+			}
+		case "message":
+			d.messages[name] = child
+		case "portType":
+			d.portTypes[name] = child
+		case "binding":
+			d.bindings[name] = child
+		case "service":
+			d.services = append(d.services, child)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadImport loads a document imported by a WSDL, which is either another WSDL or a schema
+func (d *wsdlDefinitions) loadImport(ctx context.Context, base, ref string) error {
+	if ref == "" {
+		return nil
+	}
+	location, err := resolveWSDLLocation(base, ref)
+	if err != nil || d.loaded[location] {
+		return err
+	}
+
+	data, err := loadWSDLDocument(ctx, d.client, location)
+	if err != nil {
+		return fmt.Errorf("loading the import %s: %w", ref, err)
+	}
+	root, err := parseXMLTree(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", wsdlDocumentName(location), err)
+	}
+	if root.name == (xml.Name{Space: xsdNamespace, Local: "schema"}) {
+		d.loaded[location] = true
+		return d.schemas.add(ctx, location, root, root.attr("targetNamespace"))
+	}
+	return d.load(ctx, location, data)
+}
+
+func wsdlDocumentName(location string) string {
+	if location == "" {
+		return "the document"
+	}
+	return location
+}
+
+// serviceName names the collection the operations are imported into
+func (d *wsdlDefinitions) serviceName() string {
+	if len(d.services) > 0 && d.services[0].attr("name") != "" {
+		return d.services[0].attr("name")
+	}
+	if d.name != "" {
+		return d.name
+	}
+	return "SOAP service"
+}
+
+// operations lists the operations of every SOAP port of the services, sorted by port and name.
+// Ports of other bindings, like HTTP ones, are skipped.
+func (d *wsdlDefinitions) operations() ([]soapOperation, error) {
+	var operations []soapOperation
+	for _, service := range d.services {
+		for _, port := range service.childrenNamed(wsdlNamespace, "port") {
+			binding := d.bindings[port.qname("binding")]
+			if binding == nil {
+				return nil, fmt.Errorf("port %s: binding %s not found", port.attr("name"), port.attr("binding"))
+			}
+
+			version, bindingNamespace := "1.1", wsdlSOAP11Namespace
+			soapBinding := binding.child(wsdlSOAP11Namespace, "binding")
+			if soapBinding == nil {
+				version, bindingNamespace = "1.2", wsdlSOAP12Namespace
+				soapBinding = binding.child(wsdlSOAP12Namespace, "binding")
+			}
+			if soapBinding == nil {
+				continue
+			}
+
+			var endpoint string
+			if address := port.child(bindingNamespace, "address"); address != nil {
+				endpoint = address.attr("location")
+			}
+
+			portType := d.portTypes[binding.qname("type")]
+			if portType == nil {
+				return nil, fmt.Errorf("binding %s: port type %s not found", binding.attr("name"), binding.attr("type"))
+			}
+
+			for _, bindingOperation := range binding.childrenNamed(wsdlNamespace, "operation") {
+				operation := soapOperation{
+					name:        bindingOperation.attr("name"),
+					port:        port.attr("name"),
+					endpoint:    endpoint,
+					soapVersion: version,
+				}
+
+				style := soapBinding.attr("style")
+				if soapOperation := bindingOperation.child(bindingNamespace, "operation"); soapOperation != nil {
+					operation.action = soapOperation.attr("soapAction")
+					if soapOperation.attr("style") != "" {
+						style = soapOperation.attr("style")
+					}
+				}
+
+				var input *xmlNode
+				for _, candidate := range portType.childrenNamed(wsdlNamespace, "operation") {
+					if candidate.attr("name") == operation.name {
+						input = candidate.child(wsdlNamespace, "input")
+						break
+					}
+				}
+				if input == nil {
+					return nil, fmt.Errorf("operation %s not found in port type %s", operation.name, portType.attr("name"))
+				}
+
+				envelope, err := d.envelope(version, style, bindingNamespace, bindingOperation, input)
+				if err != nil {
+					return nil, fmt.Errorf("operation %s: %w", operation.name, err)
+				}
+				operation.envelope = envelope
+				operations = append(operations, operation)
+			}
+		}
+	}
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		if operations[i].port != operations[j].port {
+			return operations[i].port < operations[j].port
+		}
+		return operations[i].name < operations[j].name
+	})
+	return operations, nil
+}
+
+// envelope generates the skeleton envelope of the input of an operation, document/literal or RPC style
+func (d *wsdlDefinitions) envelope(version, style, bindingNamespace string, bindingOperation, input *xmlNode) (string, error) {
+	message := d.messages[input.qname("message")]
+	if message == nil {
+		return "", fmt.Errorf("message %s not found", input.attr("message"))
+	}
+
+	// The binding may send only some parts in the body, and others as headers
+	var soapBody *xmlNode
+	var soapHeaders []*xmlNode
+	if bindingInput := bindingOperation.child(wsdlNamespace, "input"); bindingInput != nil {
+		soapBody = bindingInput.child(bindingNamespace, "body")
+		soapHeaders = bindingInput.childrenNamed(bindingNamespace, "header")
+	}
+	parts := message.childrenNamed(wsdlNamespace, "part")
+	if soapBody != nil && soapBody.attr("parts") != "" {
+		wanted := strings.Fields(soapBody.attr("parts"))
+		var bodyParts []*xmlNode
+		for _, part := range parts {
+			for _, name := range wanted {
+				if part.attr("name") == name {
+					bodyParts = append(bodyParts, part)
+				}
+			}
+		}
+		parts = bodyParts
+	}
+
+	writer := newEnvelopeWriter(d.schemas)
+
+	var header strings.Builder
+	for _, soapHeader := range soapHeaders {
+		headerMessage := d.messages[soapHeader.qname("message")]
+		if headerMessage == nil {
+			continue
+		}
+		for _, part := range headerMessage.childrenNamed(wsdlNamespace, "part") {
+			if part.attr("name") == soapHeader.attr("part") {
+				header.WriteString(writer.part(part, 2))
+			}
+		}
+	}
+
+	var body strings.Builder
+	if style == "rpc" {
+		namespace := ""
+		if soapBody != nil {
+			namespace = soapBody.attr("namespace")
+		}
+		tag := writer.tag(xml.Name{Space: namespace, Local: bindingOperation.attr("name")})
+		var content strings.Builder
+		for _, part := range parts {
+			content.WriteString(writer.part(part, 3))
+		}
+		body.WriteString(writer.wrap(tag, "", content.String(), 2))
+	} else {
+		for _, part := range parts {
+			body.WriteString(writer.part(part, 2))
+		}
+	}
+
+	return writer.envelope(version, header.String(), body.String()), nil
+}
+
+// xsdSchemas indexes the global declarations of the schemas of a WSDL by their qualified name
+type xsdSchemas struct {
+	elements   map[xml.Name]*xmlNode
+	types      map[xml.Name]*xmlNode // complex and simple types
+	groups     map[xml.Name]*xmlNode
+	namespaces map[*xmlNode]string // target namespace of every schema, which includes may not declare
+
+	client *http.Client // fetches imported and included schemas
+	loaded map[string]bool
+}
+
+func newXSDSchemas(client *http.Client) *xsdSchemas {
+	return &xsdSchemas{
+		client:     client,
+		elements:   map[xml.Name]*xmlNode{},
+		types:      map[xml.Name]*xmlNode{},
+		groups:     map[xml.Name]*xmlNode{},
+		namespaces: map[*xmlNode]string{},
+		loaded:     map[string]bool{},
+	}
+}
+
+// add indexes schema, found in the document at location, then the schemas it imports or includes
+func (s *xsdSchemas) add(ctx context.Context, location string, schema *xmlNode, targetNamespace string) error {
+	s.namespaces[schema] = targetNamespace
+
+	for _, child := range schema.childrenNamed(xsdNamespace, "") {
+		name := xml.Name{Space: targetNamespace, Local: child.attr("name")}
+		switch child.name.Local {
+		case "element":
+			s.elements[name] = child
+		case "complexType", "simpleType":
+			s.types[name] = child
+		case "group":
+			s.groups[name] = child
+		case "import", "include":
+			ref := child.attr("schemaLocation")
+			if ref == "" {
+				continue
+			}
+			// Imports of schemas embedded in the WSDL, or of well-known ones, often can't be resolved
+			resolved, err := resolveWSDLLocation(location, ref)
+			if err != nil || s.loaded[resolved] {
+				continue
+			}
+			s.loaded[resolved] = true
+
+			data, err := loadWSDLDocument(ctx, s.client, resolved)
+			if err != nil {
+				return fmt.Errorf("loading the schema %s: %w", ref, err)
+			}
+			root, err := parseXMLTree(data)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", resolved, err)
+			}
+
+			// Included schemas without a target namespace take the one of the including schema
+			namespace := root.attr("targetNamespace")
+			if child.name.Local == "include" && namespace == "" {
+				namespace = targetNamespace
+			}
+			err = s.add(ctx, resolved, root, namespace)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaOf returns the schema declaring node
+func (s *xsdSchemas) schemaOf(node *xmlNode) *xmlNode {
+	for ; node != nil; node = node.parent {
+		if node.name == (xml.Name{Space: xsdNamespace, Local: "schema"}) {
+			return node
+		}
+	}
+	return nil
+}
+
+// elementName returns the qualified name of the element declared by decl. Global elements are in the
+// target namespace, local ones only when qualified.
+func (s *xsdSchemas) elementName(decl *xmlNode) xml.Name {
+	schema := s.schemaOf(decl)
+	name := xml.Name{Local: decl.attr("name")}
+	if schema == nil {
+		return name
+	}
+
+	form := decl.attr("form")
+	if form == "" {
+		form = schema.attr("elementFormDefault")
+	}
+	if decl.parent == schema || form == "qualified" {
+		name.Space = s.namespaces[schema]
+	}
+	return name
+}
+
+// envelopeWriter writes skeleton SOAP envelopes, declaring a prefix for every namespace used
+type envelopeWriter struct {
+	schemas    *xsdSchemas
+	prefixes   map[string]string
+	namespaces []string // in the order their prefixes were declared
+
+	expanding map[*xmlNode]bool // types and elements being expanded, to stop recursive ones
+}
+
+func newEnvelopeWriter(schemas *xsdSchemas) *envelopeWriter {
+	return &envelopeWriter{schemas: schemas, prefixes: map[string]string{}, expanding: map[*xmlNode]bool{}}
+}
+
+// tag returns the tag of an element named name, prefixed when it's in a namespace
+func (w *envelopeWriter) tag(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	prefix, ok := w.prefixes[name.Space]
+	if !ok {
+		prefix = fmt.Sprintf("ns%d", len(w.namespaces)+1)
+		w.prefixes[name.Space] = prefix
+		w.namespaces = append(w.namespaces, name.Space)
+	}
+	return prefix + ":" + name.Local
+}
+
+// wrap writes an element with its attributes, whose content is either child elements or a value
+func (w *envelopeWriter) wrap(tag, attrs, content string, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	// Child elements are written a line each
+	if strings.HasSuffix(content, "\n") {
+		return fmt.Sprintf("%s<%s%s>\n%s%s</%s>\n", indent, tag, attrs, content, indent, tag)
+	}
+	return fmt.Sprintf("%s<%s%s>%s</%s>\n", indent, tag, attrs, content, tag)
+}
+
+// envelope wraps the header and body entries in the envelope of the given SOAP version
+func (w *envelopeWriter) envelope(version, header, body string) string {
+	namespace := soap11EnvelopeNamespace
+	prefix := "soapenv"
+	if version == "1.2" {
+		namespace = soap12EnvelopeNamespace
+		prefix = "soap"
+	}
+
+	var envelope strings.Builder
+	fmt.Fprintf(&envelope, "<%s:Envelope xmlns:%s=%q", prefix, prefix, namespace)
+	for _, ns := range w.namespaces {
+		fmt.Fprintf(&envelope, " xmlns:%s=%q", w.prefixes[ns], ns)
+	}
+	envelope.WriteString(">\n")
+	if header == "" {
+		fmt.Fprintf(&envelope, "  <%s:Header/>\n", prefix)
+	} else {
+		fmt.Fprintf(&envelope, "  <%s:Header>\n%s  </%s:Header>\n", prefix, header, prefix)
+	}
+	fmt.Fprintf(&envelope, "  <%s:Body>\n%s  </%s:Body>\n", prefix, body, prefix)
+	fmt.Fprintf(&envelope, "</%s:Envelope>", prefix)
+	return envelope.String()
+}
+
+// part writes a message part: its global element, or an unqualified element named after it holding
+// its type, as the accessors of RPC style operations are
+func (w *envelopeWriter) part(part *xmlNode, depth int) string {
+	if part.attr("element") != "" {
+		decl := w.schemas.elements[part.qname("element")]
+		if decl == nil {
+			return w.wrap(w.tag(part.qname("element")), "", "?", depth)
+		}
+		return w.element(decl, depth)
+	}
+
+	attrs, content := w.typeContent(part.qname("type"), depth+1)
+	return w.wrap(part.attr("name"), attrs, content, depth)
+}
+
+// element writes the element declared by decl, following references to global elements
+func (w *envelopeWriter) element(decl *xmlNode, depth int) string {
+	if decl.attr("ref") != "" {
+		global := w.schemas.elements[decl.qname("ref")]
+		if global == nil {
+			return w.wrap(w.tag(decl.qname("ref")), "", "?", depth)
+		}
+		decl = global
+	}
+
+	tag := w.tag(w.schemas.elementName(decl))
+	if w.expanding[decl] || depth > maxEnvelopeDepth {
+		return w.wrap(tag, "", "", depth)
+	}
+	w.expanding[decl] = true
+	defer delete(w.expanding, decl)
+
+	var attrs, content string
+	switch {
+	case decl.attr("type") != "":
+		attrs, content = w.typeContent(decl.qname("type"), depth+1)
+	case decl.child(xsdNamespace, "complexType") != nil:
+		attrs, content = w.complexContent(decl.child(xsdNamespace, "complexType"), depth+1)
+	case decl.child(xsdNamespace, "simpleType") != nil:
+		content = w.simpleValue(decl.child(xsdNamespace, "simpleType"))
+	default:
+		content = "?"
+	}
+	return w.wrap(tag, attrs, content, depth)
+}
+
+// typeContent returns the attributes and content of an element of the type name. Built-in types, and
+// types that can't be found, get a ? placeholder.
+func (w *envelopeWriter) typeContent(name xml.Name, depth int) (string, string) {
+	decl := w.schemas.types[name]
+	if name.Space == xsdNamespace || decl == nil {
+		return "", "?"
+	}
+	if decl.name.Local == "simpleType" {
+		return "", w.simpleValue(decl)
+	}
+
+	if w.expanding[decl] {
+		return "", ""
+	}
+	w.expanding[decl] = true
+	defer delete(w.expanding, decl)
+	return w.complexContent(decl, depth)
+}
+
+// complexContent returns the required attributes and the child elements or value of a complex type
+func (w *envelopeWriter) complexContent(decl *xmlNode, depth int) (string, string) {
+	var attrs, content strings.Builder
+	for _, child := range decl.childrenNamed(xsdNamespace, "") {
+		switch child.name.Local {
+		case "sequence", "all", "choice", "group":
+			content.WriteString(w.particle(child, depth))
+		case "attribute":
+			attrs.WriteString(w.attribute(child))
+		case "complexContent", "simpleContent":
+			derivation := child.child(xsdNamespace, "extension")
+			if derivation == nil {
+				derivation = child.child(xsdNamespace, "restriction")
+			}
+			if derivation == nil {
+				continue
+			}
+
+			// Extensions add to their base, restrictions restate what they keep
+			if child.name.Local == "simpleContent" {
+				_, value := w.typeContent(derivation.qname("base"), depth)
+				if enumeration := derivation.child(xsdNamespace, "enumeration"); enumeration != nil {
+					value = enumeration.attr("value")
+				}
+				content.WriteString(value)
+			} else if derivation.name.Local == "extension" {
+				baseAttrs, baseContent := w.typeContent(derivation.qname("base"), depth)
+				if baseContent != "?" {
+					attrs.WriteString(baseAttrs)
+					content.WriteString(baseContent)
+				}
+			}
+			derivedAttrs, derivedContent := w.complexContent(derivation, depth)
+			attrs.WriteString(derivedAttrs)
+			content.WriteString(derivedContent)
+		}
+	}
+	return attrs.String(), content.String()
+}
+
+// particle writes the elements of a sequence, all, choice or group. Only the first option of
+// a choice is written.
+func (w *envelopeWriter) particle(decl *xmlNode, depth int) string {
+	if decl.name.Local == "group" && decl.attr("ref") != "" {
+		group := w.schemas.groups[decl.qname("ref")]
+		if group == nil || w.expanding[group] {
+			return ""
+		}
+		w.expanding[group] = true
+		defer delete(w.expanding, group)
+		decl = group
+	}
+
+	var content strings.Builder
+	for _, child := range decl.childrenNamed(xsdNamespace, "") {
+		switch child.name.Local {
+		case "element":
+			content.WriteString(w.element(child, depth))
+		case "sequence", "all", "choice", "group":
+			content.WriteString(w.particle(child, depth))
+		default:
+			continue
+		}
+		if decl.name.Local == "choice" {
+			break
+		}
+	}
+	return content.String()
+}
+
+// attribute writes the attribute declared by decl when it's required
+func (w *envelopeWriter) attribute(decl *xmlNode) string {
+	if decl.attr("use") != "required" || decl.attr("name") == "" {
+		return ""
+	}
+
+	value := "?"
+	if simpleType := decl.child(xsdNamespace, "simpleType"); simpleType != nil {
+		value = w.simpleValue(simpleType)
+	} else if decl.attr("type") != "" {
+		_, value = w.typeContent(decl.qname("type"), 0)
+	}
+	return fmt.Sprintf(" %s=%q", decl.attr("name"), value)
+}
+
+// simpleValue returns the first value of an enumeration, or a ? placeholder
+func (w *envelopeWriter) simpleValue(decl *xmlNode) string {
+	restriction := decl.child(xsdNamespace, "restriction")
+	if restriction == nil {
+		return "?"
+	}
+	if enumeration := restriction.child(xsdNamespace, "enumeration"); enumeration != nil {
+		return enumeration.attr("value")
+	}
+	if restriction.attr("base") != "" && restriction.qname("base").Space != xsdNamespace {
+		_, value := w.typeContent(restriction.qname("base"), 0)
+		return value
+	}
+	return "?"
+}
@@ -0,0 +1,497 @@
+package services
+
+import (
+	"apiclient/backend/database"
+	"apiclient/backend/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stockQuoteWSDL has document and RPC style operations, over a SOAP 1.1, a SOAP 1.2 and an HTTP port
+const stockQuoteWSDL = `<?xml version="1.0" encoding="UTF-8"?>
+<wsdl:definitions name="StockQuote"
+    targetNamespace="http://example.com/stockquote.wsdl"
+    xmlns:tns="http://example.com/stockquote.wsdl"
+    xmlns:xsd1="http://example.com/stockquote.xsd"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+    xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/"
+    xmlns:http="http://schemas.xmlsoap.org/wsdl/http/"
+    xmlns:xsd="http://www.w3.org/2001/XMLSchema"
+    xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/">
+  <wsdl:types>
+    <xsd:schema targetNamespace="http://example.com/stockquote.xsd" elementFormDefault="qualified">
+      <xsd:simpleType name="Exchange">
+        <xsd:restriction base="xsd:string">
+          <xsd:enumeration value="NYSE"/>
+          <xsd:enumeration value="NASDAQ"/>
+        </xsd:restriction>
+      </xsd:simpleType>
+      <xsd:complexType name="Symbol">
+        <xsd:simpleContent>
+          <xsd:extension base="xsd:string">
+            <xsd:attribute name="exchange" type="xsd1:Exchange" use="required"/>
+            <xsd:attribute name="note" type="xsd:string"/>
+          </xsd:extension>
+        </xsd:simpleContent>
+      </xsd:complexType>
+      <xsd:complexType name="Request">
+        <xsd:sequence>
+          <xsd:element name="requestId" type="xsd:string"/>
+        </xsd:sequence>
+      </xsd:complexType>
+      <xsd:complexType name="QuoteRequest">
+        <xsd:complexContent>
+          <xsd:extension base="xsd1:Request">
+            <xsd:sequence>
+              <xsd:element name="symbol" type="xsd1:Symbol"/>
+              <xsd:choice>
+                <xsd:element name="date" type="xsd:date"/>
+                <xsd:element name="range" type="xsd:string"/>
+              </xsd:choice>
+              <xsd:element name="portfolio" type="xsd1:Portfolio" minOccurs="0"/>
+            </xsd:sequence>
+          </xsd:extension>
+        </xsd:complexContent>
+      </xsd:complexType>
+      <xsd:complexType name="Portfolio">
+        <xsd:sequence>
+          <xsd:element name="name" type="xsd:string"/>
+          <xsd:element name="parent" type="xsd1:Portfolio" minOccurs="0"/>
+        </xsd:sequence>
+      </xsd:complexType>
+      <xsd:element name="GetQuote" type="xsd1:QuoteRequest"/>
+      <xsd:element name="Auth">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="token" type="xsd:string"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+    </xsd:schema>
+  </wsdl:types>
+
+  <wsdl:message name="GetQuoteInput">
+    <wsdl:part name="body" element="xsd1:GetQuote"/>
+  </wsdl:message>
+  <wsdl:message name="AuthHeader">
+    <wsdl:part name="auth" element="xsd1:Auth"/>
+  </wsdl:message>
+  <wsdl:message name="GetPriceInput">
+    <wsdl:part name="symbol" type="xsd1:Symbol"/>
+    <wsdl:part name="exchange" type="xsd1:Exchange"/>
+    <wsdl:part name="count" type="xsd:int"/>
+  </wsdl:message>
+  <wsdl:message name="Output">
+    <wsdl:part name="price" type="xsd:float"/>
+  </wsdl:message>
+
+  <wsdl:portType name="StockQuotePortType">
+    <wsdl:operation name="GetQuote">
+      <wsdl:input message="tns:GetQuoteInput"/>
+      <wsdl:output message="tns:Output"/>
+    </wsdl:operation>
+    <wsdl:operation name="GetPrice">
+      <wsdl:input message="tns:GetPriceInput"/>
+      <wsdl:output message="tns:Output"/>
+    </wsdl:operation>
+  </wsdl:portType>
+
+  <wsdl:binding name="StockQuoteSoapBinding" type="tns:StockQuotePortType">
+    <soap:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="GetQuote">
+      <soap:operation soapAction="http://example.com/GetQuote"/>
+      <wsdl:input>
+        <soap:body use="literal"/>
+        <soap:header message="tns:AuthHeader" part="auth" use="literal"/>
+      </wsdl:input>
+    </wsdl:operation>
+    <wsdl:operation name="GetPrice">
+      <soap:operation soapAction="http://example.com/GetPrice" style="rpc"/>
+      <wsdl:input>
+        <soap:body use="literal" namespace="http://example.com/stockquote/rpc" parts="symbol count"/>
+      </wsdl:input>
+    </wsdl:operation>
+  </wsdl:binding>
+
+  <wsdl:binding name="StockQuoteSoap12Binding" type="tns:StockQuotePortType">
+    <soap12:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="GetQuote">
+      <soap12:operation soapAction="http://example.com/GetQuote"/>
+      <wsdl:input>
+        <soap12:body use="literal"/>
+      </wsdl:input>
+    </wsdl:operation>
+    <wsdl:operation name="GetPrice">
+      <soap12:operation style="rpc"/>
+      <wsdl:input>
+        <soap12:body use="literal" namespace="http://example.com/stockquote/rpc"/>
+      </wsdl:input>
+    </wsdl:operation>
+  </wsdl:binding>
+
+  <wsdl:binding name="StockQuoteHttpBinding" type="tns:StockQuotePortType">
+    <http:binding verb="GET"/>
+  </wsdl:binding>
+
+  <wsdl:service name="StockQuoteService">
+    <wsdl:port name="StockQuoteSoap" binding="tns:StockQuoteSoapBinding">
+      <soap:address location="http://example.com/stockquote"/>
+    </wsdl:port>
+    <wsdl:port name="StockQuoteSoap12" binding="tns:StockQuoteSoap12Binding">
+      <soap12:address location="http://example.com/stockquote12"/>
+    </wsdl:port>
+    <wsdl:port name="StockQuoteHttp" binding="tns:StockQuoteHttpBinding">
+      <http:address location="http://example.com/stockquote/http"/>
+    </wsdl:port>
+  </wsdl:service>
+</wsdl:definitions>
+`
+
+func TestWSDLOperations(t *testing.T) {
+	definitions := newWSDLDefinitions(http.DefaultClient)
+	err := definitions.load(context.Background(), "", []byte(stockQuoteWSDL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations, err := definitions.operations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getQuoteBody := `    <ns1:GetQuote>
+      <ns1:requestId>?</ns1:requestId>
+      <ns1:symbol exchange="NYSE">?</ns1:symbol>
+      <ns1:date>?</ns1:date>
+      <ns1:portfolio>
+        <ns1:name>?</ns1:name>
+        <ns1:parent></ns1:parent>
+      </ns1:portfolio>
+    </ns1:GetQuote>
+`
+	want := []soapOperation{
+		{
+			name: "GetPrice", port: "StockQuoteSoap", endpoint: "http://example.com/stockquote", soapVersion: "1.1", action: "http://example.com/GetPrice",
+			envelope: `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="http://example.com/stockquote/rpc">
+  <soapenv:Header/>
+  <soapenv:Body>
+    <ns1:GetPrice>
+      <symbol exchange="NYSE">?</symbol>
+      <count>?</count>
+    </ns1:GetPrice>
+  </soapenv:Body>
+</soapenv:Envelope>`,
+		},
+		{
+			name: "GetQuote", port: "StockQuoteSoap", endpoint: "http://example.com/stockquote", soapVersion: "1.1", action: "http://example.com/GetQuote",
+			envelope: `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="http://example.com/stockquote.xsd">
+  <soapenv:Header>
+    <ns1:Auth>
+      <ns1:token>?</ns1:token>
+    </ns1:Auth>
+  </soapenv:Header>
+  <soapenv:Body>
+` + getQuoteBody + `  </soapenv:Body>
+</soapenv:Envelope>`,
+		},
+		{
+			name: "GetPrice", port: "StockQuoteSoap12", endpoint: "http://example.com/stockquote12", soapVersion: "1.2",
+			envelope: `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns:ns1="http://example.com/stockquote/rpc">
+  <soap:Header/>
+  <soap:Body>
+    <ns1:GetPrice>
+      <symbol exchange="NYSE">?</symbol>
+      <exchange>NYSE</exchange>
+      <count>?</count>
+    </ns1:GetPrice>
+  </soap:Body>
+</soap:Envelope>`,
+		},
+		{
+			name: "GetQuote", port: "StockQuoteSoap12", endpoint: "http://example.com/stockquote12", soapVersion: "1.2", action: "http://example.com/GetQuote",
+			envelope: `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns:ns1="http://example.com/stockquote.xsd">
+  <soap:Header/>
+  <soap:Body>
+` + getQuoteBody + `  </soap:Body>
+</soap:Envelope>`,
+		},
+	}
+	if len(operations) != len(want) {
+		t.Fatalf("got %d operations, want %d", len(operations), len(want))
+	}
+	for i, operation := range operations {
+		if operation != want[i] {
+			t.Errorf("operation %d is\n%+v\nwant\n%+v", i, operation, want[i])
+		}
+	}
+}
+
+// greeterWSDLFiles is a WSDL importing another WSDL and a schema, which includes another schema
+var greeterWSDLFiles = map[string]string{
+	"service.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" xmlns:xsd="http://www.w3.org/2001/XMLSchema"
+    xmlns:tns="urn:greeter" xmlns:types="urn:greeter:types" targetNamespace="urn:greeter">
+  <import namespace="urn:greeter" location="ports/binding.wsdl"/>
+  <types>
+    <xsd:schema targetNamespace="urn:greeter:wrapper">
+      <xsd:import namespace="urn:greeter:types" schemaLocation="schemas/types.xsd"/>
+    </xsd:schema>
+  </types>
+  <message name="HelloInput">
+    <part name="body" element="types:Hello"/>
+  </message>
+  <portType name="Greeter">
+    <operation name="Hello">
+      <input message="tns:HelloInput"/>
+    </operation>
+  </portType>
+</definitions>`,
+	"ports/binding.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+    xmlns:tns="urn:greeter" targetNamespace="urn:greeter">
+  <binding name="GreeterBinding" type="tns:Greeter">
+    <soap:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <operation name="Hello">
+      <soap:operation soapAction="urn:hello"/>
+      <input><soap:body use="literal"/></input>
+    </operation>
+  </binding>
+  <service name="GreeterService">
+    <port name="GreeterPort" binding="tns:GreeterBinding">
+      <soap:address location="http://example.com/greeter"/>
+    </port>
+  </service>
+</definitions>`,
+	"schemas/types.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:types="urn:greeter:types"
+    targetNamespace="urn:greeter:types" elementFormDefault="qualified">
+  <xsd:include schemaLocation="common.xsd"/>
+  <xsd:element name="Hello">
+    <xsd:complexType>
+      <xsd:sequence>
+        <xsd:element name="name" type="xsd:string"/>
+        <xsd:element name="language" type="types:Language"/>
+      </xsd:sequence>
+    </xsd:complexType>
+  </xsd:element>
+</xsd:schema>`,
+	// Included without a target namespace, it takes the one of types.xsd
+	"schemas/common.xsd": `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+  <xsd:simpleType name="Language">
+    <xsd:restriction base="xsd:string">
+      <xsd:enumeration value="en"/>
+    </xsd:restriction>
+  </xsd:simpleType>
+</xsd:schema>`,
+}
+
+func TestWSDLImports(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range greeterWSDLFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	location := filepath.Join(dir, "service.wsdl")
+	data, err := loadWSDLDocument(context.Background(), http.DefaultClient, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	definitions := newWSDLDefinitions(http.DefaultClient)
+	err = definitions.load(context.Background(), location, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations, err := definitions.operations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(operations))
+	}
+
+	want := `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="urn:greeter:types">
+  <soapenv:Header/>
+  <soapenv:Body>
+    <ns1:Hello>
+      <ns1:name>?</ns1:name>
+      <ns1:language>en</ns1:language>
+    </ns1:Hello>
+  </soapenv:Body>
+</soapenv:Envelope>`
+	if operations[0].envelope != want {
+		t.Errorf("got envelope\n%s\nwant\n%s", operations[0].envelope, want)
+	}
+	if definitions.serviceName() != "GreeterService" {
+		t.Errorf("got service name %q, want GreeterService", definitions.serviceName())
+	}
+}
+
+func TestImportWSDLOverHTTPS(t *testing.T) {
+	useTestDatabase(t)
+
+	secret := filepath.Join(t.TempDir(), "secret.xsd")
+	if err := os.WriteFile(secret, []byte(`<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema"/>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"local-import.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/"><import location="file://` + filepath.ToSlash(secret) + `"/></definitions>`,
+	}
+	for name, content := range greeterWSDLFiles {
+		files[name] = content
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	s := &APIClientService{}
+
+	// The self-signed certificate of the server is only accepted through the execution transport
+	_, err := s.ImportWSDL(context.Background(), server.URL+"/service.wsdl", "", models.RequestSettings{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("got error %v without skipping TLS verification, want a certificate error", err)
+	}
+
+	settings := models.RequestSettings{SkipTLSVerify: true}
+	requests, err := s.ImportWSDL(context.Background(), server.URL+"/service.wsdl", "", settings, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].Name != "Hello" || !strings.Contains(requests[0].Body, "<ns1:language>en</ns1:language>") {
+		t.Errorf("got requests %+v, want Hello with the envelope of the imported schemas", requests)
+	}
+
+	_, err = s.ImportWSDL(context.Background(), server.URL+"/local-import.wsdl", "", settings, nil)
+	if err == nil || !strings.Contains(err.Error(), "isn't an http or https URL") {
+		t.Errorf("got error %v importing a local file from a remote WSDL, want it rejected", err)
+	}
+}
+
+func TestWSDLLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		err      string
+	}{
+		{"wsdl 2.0", `<description xmlns="http://www.w3.org/ns/wsdl"/>`, "WSDL 2.0"},
+		{"not wsdl", `<html><body/></html>`, "not a WSDL document"},
+		{"not xml", `{"openapi": "3.0.0"}`, "parsing"},
+		{"relative import without location", `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/"><import location="other.wsdl"/></definitions>`, "without the location"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newWSDLDefinitions(http.DefaultClient).load(context.Background(), "", []byte(tt.document))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want one about %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSOAPOperationHeaders(t *testing.T) {
+	tests := []struct {
+		operation soapOperation
+		want      map[string]string
+	}{
+		{soapOperation{soapVersion: "1.1", action: "urn:hello"}, map[string]string{"Content-Type": "text/xml; charset=utf-8", "SOAPAction": `"urn:hello"`}},
+		{soapOperation{soapVersion: "1.1"}, map[string]string{"Content-Type": "text/xml; charset=utf-8", "SOAPAction": `""`}},
+		{soapOperation{soapVersion: "1.2", action: "urn:hello"}, map[string]string{"Content-Type": `application/soap+xml; charset=utf-8; action="urn:hello"`}},
+		{soapOperation{soapVersion: "1.2"}, map[string]string{"Content-Type": "application/soap+xml; charset=utf-8"}},
+	}
+	for _, tt := range tests {
+		got := tt.operation.headers()
+		if len(got) != len(tt.want) {
+			t.Errorf("headers of %+v = %q, want %q", tt.operation, got, tt.want)
+			continue
+		}
+		for name, value := range tt.want {
+			if got[name] != value {
+				t.Errorf("headers of %+v = %q, want %q", tt.operation, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestResolveWSDLLocation(t *testing.T) {
+	tests := []struct {
+		base, ref string
+		want      string
+		ok        bool
+	}{
+		{"http://example.com/wsdl/service.wsdl", "types.xsd", "http://example.com/wsdl/types.xsd", true},
+		{"http://example.com/wsdl/service.wsdl", "../xsd/types.xsd", "http://example.com/xsd/types.xsd", true},
+		{"http://example.com/wsdl/service.wsdl", "https://other.com/types.xsd", "https://other.com/types.xsd", true},
+		{filepath.FromSlash("/wsdl/service.wsdl"), "types.xsd", filepath.FromSlash("/wsdl/types.xsd"), true},
+		{filepath.FromSlash("/wsdl/service.wsdl"), "xsd/types.xsd", filepath.FromSlash("/wsdl/xsd/types.xsd"), true},
+		{"", "types.xsd", "", false},
+		{filepath.FromSlash("/wsdl/service.wsdl"), "http://example.com/types.xsd", "http://example.com/types.xsd", true},
+		// Remote documents only import URLs resolved against their own
+		{"http://example.com/wsdl/service.wsdl", "/etc/passwd", "http://example.com/etc/passwd", true},
+		{"http://example.com/wsdl/service.wsdl", "file:///etc/passwd", "", false},
+		{"https://example.com/wsdl/service.wsdl", `C:\secrets\types.xsd`, "", false},
+		{"https://example.com/wsdl/service.wsdl", "ftp://example.com/types.xsd", "", false},
+	}
+	for _, tt := range tests {
+		got, err := resolveWSDLLocation(tt.base, tt.ref)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("resolveWSDLLocation(%q, %q) = %q, %v, want %q", tt.base, tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportWSDL(t *testing.T) {
+	useTestDatabase(t)
+
+	s := &APIClientService{}
+	requests, err := s.ImportWSDL(context.Background(), "", stockQuoteWSDL, models.RequestSettings{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 4 {
+		t.Fatalf("got %d requests, want 4", len(requests))
+	}
+
+	collections, err := database.GetCollections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 1 || collections[0].Name != "StockQuoteService" {
+		t.Fatalf("got collections %+v, want StockQuoteService", collections)
+	}
+
+	// The operations of every port are in a folder named after it
+	folders := map[int]string{}
+	for _, request := range requests {
+		if request.CollectionID == nil || *request.CollectionID != collections[0].ID || request.FolderID == nil {
+			t.Fatalf("request %s isn't in a folder of the collection", request.Name)
+		}
+		folders[*request.FolderID] = request.URL
+		if request.Method != "POST" || request.Type != models.RequestTypeHTTP || request.BodyType != "xml" || !strings.HasPrefix(request.Body, "<") {
+			t.Errorf("got request %+v, want a POST of an XML envelope", request)
+		}
+	}
+	if len(folders) != 2 {
+		t.Errorf("got %d folders, want 2", len(folders))
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(requests[1].Headers), &headers); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].Name != "GetQuote" || headers["SOAPAction"] != `"http://example.com/GetQuote"` {
+		t.Errorf("got request %s with headers %q, want GetQuote with its SOAPAction", requests[1].Name, headers)
+	}
+}
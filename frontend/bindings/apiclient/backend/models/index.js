@@ -3,9 +3,40 @@
 // This file is automatically generated. DO NOT EDIT
 
 export {
+    AWSConfig,
+    AttemptRecord,
+    Certificate,
     Collection,
+    Cookie,
     Environment,
+    ExchangeRecord,
+    ExecutionResult,
+    ExecutionTimings,
     Folder,
+    FormField,
+    GRPCMessage,
+    GRPCMethod,
+    GRPCService,
+    GRPCSpec,
+    GRPCStatus,
+    GraphQLBody,
+    GraphQLError,
+    GraphQLLocation,
+    GraphQLSchema,
+    GraphQLSubscriptionEvent,
+    JWTConfig,
+    KeyValue,
+    OAuth1Config,
+    OAuth2Config,
+    Proxy,
     Request,
-    RequestHistory
+    RequestAuth,
+    RequestHistory,
+    RequestSettings,
+    RequestSpec,
+    RetryPolicy,
+    SOAPFault,
+    ServerSentEvent,
+    WebSocketSession,
+    WebSocketSpec
 } from "./models.js";
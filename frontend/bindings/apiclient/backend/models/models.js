@@ -11,71 +11,150 @@ import { Create as $Create } from "@wailsio/runtime";
 import * as time$0 from "../../../time/models.js";
 
 /**
- * Collection represents an API request collection
+ * AWSConfig represents the credentials used to sign requests with AWS Signature Version 4
  */
-export class Collection {
+export class AWSConfig {
     /**
-     * Creates a new Collection instance.
-     * @param {Partial<Collection>} [$$source = {}] - The source object to create the Collection.
+     * Creates a new AWSConfig instance.
+     * @param {Partial<AWSConfig>} [$$source = {}] - The source object to create the AWSConfig.
      */
     constructor($$source = {}) {
-        if (!("id" in $$source)) {
+        if (!("access_key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["access_key"] = "";
+        }
+        if (!("secret_key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["secret_key"] = "";
+        }
+        if (!("session_token" in $$source)) {
+            /**
+             * for temporary credentials
+             * @member
+             * @type {string}
+             */
+            this["session_token"] = "";
+        }
+        if (!("region" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["region"] = "";
+        }
+        if (!("service" in $$source)) {
+            /**
+             * e.g. execute-api or s3
+             * @member
+             * @type {string}
+             */
+            this["service"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new AWSConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {AWSConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new AWSConfig(/** @type {Partial<AWSConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * AttemptRecord represents one attempt of an execution retried according to its RetryPolicy
+ */
+export class AttemptRecord {
+    /**
+     * Creates a new AttemptRecord instance.
+     * @param {Partial<AttemptRecord>} [$$source = {}] - The source object to create the AttemptRecord.
+     */
+    constructor($$source = {}) {
+        if (!("attempt" in $$source)) {
             /**
+             * starting at 1
              * @member
              * @type {number}
              */
-            this["id"] = 0;
+            this["attempt"] = 0;
         }
-        if (!("name" in $$source)) {
+        if (!("status" in $$source)) {
+            /**
+             * 0 when the attempt failed without a response
+             * @member
+             * @type {number}
+             */
+            this["status"] = 0;
+        }
+        if (!("status_text" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["name"] = "";
+            this["status_text"] = "";
         }
-        if (!("description" in $$source)) {
+        if (!("error" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["description"] = "";
+            this["error"] = "";
         }
-        if (!("created_at" in $$source)) {
+        if (!("duration_ms" in $$source)) {
             /**
              * @member
-             * @type {time$0.Time}
+             * @type {number}
              */
-            this["created_at"] = null;
+            this["duration_ms"] = 0;
         }
-        if (!("updated_at" in $$source)) {
+        if (!("delay_ms" in $$source)) {
             /**
+             * waited before the next attempt, 0 for the last one
              * @member
-             * @type {time$0.Time}
+             * @type {number}
              */
-            this["updated_at"] = null;
+            this["delay_ms"] = 0;
+        }
+        if (!("retry_after" in $$source)) {
+            /**
+             * the delay came from the Retry-After header of the response
+             * @member
+             * @type {boolean}
+             */
+            this["retry_after"] = false;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new Collection instance from a string or object.
+     * Creates a new AttemptRecord instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Collection}
+     * @returns {AttemptRecord}
      */
     static createFrom($$source = {}) {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new Collection(/** @type {Partial<Collection>} */($$parsedSource));
+        return new AttemptRecord(/** @type {Partial<AttemptRecord>} */($$parsedSource));
     }
 }
 
 /**
- * Environment represents an environment with variables
+ * Certificate represents a client certificate and/or extra CA roots used for matching hosts
  */
-export class Environment {
+export class Certificate {
     /**
-     * Creates a new Environment instance.
-     * @param {Partial<Environment>} [$$source = {}] - The source object to create the Environment.
+     * Creates a new Certificate instance.
+     * @param {Partial<Certificate>} [$$source = {}] - The source object to create the Certificate.
      */
     constructor($$source = {}) {
         if (!("id" in $$source)) {
@@ -92,20 +171,59 @@ export class Environment {
              */
             this["name"] = "";
         }
-        if (!("variables" in $$source)) {
+        if (!("host_pattern" in $$source)) {
             /**
-             * JSON string
+             * host[:port], optionally with a leading *. wildcard
              * @member
              * @type {string}
              */
-            this["variables"] = "";
+            this["host_pattern"] = "";
         }
-        if (!("is_active" in $$source)) {
+        if (!("format" in $$source)) {
+            /**
+             * pem or pkcs12
+             * @member
+             * @type {string}
+             */
+            this["format"] = "";
+        }
+        if (!("cert" in $$source)) {
+            /**
+             * PEM certificate chain, or base64 PKCS#12 bundle
+             * @member
+             * @type {string}
+             */
+            this["cert"] = "";
+        }
+        if (!("key" in $$source)) {
+            /**
+             * PEM private key, unused for PKCS#12
+             * @member
+             * @type {string}
+             */
+            this["key"] = "";
+        }
+        if (!("passphrase" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["passphrase"] = "";
+        }
+        if (!("ca" in $$source)) {
+            /**
+             * PEM root certificates to trust in addition to the system ones
+             * @member
+             * @type {string}
+             */
+            this["ca"] = "";
+        }
+        if (!("enabled" in $$source)) {
             /**
              * @member
              * @type {boolean}
              */
-            this["is_active"] = false;
+            this["enabled"] = false;
         }
         if (!("created_at" in $$source)) {
             /**
@@ -119,23 +237,23 @@ export class Environment {
     }
 
     /**
-     * Creates a new Environment instance from a string or object.
+     * Creates a new Certificate instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Environment}
+     * @returns {Certificate}
      */
     static createFrom($$source = {}) {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new Environment(/** @type {Partial<Environment>} */($$parsedSource));
+        return new Certificate(/** @type {Partial<Certificate>} */($$parsedSource));
     }
 }
 
 /**
- * Folder represents a folder within a collection
+ * Collection represents an API request collection
  */
-export class Folder {
+export class Collection {
     /**
-     * Creates a new Folder instance.
-     * @param {Partial<Folder>} [$$source = {}] - The source object to create the Folder.
+     * Creates a new Collection instance.
+     * @param {Partial<Collection>} [$$source = {}] - The source object to create the Collection.
      */
     constructor($$source = {}) {
         if (!("id" in $$source)) {
@@ -152,19 +270,20 @@ export class Folder {
              */
             this["name"] = "";
         }
-        if (!("collection_id" in $$source)) {
+        if (!("description" in $$source)) {
             /**
              * @member
-             * @type {number}
+             * @type {string}
              */
-            this["collection_id"] = 0;
+            this["description"] = "";
         }
-        if (!("parent_folder_id" in $$source)) {
+        if (!("retry_policy" in $$source)) {
             /**
+             * applies to the requests that don't set their own
              * @member
-             * @type {number | null}
+             * @type {RetryPolicy | null}
              */
-            this["parent_folder_id"] = null;
+            this["retry_policy"] = null;
         }
         if (!("created_at" in $$source)) {
             /**
@@ -173,28 +292,39 @@ export class Folder {
              */
             this["created_at"] = null;
         }
+        if (!("updated_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["updated_at"] = null;
+        }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new Folder instance from a string or object.
+     * Creates a new Collection instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Folder}
+     * @returns {Collection}
      */
     static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType1;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new Folder(/** @type {Partial<Folder>} */($$parsedSource));
+        if ("retry_policy" in $$parsedSource) {
+            $$parsedSource["retry_policy"] = $$createField3_0($$parsedSource["retry_policy"]);
+        }
+        return new Collection(/** @type {Partial<Collection>} */($$parsedSource));
     }
 }
 
 /**
- * Request represents an API request
+ * Cookie represents a cookie stored in the cookie jar of an environment
  */
-export class Request {
+export class Cookie {
     /**
-     * Creates a new Request instance.
-     * @param {Partial<Request>} [$$source = {}] - The source object to create the Request.
+     * Creates a new Cookie instance.
+     * @param {Partial<Cookie>} [$$source = {}] - The source object to create the Cookie.
      */
     constructor($$source = {}) {
         if (!("id" in $$source)) {
@@ -204,6 +334,14 @@ export class Request {
              */
             this["id"] = 0;
         }
+        if (!("environment_id" in $$source)) {
+            /**
+             * nil when no environment is active
+             * @member
+             * @type {number | null}
+             */
+            this["environment_id"] = null;
+        }
         if (!("name" in $$source)) {
             /**
              * @member
@@ -211,85 +349,86 @@ export class Request {
              */
             this["name"] = "";
         }
-        if (!("method" in $$source)) {
+        if (!("value" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["method"] = "";
+            this["value"] = "";
         }
-        if (!("url" in $$source)) {
+        if (!("domain" in $$source)) {
             /**
              * @member
              * @type {string}
              */
-            this["url"] = "";
+            this["domain"] = "";
         }
-        if (!("headers" in $$source)) {
+        if (!("path" in $$source)) {
             /**
-             * JSON string
              * @member
              * @type {string}
              */
-            this["headers"] = "";
+            this["path"] = "";
         }
-        if (!("body" in $$source)) {
+        if (!("expires" in $$source)) {
             /**
+             * nil for session cookies
              * @member
-             * @type {string}
+             * @type {time$0.Time | null}
              */
-            this["body"] = "";
+            this["expires"] = null;
         }
-        if (!("collection_id" in $$source)) {
+        if (!("secure" in $$source)) {
             /**
              * @member
-             * @type {number | null}
+             * @type {boolean}
              */
-            this["collection_id"] = null;
+            this["secure"] = false;
         }
-        if (!("folder_id" in $$source)) {
+        if (!("http_only" in $$source)) {
             /**
              * @member
-             * @type {number | null}
+             * @type {boolean}
              */
-            this["folder_id"] = null;
+            this["http_only"] = false;
         }
-        if (!("created_at" in $$source)) {
+        if (!("host_only" in $$source)) {
             /**
+             * only sent to Domain itself, not to its subdomains
              * @member
-             * @type {time$0.Time}
+             * @type {boolean}
              */
-            this["created_at"] = null;
+            this["host_only"] = false;
         }
-        if (!("updated_at" in $$source)) {
+        if (!("created_at" in $$source)) {
             /**
              * @member
              * @type {time$0.Time}
              */
-            this["updated_at"] = null;
+            this["created_at"] = null;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new Request instance from a string or object.
+     * Creates a new Cookie instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {Request}
+     * @returns {Cookie}
      */
     static createFrom($$source = {}) {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new Request(/** @type {Partial<Request>} */($$parsedSource));
+        return new Cookie(/** @type {Partial<Cookie>} */($$parsedSource));
     }
 }
 
 /**
- * RequestHistory represents a request execution history
+ * Environment represents an environment with variables
  */
-export class RequestHistory {
+export class Environment {
     /**
-     * Creates a new RequestHistory instance.
-     * @param {Partial<RequestHistory>} [$$source = {}] - The source object to create the RequestHistory.
+     * Creates a new Environment instance.
+     * @param {Partial<Environment>} [$$source = {}] - The source object to create the Environment.
      */
     constructor($$source = {}) {
         if (!("id" in $$source)) {
@@ -299,60 +438,2681 @@ export class RequestHistory {
              */
             this["id"] = 0;
         }
-        if (!("request_id" in $$source)) {
-            /**
-             * @member
-             * @type {number}
-             */
-            this["request_id"] = 0;
-        }
-        if (!("response_status" in $$source)) {
-            /**
-             * @member
-             * @type {number}
-             */
-            this["response_status"] = 0;
-        }
-        if (!("response_time" in $$source)) {
+        if (!("name" in $$source)) {
             /**
              * @member
-             * @type {number}
+             * @type {string}
              */
-            this["response_time"] = 0;
+            this["name"] = "";
         }
-        if (!("response_body" in $$source)) {
+        if (!("variables" in $$source)) {
             /**
+             * JSON string
              * @member
              * @type {string}
              */
-            this["response_body"] = "";
+            this["variables"] = "";
         }
-        if (!("response_headers" in $$source)) {
+        if (!("is_active" in $$source)) {
             /**
-             * JSON string
              * @member
-             * @type {string}
+             * @type {boolean}
              */
-            this["response_headers"] = "";
+            this["is_active"] = false;
         }
-        if (!("executed_at" in $$source)) {
+        if (!("created_at" in $$source)) {
             /**
              * @member
              * @type {time$0.Time}
              */
-            this["executed_at"] = null;
+            this["created_at"] = null;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new RequestHistory instance from a string or object.
+     * Creates a new Environment instance from a string or object.
      * @param {any} [$$source = {}]
-     * @returns {RequestHistory}
+     * @returns {Environment}
      */
     static createFrom($$source = {}) {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new RequestHistory(/** @type {Partial<RequestHistory>} */($$parsedSource));
+        return new Environment(/** @type {Partial<Environment>} */($$parsedSource));
     }
 }
+
+/**
+ * ExchangeRecord represents an intermediate request/response exchange, such as a redirect hop
+ */
+export class ExchangeRecord {
+    /**
+     * Creates a new ExchangeRecord instance.
+     * @param {Partial<ExchangeRecord>} [$$source = {}] - The source object to create the ExchangeRecord.
+     */
+    constructor($$source = {}) {
+        if (!("method" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["method"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["status"] = 0;
+        }
+        if (!("status_text" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["status_text"] = "";
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * response headers
+             * @member
+             * @type {{ [_: string]: string[] }}
+             */
+            this["headers"] = {};
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * recorded for auth exchanges
+             * @member
+             * @type {{ [_: string]: string[] } | undefined}
+             */
+            this["request_headers"] = undefined;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExchangeRecord instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ExchangeRecord}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType3;
+        const $$createField5_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField4_0($$parsedSource["headers"]);
+        }
+        if ("request_headers" in $$parsedSource) {
+            $$parsedSource["request_headers"] = $$createField5_0($$parsedSource["request_headers"]);
+        }
+        return new ExchangeRecord(/** @type {Partial<ExchangeRecord>} */($$parsedSource));
+    }
+}
+
+/**
+ * ExecutionResult represents the response of an executed RequestSpec
+ */
+export class ExecutionResult {
+    /**
+     * Creates a new ExecutionResult instance.
+     * @param {Partial<ExecutionResult>} [$$source = {}] - The source object to create the ExecutionResult.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("outcome" in $$source)) {
+            /**
+             * completed, cancelled or timed_out
+             * @member
+             * @type {string}
+             */
+            this["outcome"] = "";
+        }
+        if (!("error" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["error"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["status"] = 0;
+        }
+        if (!("status_text" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["status_text"] = "";
+        }
+        if (!("protocol" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["protocol"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * final URL, after redirects
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("proxy" in $$source)) {
+            /**
+             * proxy the final request went through, empty when direct
+             * @member
+             * @type {string}
+             */
+            this["proxy"] = "";
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * @member
+             * @type {{ [_: string]: string[] }}
+             */
+            this["headers"] = {};
+        }
+        if (!("trailers" in $$source)) {
+            /**
+             * sent after the body, e.g. the gRPC status
+             * @member
+             * @type {{ [_: string]: string[] }}
+             */
+            this["trailers"] = {};
+        }
+        if (!("body" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["body"] = "";
+        }
+        if (!("content_type" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["content_type"] = "";
+        }
+        if (!("body_size" in $$source)) {
+            /**
+             * bytes received, or written to SavedTo
+             * @member
+             * @type {number}
+             */
+            this["body_size"] = 0;
+        }
+        if (!("body_encoding" in $$source)) {
+            /**
+             * text, or base64 for binary bodies
+             * @member
+             * @type {string}
+             */
+            this["body_encoding"] = "";
+        }
+        if (!("is_binary" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["is_binary"] = false;
+        }
+        if (!("truncated" in $$source)) {
+            /**
+             * the body exceeded max_response_bytes and was cut
+             * @member
+             * @type {boolean}
+             */
+            this["truncated"] = false;
+        }
+        if (!("saved_to" in $$source)) {
+            /**
+             * file the body was streamed to
+             * @member
+             * @type {string}
+             */
+            this["saved_to"] = "";
+        }
+        if (!("headers_size" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["headers_size"] = 0;
+        }
+        if (!("timings" in $$source)) {
+            /**
+             * phases of the final exchange, total across all hops
+             * @member
+             * @type {ExecutionTimings}
+             */
+            this["timings"] = (new ExecutionTimings());
+        }
+        if (!("redirects" in $$source)) {
+            /**
+             * @member
+             * @type {ExchangeRecord[]}
+             */
+            this["redirects"] = [];
+        }
+        if (!("auth_exchanges" in $$source)) {
+            /**
+             * AuthExchanges records challenge/response authentication, e.g. the 401 answered by Digest auth
+             * followed by the authorized exchange
+             * @member
+             * @type {ExchangeRecord[]}
+             */
+            this["auth_exchanges"] = [];
+        }
+        if (!("attempts" in $$source)) {
+            /**
+             * Attempts records every attempt when the request was retried; Redirects and AuthExchanges
+             * only cover the last one
+             * @member
+             * @type {AttemptRecord[]}
+             */
+            this["attempts"] = [];
+        }
+        if (!("events" in $$source)) {
+            /**
+             * Events is the transcript of a text/event-stream response, whose raw stream is kept in Body
+             * @member
+             * @type {ServerSentEvent[]}
+             */
+            this["events"] = [];
+        }
+        if (!("messages" in $$source)) {
+            /**
+             * Messages is the transcript of a gRPC call, whose response messages are also kept in Body
+             * @member
+             * @type {GRPCMessage[]}
+             */
+            this["messages"] = [];
+        }
+        if (!("grpc_status" in $$source)) {
+            /**
+             * GRPCStatus is the status a gRPC call ended with, whose JSON form is the Body of failed calls
+             * @member
+             * @type {GRPCStatus | null}
+             */
+            this["grpc_status"] = null;
+        }
+        if (!("graphql_errors" in $$source)) {
+            /**
+             * GraphQLErrors are the errors of a GraphQL response, which usually comes with a 200 status
+             * @member
+             * @type {GraphQLError[]}
+             */
+            this["graphql_errors"] = [];
+        }
+        if (!("subscription_events" in $$source)) {
+            /**
+             * SubscriptionEvents is the transcript of a GraphQL subscription
+             * @member
+             * @type {GraphQLSubscriptionEvent[]}
+             */
+            this["subscription_events"] = [];
+        }
+        if (!("soap_fault" in $$source)) {
+            /**
+             * SOAPFault is the fault of a SOAP response, which usually comes with a 500 status
+             * @member
+             * @type {SOAPFault | null}
+             */
+            this["soap_fault"] = null;
+        }
+        if (!("unresolved_variables" in $$source)) {
+            /**
+             * UnresolvedVariables lists the {{placeholders}} that had no matching variable
+             * @member
+             * @type {string[]}
+             */
+            this["unresolved_variables"] = [];
+        }
+        if (!("generated_variables" in $$source)) {
+            /**
+             * GeneratedVariables records the values produced for {{$dynamic}} variables during this execution
+             * @member
+             * @type {{ [_: string]: string }}
+             */
+            this["generated_variables"] = {};
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExecutionResult instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ExecutionResult}
+     */
+    static createFrom($$source = {}) {
+        const $$createField8_0 = $$createType3;
+        const $$createField9_0 = $$createType3;
+        const $$createField18_0 = $$createType4;
+        const $$createField19_0 = $$createType6;
+        const $$createField20_0 = $$createType6;
+        const $$createField21_0 = $$createType8;
+        const $$createField22_0 = $$createType10;
+        const $$createField23_0 = $$createType12;
+        const $$createField24_0 = $$createType14;
+        const $$createField25_0 = $$createType16;
+        const $$createField26_0 = $$createType18;
+        const $$createField27_0 = $$createType20;
+        const $$createField28_0 = $$createType2;
+        const $$createField29_0 = $$createType21;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField8_0($$parsedSource["headers"]);
+        }
+        if ("trailers" in $$parsedSource) {
+            $$parsedSource["trailers"] = $$createField9_0($$parsedSource["trailers"]);
+        }
+        if ("timings" in $$parsedSource) {
+            $$parsedSource["timings"] = $$createField18_0($$parsedSource["timings"]);
+        }
+        if ("redirects" in $$parsedSource) {
+            $$parsedSource["redirects"] = $$createField19_0($$parsedSource["redirects"]);
+        }
+        if ("auth_exchanges" in $$parsedSource) {
+            $$parsedSource["auth_exchanges"] = $$createField20_0($$parsedSource["auth_exchanges"]);
+        }
+        if ("attempts" in $$parsedSource) {
+            $$parsedSource["attempts"] = $$createField21_0($$parsedSource["attempts"]);
+        }
+        if ("events" in $$parsedSource) {
+            $$parsedSource["events"] = $$createField22_0($$parsedSource["events"]);
+        }
+        if ("messages" in $$parsedSource) {
+            $$parsedSource["messages"] = $$createField23_0($$parsedSource["messages"]);
+        }
+        if ("grpc_status" in $$parsedSource) {
+            $$parsedSource["grpc_status"] = $$createField24_0($$parsedSource["grpc_status"]);
+        }
+        if ("graphql_errors" in $$parsedSource) {
+            $$parsedSource["graphql_errors"] = $$createField25_0($$parsedSource["graphql_errors"]);
+        }
+        if ("subscription_events" in $$parsedSource) {
+            $$parsedSource["subscription_events"] = $$createField26_0($$parsedSource["subscription_events"]);
+        }
+        if ("soap_fault" in $$parsedSource) {
+            $$parsedSource["soap_fault"] = $$createField27_0($$parsedSource["soap_fault"]);
+        }
+        if ("unresolved_variables" in $$parsedSource) {
+            $$parsedSource["unresolved_variables"] = $$createField28_0($$parsedSource["unresolved_variables"]);
+        }
+        if ("generated_variables" in $$parsedSource) {
+            $$parsedSource["generated_variables"] = $$createField29_0($$parsedSource["generated_variables"]);
+        }
+        return new ExecutionResult(/** @type {Partial<ExecutionResult>} */($$parsedSource));
+    }
+}
+
+/**
+ * ExecutionTimings represents how long each phase of an execution took, in milliseconds
+ */
+export class ExecutionTimings {
+    /**
+     * Creates a new ExecutionTimings instance.
+     * @param {Partial<ExecutionTimings>} [$$source = {}] - The source object to create the ExecutionTimings.
+     */
+    constructor($$source = {}) {
+        if (!("dns_lookup" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["dns_lookup"] = 0;
+        }
+        if (!("tcp_connect" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["tcp_connect"] = 0;
+        }
+        if (!("tls_handshake" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["tls_handshake"] = 0;
+        }
+        if (!("first_byte" in $$source)) {
+            /**
+             * from the request being written to the first response byte
+             * @member
+             * @type {number}
+             */
+            this["first_byte"] = 0;
+        }
+        if (!("download" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["download"] = 0;
+        }
+        if (!("total" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["total"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExecutionTimings instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ExecutionTimings}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ExecutionTimings(/** @type {Partial<ExecutionTimings>} */($$parsedSource));
+    }
+}
+
+/**
+ * Folder represents a folder within a collection
+ */
+export class Folder {
+    /**
+     * Creates a new Folder instance.
+     * @param {Partial<Folder>} [$$source = {}] - The source object to create the Folder.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("collection_id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["collection_id"] = 0;
+        }
+        if (!("parent_folder_id" in $$source)) {
+            /**
+             * @member
+             * @type {number | null}
+             */
+            this["parent_folder_id"] = null;
+        }
+        if (!("created_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["created_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Folder instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Folder}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Folder(/** @type {Partial<Folder>} */($$parsedSource));
+    }
+}
+
+/**
+ * FormField represents a field of a form-data or x-www-form-urlencoded body
+ */
+export class FormField {
+    /**
+     * Creates a new FormField instance.
+     * @param {Partial<FormField>} [$$source = {}] - The source object to create the FormField.
+     */
+    constructor($$source = {}) {
+        if (!("key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["key"] = "";
+        }
+        if (!("value" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["value"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * text or file
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("file_path" in $$source)) {
+            /**
+             * file to upload, for file fields
+             * @member
+             * @type {string}
+             */
+            this["file_path"] = "";
+        }
+        if (!("content_type" in $$source)) {
+            /**
+             * of the file, guessed from its extension when empty
+             * @member
+             * @type {string}
+             */
+            this["content_type"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FormField instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {FormField}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new FormField(/** @type {Partial<FormField>} */($$parsedSource));
+    }
+}
+
+/**
+ * GRPCMessage represents a message sent or received during a gRPC call
+ */
+export class GRPCMessage {
+    /**
+     * Creates a new GRPCMessage instance.
+     * @param {Partial<GRPCMessage>} [$$source = {}] - The source object to create the GRPCMessage.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("direction" in $$source)) {
+            /**
+             * sent or received
+             * @member
+             * @type {string}
+             */
+            this["direction"] = "";
+        }
+        if (!("data" in $$source)) {
+            /**
+             * JSON
+             * @member
+             * @type {string}
+             */
+            this["data"] = "";
+        }
+        if (!("time" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["time"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GRPCMessage instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GRPCMessage}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GRPCMessage(/** @type {Partial<GRPCMessage>} */($$parsedSource));
+    }
+}
+
+/**
+ * GRPCMethod represents a method of a GRPCService
+ */
+export class GRPCMethod {
+    /**
+     * Creates a new GRPCMethod instance.
+     * @param {Partial<GRPCMethod>} [$$source = {}] - The source object to create the GRPCMethod.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("full_name" in $$source)) {
+            /**
+             * package.Service/Method, as used by GRPCSpec
+             * @member
+             * @type {string}
+             */
+            this["full_name"] = "";
+        }
+        if (!("client_streaming" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["client_streaming"] = false;
+        }
+        if (!("server_streaming" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["server_streaming"] = false;
+        }
+        if (!("input_type" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["input_type"] = "";
+        }
+        if (!("output_type" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["output_type"] = "";
+        }
+        if (!("input_template" in $$source)) {
+            /**
+             * JSON request message with every field set to its zero value
+             * @member
+             * @type {string}
+             */
+            this["input_template"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GRPCMethod instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GRPCMethod}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GRPCMethod(/** @type {Partial<GRPCMethod>} */($$parsedSource));
+    }
+}
+
+/**
+ * GRPCService represents a service and its methods, as described by reflection or .proto files
+ */
+export class GRPCService {
+    /**
+     * Creates a new GRPCService instance.
+     * @param {Partial<GRPCService>} [$$source = {}] - The source object to create the GRPCService.
+     */
+    constructor($$source = {}) {
+        if (!("name" in $$source)) {
+            /**
+             * fully qualified, e.g. helloworld.Greeter
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("methods" in $$source)) {
+            /**
+             * @member
+             * @type {GRPCMethod[]}
+             */
+            this["methods"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GRPCService instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GRPCService}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType23;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("methods" in $$parsedSource) {
+            $$parsedSource["methods"] = $$createField1_0($$parsedSource["methods"]);
+        }
+        return new GRPCService(/** @type {Partial<GRPCService>} */($$parsedSource));
+    }
+}
+
+/**
+ * GRPCSpec describes a gRPC call to be executed
+ */
+export class GRPCSpec {
+    /**
+     * Creates a new GRPCSpec instance.
+     * @param {Partial<GRPCSpec>} [$$source = {}] - The source object to create the GRPCSpec.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * generated when empty; pass one to be able to cancel the call
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("target" in $$source)) {
+            /**
+             * host:port, or a grpc:// or grpcs:// URL
+             * @member
+             * @type {string}
+             */
+            this["target"] = "";
+        }
+        if (!("method" in $$source)) {
+            /**
+             * full method name, package.Service/Method
+             * @member
+             * @type {string}
+             */
+            this["method"] = "";
+        }
+        if (!("messages" in $$source)) {
+            /**
+             * JSON request messages; unary and server streaming calls send only the first one
+             * @member
+             * @type {string[]}
+             */
+            this["messages"] = [];
+        }
+        if (!("metadata" in $$source)) {
+            /**
+             * @member
+             * @type {KeyValue[]}
+             */
+            this["metadata"] = [];
+        }
+        if (!("proto_files" in $$source)) {
+            /**
+             * ProtoFiles are the .proto files describing the services. When empty, they're discovered
+             * through server reflection.
+             * @member
+             * @type {string[]}
+             */
+            this["proto_files"] = [];
+        }
+        if (!("import_paths" in $$source)) {
+            /**
+             * directories imports of ProtoFiles are resolved from
+             * @member
+             * @type {string[]}
+             */
+            this["import_paths"] = [];
+        }
+        if (!("tls" in $$source)) {
+            /**
+             * implied by grpcs:// targets
+             * @member
+             * @type {boolean}
+             */
+            this["tls"] = false;
+        }
+        if (!("skip_tls_verify" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["skip_tls_verify"] = false;
+        }
+        if (!("timeout_ms" in $$source)) {
+            /**
+             * 0 uses the default of 30 seconds; streams are only bounded until the server answers
+             * @member
+             * @type {number}
+             */
+            this["timeout_ms"] = 0;
+        }
+        if (!("request_id" in $$source)) {
+            /**
+             * RequestID is the saved request the spec was built from, whose history streaming transcripts are saved to
+             * @member
+             * @type {number | null}
+             */
+            this["request_id"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GRPCSpec instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GRPCSpec}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType2;
+        const $$createField4_0 = $$createType25;
+        const $$createField5_0 = $$createType2;
+        const $$createField6_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("messages" in $$parsedSource) {
+            $$parsedSource["messages"] = $$createField3_0($$parsedSource["messages"]);
+        }
+        if ("metadata" in $$parsedSource) {
+            $$parsedSource["metadata"] = $$createField4_0($$parsedSource["metadata"]);
+        }
+        if ("proto_files" in $$parsedSource) {
+            $$parsedSource["proto_files"] = $$createField5_0($$parsedSource["proto_files"]);
+        }
+        if ("import_paths" in $$parsedSource) {
+            $$parsedSource["import_paths"] = $$createField6_0($$parsedSource["import_paths"]);
+        }
+        return new GRPCSpec(/** @type {Partial<GRPCSpec>} */($$parsedSource));
+    }
+}
+
+/**
+ * GRPCStatus represents the status a gRPC call ended with, which servers send in the trailers of a
+ * 200 response
+ */
+export class GRPCStatus {
+    /**
+     * Creates a new GRPCStatus instance.
+     * @param {Partial<GRPCStatus>} [$$source = {}] - The source object to create the GRPCStatus.
+     */
+    constructor($$source = {}) {
+        if (!("code" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["code"] = 0;
+        }
+        if (!("name" in $$source)) {
+            /**
+             * name of the code, e.g. NotFound
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GRPCStatus instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GRPCStatus}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GRPCStatus(/** @type {Partial<GRPCStatus>} */($$parsedSource));
+    }
+}
+
+/**
+ * GraphQLBody represents the operation sent by a request whose body type is graphql
+ */
+export class GraphQLBody {
+    /**
+     * Creates a new GraphQLBody instance.
+     * @param {Partial<GraphQLBody>} [$$source = {}] - The source object to create the GraphQLBody.
+     */
+    constructor($$source = {}) {
+        if (!("query" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["query"] = "";
+        }
+        if (!("variables" in $$source)) {
+            /**
+             * JSON object, may be empty
+             * @member
+             * @type {string}
+             */
+            this["variables"] = "";
+        }
+        if (!("operation_name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["operation_name"] = "";
+        }
+        if (!("connection_params" in $$source)) {
+            /**
+             * ConnectionParams is the JSON payload of the connection_init message of subscriptions,
+             * often carrying their auth
+             * @member
+             * @type {string}
+             */
+            this["connection_params"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GraphQLBody instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GraphQLBody}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GraphQLBody(/** @type {Partial<GraphQLBody>} */($$parsedSource));
+    }
+}
+
+/**
+ * GraphQLError represents an entry of the errors of a GraphQL response
+ */
+export class GraphQLError {
+    /**
+     * Creates a new GraphQLError instance.
+     * @param {Partial<GraphQLError>} [$$source = {}] - The source object to create the GraphQLError.
+     */
+    constructor($$source = {}) {
+        if (!("message" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["message"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {GraphQLLocation[] | undefined}
+             */
+            this["locations"] = undefined;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * field names and list indexes
+             * @member
+             * @type {any[] | undefined}
+             */
+            this["path"] = undefined;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {{ [_: string]: any } | undefined}
+             */
+            this["extensions"] = undefined;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GraphQLError instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GraphQLError}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType27;
+        const $$createField2_0 = $$createType28;
+        const $$createField3_0 = $$createType29;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("locations" in $$parsedSource) {
+            $$parsedSource["locations"] = $$createField1_0($$parsedSource["locations"]);
+        }
+        if ("path" in $$parsedSource) {
+            $$parsedSource["path"] = $$createField2_0($$parsedSource["path"]);
+        }
+        if ("extensions" in $$parsedSource) {
+            $$parsedSource["extensions"] = $$createField3_0($$parsedSource["extensions"]);
+        }
+        return new GraphQLError(/** @type {Partial<GraphQLError>} */($$parsedSource));
+    }
+}
+
+/**
+ * GraphQLLocation represents a position in a GraphQL document
+ */
+export class GraphQLLocation {
+    /**
+     * Creates a new GraphQLLocation instance.
+     * @param {Partial<GraphQLLocation>} [$$source = {}] - The source object to create the GraphQLLocation.
+     */
+    constructor($$source = {}) {
+        if (!("line" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["line"] = 0;
+        }
+        if (!("column" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["column"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GraphQLLocation instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GraphQLLocation}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GraphQLLocation(/** @type {Partial<GraphQLLocation>} */($$parsedSource));
+    }
+}
+
+/**
+ * GraphQLSchema represents the introspection result of a GraphQL endpoint, cached for completion
+ */
+export class GraphQLSchema {
+    /**
+     * Creates a new GraphQLSchema instance.
+     * @param {Partial<GraphQLSchema>} [$$source = {}] - The source object to create the GraphQLSchema.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("endpoint" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["endpoint"] = "";
+        }
+        if (!("schema" in $$source)) {
+            /**
+             * JSON of the data of the introspection query, holding __schema
+             * @member
+             * @type {string}
+             */
+            this["schema"] = "";
+        }
+        if (!("fetched_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["fetched_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GraphQLSchema instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GraphQLSchema}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GraphQLSchema(/** @type {Partial<GraphQLSchema>} */($$parsedSource));
+    }
+}
+
+/**
+ * GraphQLSubscriptionEvent represents a message received for a GraphQL subscription
+ */
+export class GraphQLSubscriptionEvent {
+    /**
+     * Creates a new GraphQLSubscriptionEvent instance.
+     * @param {Partial<GraphQLSubscriptionEvent>} [$$source = {}] - The source object to create the GraphQLSubscriptionEvent.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * next, error or complete
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("payload" in $$source)) {
+            /**
+             * JSON; the execution result for next, the errors for error
+             * @member
+             * @type {string}
+             */
+            this["payload"] = "";
+        }
+        if (!("received_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["received_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GraphQLSubscriptionEvent instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {GraphQLSubscriptionEvent}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GraphQLSubscriptionEvent(/** @type {Partial<GraphQLSubscriptionEvent>} */($$parsedSource));
+    }
+}
+
+/**
+ * JWTConfig represents the settings of a JWT signed locally and sent as a bearer token
+ */
+export class JWTConfig {
+    /**
+     * Creates a new JWTConfig instance.
+     * @param {Partial<JWTConfig>} [$$source = {}] - The source object to create the JWTConfig.
+     */
+    constructor($$source = {}) {
+        if (!("algorithm" in $$source)) {
+            /**
+             * HS256, HS384, HS512, RS256 or ES256
+             * @member
+             * @type {string}
+             */
+            this["algorithm"] = "";
+        }
+        if (!("secret" in $$source)) {
+            /**
+             * shared secret, for the HS algorithms
+             * @member
+             * @type {string}
+             */
+            this["secret"] = "";
+        }
+        if (!("private_key" in $$source)) {
+            /**
+             * PEM key, for RS256 and ES256
+             * @member
+             * @type {string}
+             */
+            this["private_key"] = "";
+        }
+        if (!("key_id" in $$source)) {
+            /**
+             * kid header, optional
+             * @member
+             * @type {string}
+             */
+            this["key_id"] = "";
+        }
+        if (!("claims" in $$source)) {
+            /**
+             * JSON object, may contain {{variables}}
+             * @member
+             * @type {string}
+             */
+            this["claims"] = "";
+        }
+        if (!("expires_in" in $$source)) {
+            /**
+             * seconds from now for exp; 0 keeps the exp of the claims, if any
+             * @member
+             * @type {number}
+             */
+            this["expires_in"] = 0;
+        }
+        if (!("issued_at_offset" in $$source)) {
+            /**
+             * seconds added to now for iat, e.g. -30 to allow for clock skew
+             * @member
+             * @type {number}
+             */
+            this["issued_at_offset"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new JWTConfig instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {JWTConfig}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new JWTConfig(/** @type {Partial<JWTConfig>} */($$parsedSource));
+    }
+}
+
+/**
+ * KeyValue represents a single name/value pair such as a header or query parameter
+ */
+export class KeyValue {
+    /**
+     * Creates a new KeyValue instance.
+     * @param {Partial<KeyValue>} [$$source = {}] - The source object to create the KeyValue.
+     */
+    constructor($$source = {}) {
+        if (!("key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["key"] = "";
+        }
+        if (!("value" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["value"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new KeyValue instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {KeyValue}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new KeyValue(/** @type {Partial<KeyValue>} */($$parsedSource));
+    }
+}
+
+/**
+ * OAuth1Config represents the settings of OAuth 1.0a request signing
+ */
+export class OAuth1Config {
+    /**
+     * Creates a new OAuth1Config instance.
+     * @param {Partial<OAuth1Config>} [$$source = {}] - The source object to create the OAuth1Config.
+     */
+    constructor($$source = {}) {
+        if (!("consumer_key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["consumer_key"] = "";
+        }
+        if (!("consumer_secret" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["consumer_secret"] = "";
+        }
+        if (!("token" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["token"] = "";
+        }
+        if (!("token_secret" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["token_secret"] = "";
+        }
+        if (!("signature_method" in $$source)) {
+            /**
+             * HMAC-SHA1, RSA-SHA1 or PLAINTEXT
+             * @member
+             * @type {string}
+             */
+            this["signature_method"] = "";
+        }
+        if (!("private_key" in $$source)) {
+            /**
+             * PEM RSA key, for RSA-SHA1
+             * @member
+             * @type {string}
+             */
+            this["private_key"] = "";
+        }
+        if (!("realm" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["realm"] = "";
+        }
+        if (!("add_to" in $$source)) {
+            /**
+             * header, query or body
+             * @member
+             * @type {string}
+             */
+            this["add_to"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OAuth1Config instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {OAuth1Config}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new OAuth1Config(/** @type {Partial<OAuth1Config>} */($$parsedSource));
+    }
+}
+
+/**
+ * OAuth2Config represents the settings of OAuth 2.0 auth
+ */
+export class OAuth2Config {
+    /**
+     * Creates a new OAuth2Config instance.
+     * @param {Partial<OAuth2Config>} [$$source = {}] - The source object to create the OAuth2Config.
+     */
+    constructor($$source = {}) {
+        if (!("grant_type" in $$source)) {
+            /**
+             * client_credentials, password or authorization_code
+             * @member
+             * @type {string}
+             */
+            this["grant_type"] = "";
+        }
+        if (!("token_url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["token_url"] = "";
+        }
+        if (!("auth_url" in $$source)) {
+            /**
+             * authorization endpoint, for the authorization_code grant
+             * @member
+             * @type {string}
+             */
+            this["auth_url"] = "";
+        }
+        if (!("client_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["client_id"] = "";
+        }
+        if (!("client_secret" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["client_secret"] = "";
+        }
+        if (!("scope" in $$source)) {
+            /**
+             * space separated
+             * @member
+             * @type {string}
+             */
+            this["scope"] = "";
+        }
+        if (!("username" in $$source)) {
+            /**
+             * resource owner, for the password grant
+             * @member
+             * @type {string}
+             */
+            this["username"] = "";
+        }
+        if (!("password" in $$source)) {
+            /**
+             * resource owner, for the password grant
+             * @member
+             * @type {string}
+             */
+            this["password"] = "";
+        }
+        if (!("redirect_port" in $$source)) {
+            /**
+             * port of the loopback redirect listener, 0 picks a free one
+             * @member
+             * @type {number}
+             */
+            this["redirect_port"] = 0;
+        }
+        if (!("client_auth" in $$source)) {
+            /**
+             * header or body; empty tries both
+             * @member
+             * @type {string}
+             */
+            this["client_auth"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OAuth2Config instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {OAuth2Config}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new OAuth2Config(/** @type {Partial<OAuth2Config>} */($$parsedSource));
+    }
+}
+
+/**
+ * Proxy represents the proxy requests go through, either globally or for one environment
+ */
+export class Proxy {
+    /**
+     * Creates a new Proxy instance.
+     * @param {Partial<Proxy>} [$$source = {}] - The source object to create the Proxy.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("environment_id" in $$source)) {
+            /**
+             * nil for the global proxy
+             * @member
+             * @type {number | null}
+             */
+            this["environment_id"] = null;
+        }
+        if (!("type" in $$source)) {
+            /**
+             * http, https, socks5 or system
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("host" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["host"] = "";
+        }
+        if (!("port" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["port"] = 0;
+        }
+        if (!("username" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["username"] = "";
+        }
+        if (!("password" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["password"] = "";
+        }
+        if (!("bypass" in $$source)) {
+            /**
+             * comma separated hosts, domains and CIDRs, like NO_PROXY
+             * @member
+             * @type {string}
+             */
+            this["bypass"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["enabled"] = false;
+        }
+        if (!("created_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["created_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Proxy instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Proxy}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Proxy(/** @type {Partial<Proxy>} */($$parsedSource));
+    }
+}
+
+/**
+ * Request represents an API request
+ */
+export class Request {
+    /**
+     * Creates a new Request instance.
+     * @param {Partial<Request>} [$$source = {}] - The source object to create the Request.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("name" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["name"] = "";
+        }
+        if (!("type" in $$source)) {
+            /**
+             * http, websocket or grpc
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("method" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["method"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * JSON string
+             * @member
+             * @type {string}
+             */
+            this["headers"] = "";
+        }
+        if (!("body" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["body"] = "";
+        }
+        if (!("body_type" in $$source)) {
+            /**
+             * see RequestSpec.BodyType; empty for requests saved before it was kept
+             * @member
+             * @type {string}
+             */
+            this["body_type"] = "";
+        }
+        if (!("auth" in $$source)) {
+            /**
+             * @member
+             * @type {RequestAuth | null}
+             */
+            this["auth"] = null;
+        }
+        if (!("settings" in $$source)) {
+            /**
+             * nil uses the defaults
+             * @member
+             * @type {RequestSettings | null}
+             */
+            this["settings"] = null;
+        }
+        if (!("collection_id" in $$source)) {
+            /**
+             * @member
+             * @type {number | null}
+             */
+            this["collection_id"] = null;
+        }
+        if (!("folder_id" in $$source)) {
+            /**
+             * @member
+             * @type {number | null}
+             */
+            this["folder_id"] = null;
+        }
+        if (!("created_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["created_at"] = null;
+        }
+        if (!("updated_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["updated_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Request instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {Request}
+     */
+    static createFrom($$source = {}) {
+        const $$createField8_0 = $$createType31;
+        const $$createField9_0 = $$createType33;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("auth" in $$parsedSource) {
+            $$parsedSource["auth"] = $$createField8_0($$parsedSource["auth"]);
+        }
+        if ("settings" in $$parsedSource) {
+            $$parsedSource["settings"] = $$createField9_0($$parsedSource["settings"]);
+        }
+        return new Request(/** @type {Partial<Request>} */($$parsedSource));
+    }
+}
+
+/**
+ * RequestAuth represents the authentication settings of a request
+ */
+export class RequestAuth {
+    /**
+     * Creates a new RequestAuth instance.
+     * @param {Partial<RequestAuth>} [$$source = {}] - The source object to create the RequestAuth.
+     */
+    constructor($$source = {}) {
+        if (!("type" in $$source)) {
+            /**
+             * none, bearer, basic, digest, api-key, oauth1, oauth2, aws-sigv4 or jwt
+             * @member
+             * @type {string}
+             */
+            this["type"] = "";
+        }
+        if (!("token" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["token"] = "";
+        }
+        if (!("username" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["username"] = "";
+        }
+        if (!("password" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["password"] = "";
+        }
+        if (!("key" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["key"] = "";
+        }
+        if (!("value" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["value"] = "";
+        }
+        if (!("add_to" in $$source)) {
+            /**
+             * header or query
+             * @member
+             * @type {string}
+             */
+            this["add_to"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {OAuth1Config | null | undefined}
+             */
+            this["oauth1"] = undefined;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {OAuth2Config | null | undefined}
+             */
+            this["oauth2"] = undefined;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {AWSConfig | null | undefined}
+             */
+            this["aws"] = undefined;
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * @member
+             * @type {JWTConfig | null | undefined}
+             */
+            this["jwt"] = undefined;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RequestAuth instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RequestAuth}
+     */
+    static createFrom($$source = {}) {
+        const $$createField7_0 = $$createType35;
+        const $$createField8_0 = $$createType37;
+        const $$createField9_0 = $$createType39;
+        const $$createField10_0 = $$createType41;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("oauth1" in $$parsedSource) {
+            $$parsedSource["oauth1"] = $$createField7_0($$parsedSource["oauth1"]);
+        }
+        if ("oauth2" in $$parsedSource) {
+            $$parsedSource["oauth2"] = $$createField8_0($$parsedSource["oauth2"]);
+        }
+        if ("aws" in $$parsedSource) {
+            $$parsedSource["aws"] = $$createField9_0($$parsedSource["aws"]);
+        }
+        if ("jwt" in $$parsedSource) {
+            $$parsedSource["jwt"] = $$createField10_0($$parsedSource["jwt"]);
+        }
+        return new RequestAuth(/** @type {Partial<RequestAuth>} */($$parsedSource));
+    }
+}
+
+/**
+ * RequestHistory represents a request execution history
+ */
+export class RequestHistory {
+    /**
+     * Creates a new RequestHistory instance.
+     * @param {Partial<RequestHistory>} [$$source = {}] - The source object to create the RequestHistory.
+     */
+    constructor($$source = {}) {
+        if (!("id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["id"] = 0;
+        }
+        if (!("request_id" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["request_id"] = 0;
+        }
+        if (!("response_status" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["response_status"] = 0;
+        }
+        if (!("response_time" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["response_time"] = 0;
+        }
+        if (!("response_body" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["response_body"] = "";
+        }
+        if (!("response_headers" in $$source)) {
+            /**
+             * JSON string
+             * @member
+             * @type {string}
+             */
+            this["response_headers"] = "";
+        }
+        if (!("timings" in $$source)) {
+            /**
+             * JSON string
+             * @member
+             * @type {string}
+             */
+            this["timings"] = "";
+        }
+        if (!("transcript" in $$source)) {
+            /**
+             * JSON string, messages of a streamed session
+             * @member
+             * @type {string}
+             */
+            this["transcript"] = "";
+        }
+        if (!("executed_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["executed_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RequestHistory instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RequestHistory}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RequestHistory(/** @type {Partial<RequestHistory>} */($$parsedSource));
+    }
+}
+
+/**
+ * RequestSettings represents per-request execution options
+ */
+export class RequestSettings {
+    /**
+     * Creates a new RequestSettings instance.
+     * @param {Partial<RequestSettings>} [$$source = {}] - The source object to create the RequestSettings.
+     */
+    constructor($$source = {}) {
+        if (!("timeout_ms" in $$source)) {
+            /**
+             * 0 uses the default timeout of 30 seconds
+             * @member
+             * @type {number}
+             */
+            this["timeout_ms"] = 0;
+        }
+        if (!("disable_redirects" in $$source)) {
+            /**
+             * return 3xx responses instead of following them
+             * @member
+             * @type {boolean}
+             */
+            this["disable_redirects"] = false;
+        }
+        if (!("max_redirects" in $$source)) {
+            /**
+             * 0 uses the default of 10 hops
+             * @member
+             * @type {number}
+             */
+            this["max_redirects"] = 0;
+        }
+        if (!("rewrite_method_on_307" in $$source)) {
+            /**
+             * follow 307/308 with a GET without body
+             * @member
+             * @type {boolean}
+             */
+            this["rewrite_method_on_307"] = false;
+        }
+        if (!("skip_tls_verify" in $$source)) {
+            /**
+             * accept any server certificate, e.g. self-signed ones
+             * @member
+             * @type {boolean}
+             */
+            this["skip_tls_verify"] = false;
+        }
+        if (!("unix_socket" in $$source)) {
+            /**
+             * UnixSocket is the path of a Unix domain socket the request is sent through instead of
+             * connecting to the host of its URL, e.g. /var/run/docker.sock
+             * @member
+             * @type {string}
+             */
+            this["unix_socket"] = "";
+        }
+        if (!("max_response_bytes" in $$source)) {
+            /**
+             * 0 keeps up to 50 MiB of the body in memory
+             * @member
+             * @type {number}
+             */
+            this["max_response_bytes"] = 0;
+        }
+        if (!("save_to_file" in $$source)) {
+            /**
+             * stream the body to this path instead of returning it
+             * @member
+             * @type {string}
+             */
+            this["save_to_file"] = "";
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * Retry overrides the retry policy of the collection of the request
+             * @member
+             * @type {RetryPolicy | null | undefined}
+             */
+            this["retry"] = undefined;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RequestSettings instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RequestSettings}
+     */
+    static createFrom($$source = {}) {
+        const $$createField8_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("retry" in $$parsedSource) {
+            $$parsedSource["retry"] = $$createField8_0($$parsedSource["retry"]);
+        }
+        return new RequestSettings(/** @type {Partial<RequestSettings>} */($$parsedSource));
+    }
+}
+
+/**
+ * RequestSpec describes an HTTP request to be executed
+ */
+export class RequestSpec {
+    /**
+     * Creates a new RequestSpec instance.
+     * @param {Partial<RequestSpec>} [$$source = {}] - The source object to create the RequestSpec.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * generated when empty; pass one to be able to cancel the execution
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("method" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["method"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * @member
+             * @type {KeyValue[]}
+             */
+            this["headers"] = [];
+        }
+        if (!("query_params" in $$source)) {
+            /**
+             * @member
+             * @type {KeyValue[]}
+             */
+            this["query_params"] = [];
+        }
+        if (!("body_type" in $$source)) {
+            /**
+             * none, json, xml, raw, form-data, x-www-form-urlencoded or graphql
+             * @member
+             * @type {string}
+             */
+            this["body_type"] = "";
+        }
+        if (!("body" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["body"] = "";
+        }
+        if (!("form_fields" in $$source)) {
+            /**
+             * body of form-data and x-www-form-urlencoded requests
+             * @member
+             * @type {FormField[]}
+             */
+            this["form_fields"] = [];
+        }
+        if (/** @type {any} */(false)) {
+            /**
+             * body of graphql requests
+             * @member
+             * @type {GraphQLBody | null | undefined}
+             */
+            this["graphql"] = undefined;
+        }
+        if (!("auth" in $$source)) {
+            /**
+             * @member
+             * @type {RequestAuth | null}
+             */
+            this["auth"] = null;
+        }
+        if (!("settings" in $$source)) {
+            /**
+             * @member
+             * @type {RequestSettings}
+             */
+            this["settings"] = (new RequestSettings());
+        }
+        if (!("collection_id" in $$source)) {
+            /**
+             * CollectionID is the collection the request belongs to, whose retry policy applies by default
+             * @member
+             * @type {number | null}
+             */
+            this["collection_id"] = null;
+        }
+        if (!("request_id" in $$source)) {
+            /**
+             * RequestID is the saved request the spec was built from, whose history stream transcripts are saved to
+             * @member
+             * @type {number | null}
+             */
+            this["request_id"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RequestSpec instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RequestSpec}
+     */
+    static createFrom($$source = {}) {
+        const $$createField3_0 = $$createType25;
+        const $$createField4_0 = $$createType25;
+        const $$createField7_0 = $$createType43;
+        const $$createField8_0 = $$createType45;
+        const $$createField9_0 = $$createType31;
+        const $$createField10_0 = $$createType32;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField3_0($$parsedSource["headers"]);
+        }
+        if ("query_params" in $$parsedSource) {
+            $$parsedSource["query_params"] = $$createField4_0($$parsedSource["query_params"]);
+        }
+        if ("form_fields" in $$parsedSource) {
+            $$parsedSource["form_fields"] = $$createField7_0($$parsedSource["form_fields"]);
+        }
+        if ("graphql" in $$parsedSource) {
+            $$parsedSource["graphql"] = $$createField8_0($$parsedSource["graphql"]);
+        }
+        if ("auth" in $$parsedSource) {
+            $$parsedSource["auth"] = $$createField9_0($$parsedSource["auth"]);
+        }
+        if ("settings" in $$parsedSource) {
+            $$parsedSource["settings"] = $$createField10_0($$parsedSource["settings"]);
+        }
+        return new RequestSpec(/** @type {Partial<RequestSpec>} */($$parsedSource));
+    }
+}
+
+/**
+ * RetryPolicy represents when and how often a failed execution is attempted again.
+ * The delay doubles after every attempt, with jitter, up to MaxDelayMs, unless the response asks
+ * for one with Retry-After.
+ */
+export class RetryPolicy {
+    /**
+     * Creates a new RetryPolicy instance.
+     * @param {Partial<RetryPolicy>} [$$source = {}] - The source object to create the RetryPolicy.
+     */
+    constructor($$source = {}) {
+        if (!("max_attempts" in $$source)) {
+            /**
+             * including the first one; 0 or 1 never retries
+             * @member
+             * @type {number}
+             */
+            this["max_attempts"] = 0;
+        }
+        if (!("status_codes" in $$source)) {
+            /**
+             * responses to retry, e.g. 429, 502, 503 and 504
+             * @member
+             * @type {number[]}
+             */
+            this["status_codes"] = [];
+        }
+        if (!("network_errors" in $$source)) {
+            /**
+             * connection_refused, connection_reset, dns, timeout or eof
+             * @member
+             * @type {string[]}
+             */
+            this["network_errors"] = [];
+        }
+        if (!("initial_delay_ms" in $$source)) {
+            /**
+             * 0 uses 500 ms
+             * @member
+             * @type {number}
+             */
+            this["initial_delay_ms"] = 0;
+        }
+        if (!("max_delay_ms" in $$source)) {
+            /**
+             * 0 uses 30 seconds; also caps Retry-After
+             * @member
+             * @type {number}
+             */
+            this["max_delay_ms"] = 0;
+        }
+        if (!("ignore_retry_after" in $$source)) {
+            /**
+             * back off even when the response sends Retry-After
+             * @member
+             * @type {boolean}
+             */
+            this["ignore_retry_after"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RetryPolicy instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {RetryPolicy}
+     */
+    static createFrom($$source = {}) {
+        const $$createField1_0 = $$createType46;
+        const $$createField2_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("status_codes" in $$parsedSource) {
+            $$parsedSource["status_codes"] = $$createField1_0($$parsedSource["status_codes"]);
+        }
+        if ("network_errors" in $$parsedSource) {
+            $$parsedSource["network_errors"] = $$createField2_0($$parsedSource["network_errors"]);
+        }
+        return new RetryPolicy(/** @type {Partial<RetryPolicy>} */($$parsedSource));
+    }
+}
+
+/**
+ * SOAPFault represents the fault reported by a SOAP 1.1 or 1.2 response
+ */
+export class SOAPFault {
+    /**
+     * Creates a new SOAPFault instance.
+     * @param {Partial<SOAPFault>} [$$source = {}] - The source object to create the SOAPFault.
+     */
+    constructor($$source = {}) {
+        if (!("version" in $$source)) {
+            /**
+             * 1.1 or 1.2
+             * @member
+             * @type {string}
+             */
+            this["version"] = "";
+        }
+        if (!("code" in $$source)) {
+            /**
+             * faultcode, or the value of the 1.2 Code, e.g. soap:Server
+             * @member
+             * @type {string}
+             */
+            this["code"] = "";
+        }
+        if (!("subcodes" in $$source)) {
+            /**
+             * SOAP 1.2 subcode values, outermost first
+             * @member
+             * @type {string[]}
+             */
+            this["subcodes"] = [];
+        }
+        if (!("reason" in $$source)) {
+            /**
+             * faultstring, or the first text of the 1.2 Reason
+             * @member
+             * @type {string}
+             */
+            this["reason"] = "";
+        }
+        if (!("actor" in $$source)) {
+            /**
+             * faultactor, or the 1.2 Role
+             * @member
+             * @type {string}
+             */
+            this["actor"] = "";
+        }
+        if (!("node" in $$source)) {
+            /**
+             * SOAP 1.2 only
+             * @member
+             * @type {string}
+             */
+            this["node"] = "";
+        }
+        if (!("detail" in $$source)) {
+            /**
+             * XML content of the detail element
+             * @member
+             * @type {string}
+             */
+            this["detail"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SOAPFault instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {SOAPFault}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("subcodes" in $$parsedSource) {
+            $$parsedSource["subcodes"] = $$createField2_0($$parsedSource["subcodes"]);
+        }
+        return new SOAPFault(/** @type {Partial<SOAPFault>} */($$parsedSource));
+    }
+}
+
+/**
+ * ServerSentEvent represents an event received from a text/event-stream response
+ */
+export class ServerSentEvent {
+    /**
+     * Creates a new ServerSentEvent instance.
+     * @param {Partial<ServerSentEvent>} [$$source = {}] - The source object to create the ServerSentEvent.
+     */
+    constructor($$source = {}) {
+        if (!("execution_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["execution_id"] = "";
+        }
+        if (!("event" in $$source)) {
+            /**
+             * message unless the server names it
+             * @member
+             * @type {string}
+             */
+            this["event"] = "";
+        }
+        if (!("id" in $$source)) {
+            /**
+             * last event ID, which may have been set by a previous event
+             * @member
+             * @type {string}
+             */
+            this["id"] = "";
+        }
+        if (!("data" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["data"] = "";
+        }
+        if (!("retry" in $$source)) {
+            /**
+             * reconnection time in ms sent along with this event, 0 if none
+             * @member
+             * @type {number}
+             */
+            this["retry"] = 0;
+        }
+        if (!("received_at" in $$source)) {
+            /**
+             * @member
+             * @type {time$0.Time}
+             */
+            this["received_at"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ServerSentEvent instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {ServerSentEvent}
+     */
+    static createFrom($$source = {}) {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ServerSentEvent(/** @type {Partial<ServerSentEvent>} */($$parsedSource));
+    }
+}
+
+/**
+ * WebSocketSession represents an open WebSocket session
+ */
+export class WebSocketSession {
+    /**
+     * Creates a new WebSocketSession instance.
+     * @param {Partial<WebSocketSession>} [$$source = {}] - The source object to create the WebSocketSession.
+     */
+    constructor($$source = {}) {
+        if (!("session_id" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["session_id"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("subprotocol" in $$source)) {
+            /**
+             * chosen by the server, empty if none
+             * @member
+             * @type {string}
+             */
+            this["subprotocol"] = "";
+        }
+        if (!("status" in $$source)) {
+            /**
+             * @member
+             * @type {number}
+             */
+            this["status"] = 0;
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * of the handshake response
+             * @member
+             * @type {{ [_: string]: string[] }}
+             */
+            this["headers"] = {};
+        }
+        if (!("unresolved_variables" in $$source)) {
+            /**
+             * UnresolvedVariables lists the {{placeholders}} that had no matching variable
+             * @member
+             * @type {string[]}
+             */
+            this["unresolved_variables"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new WebSocketSession instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {WebSocketSession}
+     */
+    static createFrom($$source = {}) {
+        const $$createField4_0 = $$createType3;
+        const $$createField5_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField4_0($$parsedSource["headers"]);
+        }
+        if ("unresolved_variables" in $$parsedSource) {
+            $$parsedSource["unresolved_variables"] = $$createField5_0($$parsedSource["unresolved_variables"]);
+        }
+        return new WebSocketSession(/** @type {Partial<WebSocketSession>} */($$parsedSource));
+    }
+}
+
+/**
+ * WebSocketSpec describes a WebSocket session to open
+ */
+export class WebSocketSpec {
+    /**
+     * Creates a new WebSocketSpec instance.
+     * @param {Partial<WebSocketSpec>} [$$source = {}] - The source object to create the WebSocketSpec.
+     */
+    constructor($$source = {}) {
+        if (!("session_id" in $$source)) {
+            /**
+             * generated when empty
+             * @member
+             * @type {string}
+             */
+            this["session_id"] = "";
+        }
+        if (!("url" in $$source)) {
+            /**
+             * ws:// or wss://
+             * @member
+             * @type {string}
+             */
+            this["url"] = "";
+        }
+        if (!("headers" in $$source)) {
+            /**
+             * @member
+             * @type {KeyValue[]}
+             */
+            this["headers"] = [];
+        }
+        if (!("subprotocols" in $$source)) {
+            /**
+             * offered in order of preference
+             * @member
+             * @type {string[]}
+             */
+            this["subprotocols"] = [];
+        }
+        if (!("timeout_ms" in $$source)) {
+            /**
+             * of the handshake; 0 uses the default of 30 seconds
+             * @member
+             * @type {number}
+             */
+            this["timeout_ms"] = 0;
+        }
+        if (!("skip_tls_verify" in $$source)) {
+            /**
+             * @member
+             * @type {boolean}
+             */
+            this["skip_tls_verify"] = false;
+        }
+        if (!("request_id" in $$source)) {
+            /**
+             * saved request whose history the message log is saved to
+             * @member
+             * @type {number | null}
+             */
+            this["request_id"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new WebSocketSpec instance from a string or object.
+     * @param {any} [$$source = {}]
+     * @returns {WebSocketSpec}
+     */
+    static createFrom($$source = {}) {
+        const $$createField2_0 = $$createType25;
+        const $$createField3_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("headers" in $$parsedSource) {
+            $$parsedSource["headers"] = $$createField2_0($$parsedSource["headers"]);
+        }
+        if ("subprotocols" in $$parsedSource) {
+            $$parsedSource["subprotocols"] = $$createField3_0($$parsedSource["subprotocols"]);
+        }
+        return new WebSocketSpec(/** @type {Partial<WebSocketSpec>} */($$parsedSource));
+    }
+}
+
+// Private type creation functions
+const $$createType0 = RetryPolicy.createFrom;
+const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = $Create.Array($Create.Any);
+const $$createType3 = $Create.Map($Create.Any, $$createType2);
+const $$createType4 = ExecutionTimings.createFrom;
+const $$createType5 = ExchangeRecord.createFrom;
+const $$createType6 = $Create.Array($$createType5);
+const $$createType7 = AttemptRecord.createFrom;
+const $$createType8 = $Create.Array($$createType7);
+const $$createType9 = ServerSentEvent.createFrom;
+const $$createType10 = $Create.Array($$createType9);
+const $$createType11 = GRPCMessage.createFrom;
+const $$createType12 = $Create.Array($$createType11);
+const $$createType13 = GRPCStatus.createFrom;
+const $$createType14 = $Create.Nullable($$createType13);
+const $$createType15 = GraphQLError.createFrom;
+const $$createType16 = $Create.Array($$createType15);
+const $$createType17 = GraphQLSubscriptionEvent.createFrom;
+const $$createType18 = $Create.Array($$createType17);
+const $$createType19 = SOAPFault.createFrom;
+const $$createType20 = $Create.Nullable($$createType19);
+const $$createType21 = $Create.Map($Create.Any, $Create.Any);
+const $$createType22 = GRPCMethod.createFrom;
+const $$createType23 = $Create.Array($$createType22);
+const $$createType24 = KeyValue.createFrom;
+const $$createType25 = $Create.Array($$createType24);
+const $$createType26 = GraphQLLocation.createFrom;
+const $$createType27 = $Create.Array($$createType26);
+const $$createType28 = $Create.Array($Create.Any);
+const $$createType29 = $Create.Map($Create.Any, $Create.Any);
+const $$createType30 = RequestAuth.createFrom;
+const $$createType31 = $Create.Nullable($$createType30);
+const $$createType32 = RequestSettings.createFrom;
+const $$createType33 = $Create.Nullable($$createType32);
+const $$createType34 = OAuth1Config.createFrom;
+const $$createType35 = $Create.Nullable($$createType34);
+const $$createType36 = OAuth2Config.createFrom;
+const $$createType37 = $Create.Nullable($$createType36);
+const $$createType38 = AWSConfig.createFrom;
+const $$createType39 = $Create.Nullable($$createType38);
+const $$createType40 = JWTConfig.createFrom;
+const $$createType41 = $Create.Nullable($$createType40);
+const $$createType42 = FormField.createFrom;
+const $$createType43 = $Create.Array($$createType42);
+const $$createType44 = GraphQLBody.createFrom;
+const $$createType45 = $Create.Nullable($$createType44);
+const $$createType46 = $Create.Array($Create.Any);
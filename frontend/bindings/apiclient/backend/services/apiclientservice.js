@@ -14,6 +14,44 @@ import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Cr
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as models$0 from "../models/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../../../time/models.js";
+
+/**
+ * CancelRequest stops the in-flight execution with the given ID
+ * @param {string} executionID
+ * @returns {$CancellablePromise<void>}
+ */
+export function CancelRequest(executionID) {
+    return $Call.ByID(2385749477, executionID);
+}
+
+/**
+ * ClearCookies removes every cookie of the environment, or the cookies kept without an environment when nil
+ * @param {number | null} environmentID
+ * @returns {$CancellablePromise<void>}
+ */
+export function ClearCookies(environmentID) {
+    return $Call.ByID(2117484718, environmentID);
+}
+
+/**
+ * ClearGraphQLSchema forgets the schema cached for endpoint
+ * @param {string} endpoint
+ * @returns {$CancellablePromise<void>}
+ */
+export function ClearGraphQLSchema(endpoint) {
+    return $Call.ByID(3875207499, endpoint);
+}
+
+/**
+ * ClearOAuth2Tokens forgets every cached OAuth 2.0 token, so the next requests authorize again
+ * @returns {$CancellablePromise<void>}
+ */
+export function ClearOAuth2Tokens() {
+    return $Call.ByID(380047166);
+}
 
 /**
  * @returns {$CancellablePromise<void>}
@@ -22,6 +60,48 @@ export function ClearRequestHistory() {
     return $Call.ByID(4112628272);
 }
 
+/**
+ * CloseWebSocket starts the closing handshake of a session with code, 1000 when 0. The session ends
+ * once the server answers, or after a few seconds when it doesn't.
+ * @param {string} sessionID
+ * @param {number} code
+ * @param {string} reason
+ * @returns {$CancellablePromise<void>}
+ */
+export function CloseWebSocket(sessionID, code, reason) {
+    return $Call.ByID(3569678885, sessionID, code, reason);
+}
+
+/**
+ * ConnectWebSocket opens a WebSocket session. Messages are then exchanged through SendWebSocketMessage
+ * and the EventWebSocketMessage events, until CloseWebSocket or the server ends the session.
+ * @param {models$0.WebSocketSpec} spec
+ * @returns {$CancellablePromise<models$0.WebSocketSession | null>}
+ */
+export function ConnectWebSocket(spec) {
+    return $Call.ByID(2336643883, spec).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType1($result);
+    }));
+}
+
+/**
+ * Certificate methods
+ * @param {string} name
+ * @param {string} hostPattern
+ * @param {string} format
+ * @param {string} cert
+ * @param {string} key
+ * @param {string} passphrase
+ * @param {string} ca
+ * @param {boolean} enabled
+ * @returns {$CancellablePromise<models$0.Certificate | null>}
+ */
+export function CreateCertificate(name, hostPattern, format, cert, key, passphrase, ca, enabled) {
+    return $Call.ByID(2301324877, name, hostPattern, format, cert, key, passphrase, ca, enabled).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType3($result);
+    }));
+}
+
 /**
  * Collection methods
  * @param {string} name
@@ -30,7 +110,26 @@ export function ClearRequestHistory() {
  */
 export function CreateCollection(name, description) {
     return $Call.ByID(750527762, name, description).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType1($result);
+        return $$createType5($result);
+    }));
+}
+
+/**
+ * Cookie methods
+ * @param {number | null} environmentID
+ * @param {string} name
+ * @param {string} value
+ * @param {string} domain
+ * @param {string} path
+ * @param {time$0.Time | null} expires
+ * @param {boolean} secure
+ * @param {boolean} httpOnly
+ * @param {boolean} hostOnly
+ * @returns {$CancellablePromise<models$0.Cookie | null>}
+ */
+export function CreateCookie(environmentID, name, value, domain, path, expires, secure, httpOnly, hostOnly) {
+    return $Call.ByID(3372634992, environmentID, name, value, domain, path, expires, secure, httpOnly, hostOnly).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType7($result);
     }));
 }
 
@@ -42,7 +141,7 @@ export function CreateCollection(name, description) {
  */
 export function CreateEnvironment(name, variables) {
     return $Call.ByID(2690630719, name, variables).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType3($result);
+        return $$createType9($result);
     }));
 }
 
@@ -55,24 +154,46 @@ export function CreateEnvironment(name, variables) {
  */
 export function CreateFolder(name, collectionID, parentFolderID) {
     return $Call.ByID(31504138, name, collectionID, parentFolderID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType5($result);
+        return $$createType11($result);
+    }));
+}
+
+/**
+ * Proxy methods
+ * @param {number | null} environmentID
+ * @param {string} proxyType
+ * @param {string} host
+ * @param {number} port
+ * @param {string} username
+ * @param {string} password
+ * @param {string} bypass
+ * @param {boolean} enabled
+ * @returns {$CancellablePromise<models$0.Proxy | null>}
+ */
+export function CreateProxy(environmentID, proxyType, host, port, username, password, bypass, enabled) {
+    return $Call.ByID(1752387774, environmentID, proxyType, host, port, username, password, bypass, enabled).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType13($result);
     }));
 }
 
 /**
  * Request methods
  * @param {string} name
+ * @param {string} requestType
  * @param {string} method
  * @param {string} url
  * @param {string} headers
  * @param {string} body
+ * @param {string} bodyType
+ * @param {models$0.RequestAuth | null} auth
+ * @param {models$0.RequestSettings | null} settings
  * @param {number | null} collectionID
  * @param {number | null} folderID
  * @returns {$CancellablePromise<models$0.Request | null>}
  */
-export function CreateRequest(name, method, url, headers, body, collectionID, folderID) {
-    return $Call.ByID(616470831, name, method, url, headers, body, collectionID, folderID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType7($result);
+export function CreateRequest(name, requestType, method, url, headers, body, bodyType, auth, settings, collectionID, folderID) {
+    return $Call.ByID(616470831, name, requestType, method, url, headers, body, bodyType, auth, settings, collectionID, folderID).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType15($result);
     }));
 }
 
@@ -83,14 +204,23 @@ export function CreateRequest(name, method, url, headers, body, collectionID, fo
  * @param {number} responseTime
  * @param {string} responseBody
  * @param {string} responseHeaders
+ * @param {string} timings
  * @returns {$CancellablePromise<models$0.RequestHistory | null>}
  */
-export function CreateRequestHistory(requestID, responseStatus, responseTime, responseBody, responseHeaders) {
-    return $Call.ByID(593508241, requestID, responseStatus, responseTime, responseBody, responseHeaders).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType9($result);
+export function CreateRequestHistory(requestID, responseStatus, responseTime, responseBody, responseHeaders, timings) {
+    return $Call.ByID(593508241, requestID, responseStatus, responseTime, responseBody, responseHeaders, timings).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType17($result);
     }));
 }
 
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<void>}
+ */
+export function DeleteCertificate(id) {
+    return $Call.ByID(4112662764, id);
+}
+
 /**
  * @param {number} id
  * @returns {$CancellablePromise<void>}
@@ -99,6 +229,14 @@ export function DeleteCollection(id) {
     return $Call.ByID(3996036597, id);
 }
 
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<void>}
+ */
+export function DeleteCookie(id) {
+    return $Call.ByID(291969555, id);
+}
+
 /**
  * @param {number} id
  * @returns {$CancellablePromise<void>}
@@ -115,6 +253,14 @@ export function DeleteFolder(id) {
     return $Call.ByID(2210387745, id);
 }
 
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<void>}
+ */
+export function DeleteProxy(id) {
+    return $Call.ByID(2889718999, id);
+}
+
 /**
  * @param {number} id
  * @returns {$CancellablePromise<void>}
@@ -132,16 +278,38 @@ export function DeleteRequestHistory(id) {
 }
 
 /**
- * ExecuteRequest sends an HTTP request and returns the response
- * @param {string} method
- * @param {string} url
- * @param {string} headers
- * @param {string} body
- * @returns {$CancellablePromise<{ [_: string]: any }>}
+ * ExecuteGRPC calls a gRPC method with the messages of spec, whether it's unary or streams in
+ * either direction. Streamed messages are published as EventGRPCMessage events as they're exchanged.
+ * @param {models$0.GRPCSpec} spec
+ * @returns {$CancellablePromise<models$0.ExecutionResult | null>}
  */
-export function ExecuteRequest(method, url, headers, body) {
-    return $Call.ByID(4279139746, method, url, headers, body).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType10($result);
+export function ExecuteGRPC(spec) {
+    return $Call.ByID(2231647925, spec).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType19($result);
+    }));
+}
+
+/**
+ * ExecuteGraphQLSubscription runs the GraphQL subscription of spec over WebSocket, with the graphql-ws
+ * protocol or its subscriptions-transport-ws predecessor. Events are published as EventGraphQLSubscription
+ * events until the server completes the subscription or it's stopped with CancelRequest.
+ * @param {models$0.RequestSpec} spec
+ * @returns {$CancellablePromise<models$0.ExecutionResult | null>}
+ */
+export function ExecuteGraphQLSubscription(spec) {
+    return $Call.ByID(3321539567, spec).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType19($result);
+    }));
+}
+
+/**
+ * ExecuteRequest sends the HTTP request described by spec and returns the response
+ * @param {models$0.RequestSpec} spec
+ * @returns {$CancellablePromise<models$0.ExecutionResult | null>}
+ */
+export function ExecuteRequest(spec) {
+    return $Call.ByID(4279139746, spec).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType19($result);
     }));
 }
 
@@ -150,17 +318,36 @@ export function ExecuteRequest(method, url, headers, body) {
  */
 export function GetActiveEnvironment() {
     return $Call.ByID(4114954677).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType9($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<models$0.Certificate | null>}
+ */
+export function GetCertificate(id) {
+    return $Call.ByID(3289540749, id).then(/** @type {($result: any) => any} */(($result) => {
         return $$createType3($result);
     }));
 }
 
+/**
+ * @returns {$CancellablePromise<(models$0.Certificate | null)[]>}
+ */
+export function GetCertificates() {
+    return $Call.ByID(2801485786).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType20($result);
+    }));
+}
+
 /**
  * @param {number} id
  * @returns {$CancellablePromise<models$0.Collection | null>}
  */
 export function GetCollection(id) {
     return $Call.ByID(156982866, id).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType1($result);
+        return $$createType5($result);
     }));
 }
 
@@ -169,7 +356,27 @@ export function GetCollection(id) {
  */
 export function GetCollections() {
     return $Call.ByID(3688181235).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType11($result);
+        return $$createType21($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<models$0.Cookie | null>}
+ */
+export function GetCookie(id) {
+    return $Call.ByID(3791755696, id).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType7($result);
+    }));
+}
+
+/**
+ * @param {number | null} environmentID
+ * @returns {$CancellablePromise<(models$0.Cookie | null)[]>}
+ */
+export function GetCookies(environmentID) {
+    return $Call.ByID(2340752889, environmentID).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType22($result);
     }));
 }
 
@@ -179,7 +386,7 @@ export function GetCollections() {
  */
 export function GetEnvironment(id) {
     return $Call.ByID(3774239871, id).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType3($result);
+        return $$createType9($result);
     }));
 }
 
@@ -188,7 +395,7 @@ export function GetEnvironment(id) {
  */
 export function GetEnvironments() {
     return $Call.ByID(801525476).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType12($result);
+        return $$createType23($result);
     }));
 }
 
@@ -198,7 +405,7 @@ export function GetEnvironments() {
  */
 export function GetFolder(id) {
     return $Call.ByID(2681527882, id).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType5($result);
+        return $$createType11($result);
     }));
 }
 
@@ -207,7 +414,7 @@ export function GetFolder(id) {
  */
 export function GetFolders() {
     return $Call.ByID(3575239611).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType13($result);
+        return $$createType24($result);
     }));
 }
 
@@ -217,6 +424,25 @@ export function GetFolders() {
  */
 export function GetFoldersByCollection(collectionID) {
     return $Call.ByID(3308135550, collectionID).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType24($result);
+    }));
+}
+
+/**
+ * @returns {$CancellablePromise<(models$0.Proxy | null)[]>}
+ */
+export function GetProxies() {
+    return $Call.ByID(2584354630).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType25($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @returns {$CancellablePromise<models$0.Proxy | null>}
+ */
+export function GetProxy(id) {
+    return $Call.ByID(746271230, id).then(/** @type {($result: any) => any} */(($result) => {
         return $$createType13($result);
     }));
 }
@@ -227,7 +453,7 @@ export function GetFoldersByCollection(collectionID) {
  */
 export function GetRequest(id) {
     return $Call.ByID(710646895, id).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType7($result);
+        return $$createType15($result);
     }));
 }
 
@@ -236,7 +462,7 @@ export function GetRequest(id) {
  */
 export function GetRequestHistory() {
     return $Call.ByID(2650206417).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType14($result);
+        return $$createType26($result);
     }));
 }
 
@@ -246,7 +472,7 @@ export function GetRequestHistory() {
  */
 export function GetRequestHistoryByID(id) {
     return $Call.ByID(1214143277, id).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType9($result);
+        return $$createType17($result);
     }));
 }
 
@@ -256,7 +482,7 @@ export function GetRequestHistoryByID(id) {
  */
 export function GetRequestHistoryByRequest(requestID) {
     return $Call.ByID(1318458705, requestID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType14($result);
+        return $$createType26($result);
     }));
 }
 
@@ -265,7 +491,7 @@ export function GetRequestHistoryByRequest(requestID) {
  */
 export function GetRequests() {
     return $Call.ByID(3392585748).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType15($result);
+        return $$createType27($result);
     }));
 }
 
@@ -275,7 +501,7 @@ export function GetRequests() {
  */
 export function GetRequestsByCollection(collectionID) {
     return $Call.ByID(3935467957, collectionID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType15($result);
+        return $$createType27($result);
     }));
 }
 
@@ -285,10 +511,65 @@ export function GetRequestsByCollection(collectionID) {
  */
 export function GetRequestsByFolder(folderID) {
     return $Call.ByID(88407521, folderID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType15($result);
+        return $$createType27($result);
+    }));
+}
+
+/**
+ * ImportWSDL creates a request per operation of the SOAP services described by a WSDL, with a skeleton
+ * envelope, the SOAPAction and the endpoint of its port. The WSDL is read from location, a URL or a file
+ * path, unless document is given; imports of WSDL and schema documents are resolved relative to location.
+ * Documents are fetched with the proxy, certificates and settings requests would be sent with.
+ * Requests are added to the collection, or to a new one named after the service when collectionID is nil,
+ * in a folder per port when the services have several.
+ * @param {string} location
+ * @param {string} document
+ * @param {models$0.RequestSettings} settings
+ * @param {number | null} collectionID
+ * @returns {$CancellablePromise<(models$0.Request | null)[]>}
+ */
+export function ImportWSDL(location, document, settings, collectionID) {
+    return $Call.ByID(184819533, location, document, settings, collectionID).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType27($result);
+    }));
+}
+
+/**
+ * IntrospectGraphQL returns the schema of the GraphQL endpoint of spec, as the data of an introspection
+ * query. Schemas are cached per endpoint, and only fetched again when refresh is set. The headers, auth
+ * and settings of spec are used to send the query.
+ * @param {models$0.RequestSpec} spec
+ * @param {boolean} refresh
+ * @returns {$CancellablePromise<models$0.GraphQLSchema | null>}
+ */
+export function IntrospectGraphQL(spec, refresh) {
+    return $Call.ByID(3489356882, spec, refresh).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType29($result);
     }));
 }
 
+/**
+ * ListGRPCServices describes the services of the target of spec, from its .proto files or else
+ * through server reflection
+ * @param {models$0.GRPCSpec} spec
+ * @returns {$CancellablePromise<models$0.GRPCService[]>}
+ */
+export function ListGRPCServices(spec) {
+    return $Call.ByID(1207081742, spec).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType31($result);
+    }));
+}
+
+/**
+ * PingWebSocket sends a ping frame, which the server should answer with a pong carrying the same data
+ * @param {string} sessionID
+ * @param {string} data
+ * @returns {$CancellablePromise<void>}
+ */
+export function PingWebSocket(sessionID, data) {
+    return $Call.ByID(160743105, sessionID, data);
+}
+
 /**
  * SaveFileToDownloads saves a file to the user's Downloads folder
  * @param {string} filename
@@ -299,6 +580,48 @@ export function SaveFileToDownloads(filename, content) {
     return $Call.ByID(3346483781, filename, content);
 }
 
+/**
+ * SendWebSocketMessage sends a text or binary message; binary data is given base64 encoded
+ * @param {string} sessionID
+ * @param {string} messageType
+ * @param {string} data
+ * @returns {$CancellablePromise<void>}
+ */
+export function SendWebSocketMessage(sessionID, messageType, data) {
+    return $Call.ByID(1499105610, sessionID, messageType, data);
+}
+
+/**
+ * SetCollectionRetryPolicy sets the retry policy applied to the requests of a collection
+ * that don't set their own; nil disables retries for them
+ * @param {number} id
+ * @param {models$0.RetryPolicy | null} policy
+ * @returns {$CancellablePromise<models$0.Collection | null>}
+ */
+export function SetCollectionRetryPolicy(id, policy) {
+    return $Call.ByID(2462875862, id, policy).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType5($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @param {string} name
+ * @param {string} hostPattern
+ * @param {string} format
+ * @param {string} cert
+ * @param {string} key
+ * @param {string} passphrase
+ * @param {string} ca
+ * @param {boolean} enabled
+ * @returns {$CancellablePromise<models$0.Certificate | null>}
+ */
+export function UpdateCertificate(id, name, hostPattern, format, cert, key, passphrase, ca, enabled) {
+    return $Call.ByID(1033922206, id, name, hostPattern, format, cert, key, passphrase, ca, enabled).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType3($result);
+    }));
+}
+
 /**
  * @param {number} id
  * @param {string} name
@@ -307,7 +630,26 @@ export function SaveFileToDownloads(filename, content) {
  */
 export function UpdateCollection(id, name, description) {
     return $Call.ByID(378234159, id, name, description).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType1($result);
+        return $$createType5($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @param {number | null} environmentID
+ * @param {string} name
+ * @param {string} value
+ * @param {string} domain
+ * @param {string} path
+ * @param {time$0.Time | null} expires
+ * @param {boolean} secure
+ * @param {boolean} httpOnly
+ * @param {boolean} hostOnly
+ * @returns {$CancellablePromise<models$0.Cookie | null>}
+ */
+export function UpdateCookie(id, environmentID, name, value, domain, path, expires, secure, httpOnly, hostOnly) {
+    return $Call.ByID(2763214049, id, environmentID, name, value, domain, path, expires, secure, httpOnly, hostOnly).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType7($result);
     }));
 }
 
@@ -320,7 +662,7 @@ export function UpdateCollection(id, name, description) {
  */
 export function UpdateEnvironment(id, name, variables, isActive) {
     return $Call.ByID(3433491096, id, name, variables, isActive).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType3($result);
+        return $$createType9($result);
     }));
 }
 
@@ -333,41 +675,79 @@ export function UpdateEnvironment(id, name, variables, isActive) {
  */
 export function UpdateFolder(id, name, collectionID, parentFolderID) {
     return $Call.ByID(218600027, id, name, collectionID, parentFolderID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType5($result);
+        return $$createType11($result);
+    }));
+}
+
+/**
+ * @param {number} id
+ * @param {number | null} environmentID
+ * @param {string} proxyType
+ * @param {string} host
+ * @param {number} port
+ * @param {string} username
+ * @param {string} password
+ * @param {string} bypass
+ * @param {boolean} enabled
+ * @returns {$CancellablePromise<models$0.Proxy | null>}
+ */
+export function UpdateProxy(id, environmentID, proxyType, host, port, username, password, bypass, enabled) {
+    return $Call.ByID(4080302489, id, environmentID, proxyType, host, port, username, password, bypass, enabled).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType13($result);
     }));
 }
 
 /**
  * @param {number} id
  * @param {string} name
+ * @param {string} requestType
  * @param {string} method
  * @param {string} url
  * @param {string} headers
  * @param {string} body
+ * @param {string} bodyType
+ * @param {models$0.RequestAuth | null} auth
+ * @param {models$0.RequestSettings | null} settings
  * @param {number | null} collectionID
  * @param {number | null} folderID
  * @returns {$CancellablePromise<models$0.Request | null>}
  */
-export function UpdateRequest(id, name, method, url, headers, body, collectionID, folderID) {
-    return $Call.ByID(3453889040, id, name, method, url, headers, body, collectionID, folderID).then(/** @type {($result: any) => any} */(($result) => {
-        return $$createType7($result);
+export function UpdateRequest(id, name, requestType, method, url, headers, body, bodyType, auth, settings, collectionID, folderID) {
+    return $Call.ByID(3453889040, id, name, requestType, method, url, headers, body, bodyType, auth, settings, collectionID, folderID).then(/** @type {($result: any) => any} */(($result) => {
+        return $$createType15($result);
     }));
 }
 
 // Private type creation functions
-const $$createType0 = models$0.Collection.createFrom;
+const $$createType0 = models$0.WebSocketSession.createFrom;
 const $$createType1 = $Create.Nullable($$createType0);
-const $$createType2 = models$0.Environment.createFrom;
+const $$createType2 = models$0.Certificate.createFrom;
 const $$createType3 = $Create.Nullable($$createType2);
-const $$createType4 = models$0.Folder.createFrom;
+const $$createType4 = models$0.Collection.createFrom;
 const $$createType5 = $Create.Nullable($$createType4);
-const $$createType6 = models$0.Request.createFrom;
+const $$createType6 = models$0.Cookie.createFrom;
 const $$createType7 = $Create.Nullable($$createType6);
-const $$createType8 = models$0.RequestHistory.createFrom;
+const $$createType8 = models$0.Environment.createFrom;
 const $$createType9 = $Create.Nullable($$createType8);
-const $$createType10 = $Create.Map($Create.Any, $Create.Any);
-const $$createType11 = $Create.Array($$createType1);
-const $$createType12 = $Create.Array($$createType3);
-const $$createType13 = $Create.Array($$createType5);
-const $$createType14 = $Create.Array($$createType9);
-const $$createType15 = $Create.Array($$createType7);
+const $$createType10 = models$0.Folder.createFrom;
+const $$createType11 = $Create.Nullable($$createType10);
+const $$createType12 = models$0.Proxy.createFrom;
+const $$createType13 = $Create.Nullable($$createType12);
+const $$createType14 = models$0.Request.createFrom;
+const $$createType15 = $Create.Nullable($$createType14);
+const $$createType16 = models$0.RequestHistory.createFrom;
+const $$createType17 = $Create.Nullable($$createType16);
+const $$createType18 = models$0.ExecutionResult.createFrom;
+const $$createType19 = $Create.Nullable($$createType18);
+const $$createType20 = $Create.Array($$createType3);
+const $$createType21 = $Create.Array($$createType5);
+const $$createType22 = $Create.Array($$createType7);
+const $$createType23 = $Create.Array($$createType9);
+const $$createType24 = $Create.Array($$createType11);
+const $$createType25 = $Create.Array($$createType13);
+const $$createType26 = $Create.Array($$createType17);
+const $$createType27 = $Create.Array($$createType15);
+const $$createType28 = models$0.GraphQLSchema.createFrom;
+const $$createType29 = $Create.Nullable($$createType28);
+const $$createType30 = models$0.GRPCService.createFrom;
+const $$createType31 = $Create.Array($$createType30);
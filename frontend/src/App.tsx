@@ -1,4 +1,5 @@
 import React from 'react';
+import { Browser, Events } from '@wailsio/runtime';
 import { AppLayout } from '@/components/layout/AppLayout';
 import { useAPIStore } from '@/store';
 
@@ -24,6 +25,14 @@ function App() {
     initializeData();
   }, [fetchCollections, fetchFolders, fetchRequests, fetchEnvironments]);
 
+  React.useEffect(() => {
+    // OAuth 2.0 logins happen in the browser, which the backend asks us to open
+    return Events.On('execution:authorize', (event) => {
+      const [prompt] = event.data as { execution_id: string; url: string }[];
+      Browser.OpenURL(prompt.url);
+    });
+  }, []);
+
   return (
     <div className="App">
       <AppLayout />
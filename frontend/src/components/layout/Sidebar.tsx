@@ -29,7 +29,7 @@ import { BulkOperationsModal } from '@/components/modals/BulkOperationsModal';
 import { ConfirmationModal } from '@/components/modals/ConfirmationModal';
 
 import { getMethodColor, cn } from '@/utils';
-import type { CollectionTreeItem, HTTPMethod } from '@/types';
+import type { CollectionTreeItem, HTTPMethod, RequestType } from '@/types';
 
 export const Sidebar: React.FC = (): JSX.Element => {
   const { 
@@ -452,9 +452,9 @@ export const Sidebar: React.FC = (): JSX.Element => {
     }
   };
 
-  const handleCreateRequestInCollection = async (name: string, method: HTTPMethod, url: string, folderId?: number) => {
+  const handleCreateRequestInCollection = async (name: string, method: HTTPMethod, url: string, folderId?: number, type?: RequestType) => {
     if (selectedCollectionId) {
-      const request = await createRequest(name, method, url, '{}', '', selectedCollectionId, folderId);
+      const request = await createRequest(name, method, url, '{}', '', selectedCollectionId, folderId, undefined, type);
       // Open the new request in a tab
       openRequestTab(request);
     }
@@ -559,9 +559,11 @@ export const Sidebar: React.FC = (): JSX.Element => {
             {item.type === 'request' && (
               <div className={cn(
                 'px-1.5 py-0.5 rounded text-xs font-medium',
-                getMethodColor(item.method!)
+                item.request_type === 'websocket' ? 'text-teal-600'
+                  : item.request_type === 'grpc' ? 'text-indigo-600'
+                  : getMethodColor(item.method!)
               )}>
-                {item.method}
+                {item.request_type === 'websocket' ? 'WS' : item.request_type === 'grpc' ? 'gRPC' : item.method}
               </div>
             )}
           </div>
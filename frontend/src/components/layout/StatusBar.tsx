@@ -1,11 +1,34 @@
 import React from 'react';
-import { Wifi, WifiOff, Clock, HardDrive } from 'lucide-react';
+import { Events } from '@wailsio/runtime';
+import { Wifi, WifiOff, Clock, HardDrive, ArrowUp, ArrowDown } from 'lucide-react';
 import { useUIStore } from '@/store';
 import { formatResponseTime, formatFileSize, cn } from '@/utils';
+import type { TransferProgress } from '@/types';
+
+const formatProgress = (progress: TransferProgress) => {
+  const rate = `${formatFileSize(Math.round(progress.bytes_per_second))}/s`;
+  if (progress.total <= 0) return `${formatFileSize(progress.bytes)} · ${rate}`;
+  const percent = Math.floor((progress.bytes / progress.total) * 100);
+  return `${percent}% · ${formatFileSize(progress.bytes)} of ${formatFileSize(progress.total)} · ${rate}`;
+};
 
 export const StatusBar: React.FC = () => {
   const { lastResponse, isExecutingRequest } = useUIStore();
   const [isOnline, setIsOnline] = React.useState(navigator.onLine);
+  // Transfers in flight, by execution and direction
+  const [transfers, setTransfers] = React.useState<Record<string, TransferProgress>>({});
+
+  React.useEffect(() => {
+    return Events.On('execution:progress', (event) => {
+      const [progress] = event.data as TransferProgress[];
+      const key = `${progress.execution_id}:${progress.direction}`;
+      setTransfers(current => {
+        const next = { ...current, [key]: progress };
+        if (progress.done) delete next[key];
+        return next;
+      });
+    });
+  }, []);
 
   React.useEffect(() => {
     const handleOnline = () => setIsOnline(true);
@@ -49,6 +72,13 @@ export const StatusBar: React.FC = () => {
             <span>Sending request...</span>
           </div>
         )}
+
+        {Object.entries(transfers).map(([key, progress]) => (
+          <div key={key} className="flex items-center gap-1 text-blue-600">
+            {progress.direction === 'upload' ? <ArrowUp className="h-3 w-3" /> : <ArrowDown className="h-3 w-3" />}
+            <span>{formatProgress(progress)}</span>
+          </div>
+        ))}
       </div>
 
       {/* Center Section - Request Stats */}
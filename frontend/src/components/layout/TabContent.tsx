@@ -2,6 +2,8 @@ import React from 'react';
 import { ChevronLeft, ChevronRight } from 'lucide-react';
 import { RequestBuilder } from '@/components/request/RequestBuilder';
 import { ResponseViewer } from '@/components/request/ResponseViewer';
+import { WebSocketPanel } from '@/components/request/WebSocketPanel';
+import { GRPCPanel } from '@/components/request/GRPCPanel';
 import { CollectionDetailsView } from '@/components/collection/CollectionDetailsView';
 import { useAPIStore, useTabsStore } from '@/store';
 import { cn } from '@/utils';
@@ -74,6 +76,24 @@ export const TabContent: React.FC<TabContentProps> = ({
   if (tab.type === 'request') {
     const requestTab = tab as RequestTab;
     
+    if (requestTab.request.type === 'websocket') {
+      return (
+        <div className={cn(className, 'flex')}>
+          <WebSocketPanel
+            key={requestTab.request.id}
+            request={requestTab.request}
+            onRequestUpdate={(updatedRequest) => {
+              updateTab(tab.id, {
+                request: updatedRequest,
+                unsavedChanges: false,
+                title: updatedRequest.name,
+              } as Partial<RequestTab>);
+            }}
+          />
+        </div>
+      );
+    }
+
     return (
       <div className={cn(className, isDragging && "select-none")}>
         <div ref={containerRef} className="flex h-full relative">
@@ -86,28 +106,53 @@ export const TabContent: React.FC<TabContentProps> = ({
                 : '100%' 
             }}
           >
-            <RequestBuilder 
-              key={requestTab.request.id}
-              initialRequest={requestTab.request}
-              onRequestUpdate={(updatedRequest) => {
-                updateTab(tab.id, {
-                  request: updatedRequest,
-                  unsavedChanges: true,
-                  title: updatedRequest.name || 'Untitled Request',
-                } as Partial<RequestTab>);
-              }}
-              onResponseUpdate={(response) => {
-                updateTab(tab.id, {
-                  response,
-                  isExecuting: false,
-                } as Partial<RequestTab>);
-              }}
-              onExecutionStart={() => {
-                updateTab(tab.id, {
-                  isExecuting: true,
-                } as Partial<RequestTab>);
-              }}
-            />
+            {requestTab.request.type === 'grpc' ? (
+              <GRPCPanel
+                key={requestTab.request.id}
+                request={requestTab.request}
+                onRequestUpdate={(updatedRequest) => {
+                  updateTab(tab.id, {
+                    request: updatedRequest,
+                    unsavedChanges: false,
+                    title: updatedRequest.name,
+                  } as Partial<RequestTab>);
+                }}
+                onResponseUpdate={(response) => {
+                  updateTab(tab.id, {
+                    response,
+                    isExecuting: false,
+                  } as Partial<RequestTab>);
+                }}
+                onExecutionStart={() => {
+                  updateTab(tab.id, {
+                    isExecuting: true,
+                  } as Partial<RequestTab>);
+                }}
+              />
+            ) : (
+              <RequestBuilder 
+                key={requestTab.request.id}
+                initialRequest={requestTab.request}
+                onRequestUpdate={(updatedRequest) => {
+                  updateTab(tab.id, {
+                    request: updatedRequest,
+                    unsavedChanges: true,
+                    title: updatedRequest.name || 'Untitled Request',
+                  } as Partial<RequestTab>);
+                }}
+                onResponseUpdate={(response) => {
+                  updateTab(tab.id, {
+                    response,
+                    isExecuting: false,
+                  } as Partial<RequestTab>);
+                }}
+                onExecutionStart={() => {
+                  updateTab(tab.id, {
+                    isExecuting: true,
+                  } as Partial<RequestTab>);
+                }}
+              />
+            )}
           </div>
 
           {/* Resizer */}
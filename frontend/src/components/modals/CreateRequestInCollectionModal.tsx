@@ -3,14 +3,14 @@ import { X, FileText, Plus } from 'lucide-react';
 import { Button, Input, Select } from '@/components/ui';
 import { useAPIStore, useTabsStore } from '@/store';
 import { getMethodColor } from '@/utils';
-import type { Collection, HTTPMethod } from '@/types';
+import type { Collection, HTTPMethod, RequestType } from '@/types';
 
 interface CreateRequestInCollectionModalProps {
   collectionId?: number;
   folderId?: number;
   isOpen: boolean;
   onClose: () => void;
-  onSubmit: (name: string, method: string, url: string, folderId?: number) => void;
+  onSubmit: (name: string, method: string, url: string, folderId?: number, type?: RequestType) => void;
 }
 
 const HTTP_METHODS: { value: HTTPMethod; label: string }[] = [
@@ -23,6 +23,18 @@ const HTTP_METHODS: { value: HTTPMethod; label: string }[] = [
   { value: 'OPTIONS', label: 'OPTIONS' },
 ];
 
+const URL_PLACEHOLDERS: Record<RequestType, string> = {
+  http: 'https://api.example.com/endpoint',
+  websocket: 'wss://api.example.com/socket',
+  grpc: 'grpcs://api.example.com:443',
+};
+
+const REQUEST_TYPES: { value: RequestType; label: string }[] = [
+  { value: 'http', label: 'HTTP' },
+  { value: 'websocket', label: 'WebSocket' },
+  { value: 'grpc', label: 'gRPC' },
+];
+
 export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionModalProps> = ({
   collectionId,
   folderId,
@@ -33,6 +45,7 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
   const { folders, collections, createRequest } = useAPIStore();
   const { openRequestTab } = useTabsStore();
   const [name, setName] = React.useState('');
+  const [type, setType] = React.useState<RequestType>('http');
   const [method, setMethod] = React.useState<HTTPMethod>('GET');
   const [url, setUrl] = React.useState('');
   const [selectedFolderId, setSelectedFolderId] = React.useState(folderId);
@@ -44,6 +57,7 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
   // Reset form when props change
   React.useEffect(() => {
     setName('');
+    setType('http');
     setMethod('GET');
     setUrl('');
     setSelectedFolderId(folderId);
@@ -69,13 +83,16 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
       // Use the onSubmit prop to handle the request creation
       await onSubmit(
         name.trim(),
-        method,
+        // gRPC calls are POSTs, WebSocket handshakes GETs
+        type === 'grpc' ? 'POST' : type === 'websocket' ? 'GET' : method,
         url.trim(),
-        selectedFolderId || undefined
+        selectedFolderId || undefined,
+        type
       );
       
       onClose();
       setName('');
+      setType('http');
       setMethod('GET');
       setUrl('');
       setSelectedFolderId(undefined);
@@ -132,6 +149,18 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
             />
           </div>
 
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Type
+            </label>
+            <Select
+              options={REQUEST_TYPES}
+              value={type}
+              onChange={(value) => setType(value as RequestType)}
+            />
+          </div>
+
+          {type === 'http' && (
           <div>
             <label className="block text-sm font-medium text-gray-700 mb-2">
               HTTP Method
@@ -150,6 +179,7 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
               onChange={(value) => setMethod(value as HTTPMethod)}
             />
           </div>
+          )}
 
           <div>
             <label className="block text-sm font-medium text-gray-700 mb-2">
@@ -158,7 +188,7 @@ export const CreateRequestInCollectionModal: React.FC<CreateRequestInCollectionM
             <Input
               value={url}
               onChange={(e) => setUrl(e.target.value)}
-              placeholder="https://api.example.com/endpoint"
+              placeholder={URL_PLACEHOLDERS[type]}
             />
           </div>
 
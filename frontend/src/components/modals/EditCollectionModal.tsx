@@ -2,8 +2,8 @@ import React from 'react';
 import { X, FolderOpen } from 'lucide-react';
 import { Button, Input, Select } from '@/components/ui';
 import { useAPIStore } from '@/store';
-import { cn } from '@/utils';
-import type { Collection } from '@/types';
+import { cn, RETRY_NETWORK_ERRORS } from '@/utils';
+import type { Collection, RetryNetworkError } from '@/types';
 
 interface EditCollectionModalProps {
   collection: Collection;
@@ -18,10 +18,13 @@ export const EditCollectionModal: React.FC<EditCollectionModalProps> = ({
   onClose,
   onCollectionUpdated,
 }) => {
-  const { environments, updateCollection } = useAPIStore();
+  const { environments, updateCollection, setCollectionRetryPolicy } = useAPIStore();
   const [name, setName] = React.useState(collection.name);
   const [description, setDescription] = React.useState(collection.description);
   const [environmentId, setEnvironmentId] = React.useState(collection.environment_id);
+  const [maxAttempts, setMaxAttempts] = React.useState(collection.retry_policy?.max_attempts || 1);
+  const [statusCodes, setStatusCodes] = React.useState(collection.retry_policy?.status_codes.join(', ') || '');
+  const [networkErrors, setNetworkErrors] = React.useState<RetryNetworkError[]>(collection.retry_policy?.network_errors || []);
   const [isLoading, setIsLoading] = React.useState(false);
 
   // Reset form when collection changes
@@ -29,8 +32,15 @@ export const EditCollectionModal: React.FC<EditCollectionModalProps> = ({
     setName(collection.name);
     setDescription(collection.description);
     setEnvironmentId(collection.environment_id);
+    setMaxAttempts(collection.retry_policy?.max_attempts || 1);
+    setStatusCodes(collection.retry_policy?.status_codes.join(', ') || '');
+    setNetworkErrors(collection.retry_policy?.network_errors || []);
   }, [collection]);
 
+  const toggleNetworkError = (value: RetryNetworkError) => {
+    setNetworkErrors(prev => prev.includes(value) ? prev.filter(e => e !== value) : [...prev, value]);
+  };
+
   const environmentOptions = [
     { value: '', label: 'No Environment' },
     ...environments.map(env => ({
@@ -45,12 +55,26 @@ export const EditCollectionModal: React.FC<EditCollectionModalProps> = ({
 
     setIsLoading(true);
     try {
-      const updatedCollection = await updateCollection(
+      await updateCollection(
         collection.id,
         name.trim(),
         description.trim(),
         environmentId || undefined
       );
+      const updatedCollection = await setCollectionRetryPolicy(
+        collection.id,
+        maxAttempts > 1
+          ? {
+              ...collection.retry_policy,
+              max_attempts: maxAttempts,
+              status_codes: statusCodes
+                .split(',')
+                .map(code => parseInt(code.trim()))
+                .filter(code => !isNaN(code)),
+              network_errors: networkErrors,
+            }
+          : undefined
+      );
       onCollectionUpdated?.(updatedCollection);
       onClose();
     } catch (error) {
@@ -126,6 +150,48 @@ export const EditCollectionModal: React.FC<EditCollectionModalProps> = ({
             </p>
           </div>
 
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Retries
+            </label>
+            <div className="grid grid-cols-2 gap-3">
+              <Input
+                type="number"
+                min={1}
+                max={10}
+                value={maxAttempts}
+                onChange={(e) => setMaxAttempts(parseInt(e.target.value) || 1)}
+                placeholder="Max attempts"
+              />
+              <Input
+                value={statusCodes}
+                onChange={(e) => setStatusCodes(e.target.value)}
+                placeholder="429, 502, 503, 504"
+                disabled={maxAttempts <= 1}
+              />
+            </div>
+            <div className="grid grid-cols-2 gap-2 mt-3">
+              {RETRY_NETWORK_ERRORS.map(({ value, label }) => (
+                <div key={value} className="flex items-center gap-2">
+                  <input
+                    type="checkbox"
+                    id={`retry-${value}`}
+                    checked={networkErrors.includes(value)}
+                    onChange={() => toggleNetworkError(value)}
+                    disabled={maxAttempts <= 1}
+                    className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+                  />
+                  <label htmlFor={`retry-${value}`} className="text-sm text-gray-700">
+                    {label}
+                  </label>
+                </div>
+              ))}
+            </div>
+            <p className="text-xs text-gray-500 mt-1">
+              Max attempts including the first one. Requests of this collection are retried on these status codes and network errors, with exponential backoff and Retry-After
+            </p>
+          </div>
+
           <div className="flex justify-end gap-3 pt-4">
             <Button
               type="button"
@@ -1,6 +1,6 @@
 import React from 'react';
 import { Upload, Download, FileText, AlertCircle, CheckCircle } from 'lucide-react';
-import { Button, Modal } from '@/components/ui';
+import { Button, Input, Modal, Select } from '@/components/ui';
 import { PostmanConverter } from '@/utils/postmanConverter';
 import { useAPIStore } from '@/store';
 import { apiService } from '@/services/api';
@@ -15,7 +15,7 @@ export const ImportExportModal: React.FC<ImportExportModalProps> = ({
   isOpen,
   onClose,
 }) => {
-  const { collections, folders, requests, createCollection, createFolder, createRequest } = useAPIStore();
+  const { collections, folders, requests, createCollection, createFolder, createRequest, importWSDL } = useAPIStore();
   const [activeTab, setActiveTab] = React.useState<'import' | 'export'>('import');
   const [isLoading, setIsLoading] = React.useState(false);
   const [selectedCollections, setSelectedCollections] = React.useState<Set<number>>(new Set());
@@ -25,6 +25,43 @@ export const ImportExportModal: React.FC<ImportExportModalProps> = ({
     message: string;
     details?: string;
   } | null>(null);
+  const [wsdlLocation, setWSDLLocation] = React.useState('');
+  const [wsdlCollectionId, setWSDLCollectionId] = React.useState('');
+  const [wsdlSkipTLSVerify, setWSDLSkipTLSVerify] = React.useState(false);
+
+  // WSDLs are read from a URL or path, which their imports are resolved against, or from a chosen file
+  const handleImportWSDL = async (location: string, document = '') => {
+    setIsLoading(true);
+    setImportResult(null);
+
+    try {
+      const collectionId = wsdlCollectionId ? Number(wsdlCollectionId) : undefined;
+      const imported = await importWSDL(location, document, collectionId, wsdlSkipTLSVerify);
+      setImportResult({
+        success: true,
+        message: 'Successfully imported the WSDL',
+        details: `Created ${imported.length} SOAP requests`,
+      });
+      setWSDLLocation('');
+    } catch (error) {
+      console.error('WSDL import failed:', error);
+      setImportResult({
+        success: false,
+        message: 'Failed to import the WSDL',
+        details: error instanceof Error ? error.message : String(error),
+      });
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
+  const handleWSDLFile = async (event: React.ChangeEvent<HTMLInputElement>) => {
+    const file = event.target.files?.[0];
+    if (!file) return;
+
+    await handleImportWSDL('', await file.text());
+    event.target.value = '';
+  };
 
   const handleImport = async (event: React.ChangeEvent<HTMLInputElement>) => {
     const file = event.target.files?.[0];
@@ -90,7 +127,8 @@ export const ImportExportModal: React.FC<ImportExportModalProps> = ({
           request.headers,
           request.body,
           createdCollection.id,
-          request.folder_id !== undefined ? createdFolders.get(request.folder_id)?.id : undefined
+          request.folder_id !== undefined ? createdFolders.get(request.folder_id)?.id : undefined,
+          request.auth
         );
         console.log('✅ Request created:', createdRequest);
       }
@@ -224,7 +262,7 @@ export const ImportExportModal: React.FC<ImportExportModalProps> = ({
             `}
           >
             <Upload className="h-4 w-4 inline mr-2" />
-            Import from Postman
+            Import
           </button>
           <button
             onClick={() => setActiveTab('export')}
@@ -316,6 +354,71 @@ export const ImportExportModal: React.FC<ImportExportModalProps> = ({
                 </div>
               </div>
 
+              <div className="border-t border-gray-200 pt-6">
+                <h3 className="text-sm font-medium text-gray-900 mb-2">
+                  Import WSDL
+                </h3>
+                <p className="text-sm text-gray-600 mb-4">
+                  Create a SOAP request per operation, with a skeleton envelope, its SOAPAction and endpoint.
+                  Imported schemas are only resolved when the WSDL is loaded from a URL or path.
+                </p>
+
+                <div className="space-y-3">
+                  <Select
+                    label="Into"
+                    options={[
+                      { value: '', label: 'A new collection named after the service' },
+                      ...collections.map(c => ({ value: String(c.id), label: c.name })),
+                    ]}
+                    value={wsdlCollectionId}
+                    onChange={setWSDLCollectionId}
+                  />
+                  <div className="flex items-end gap-2">
+                    <div className="flex-1">
+                      <Input
+                        label="WSDL URL or path"
+                        value={wsdlLocation}
+                        onChange={(e) => setWSDLLocation(e.target.value)}
+                        placeholder="https://example.com/service?wsdl"
+                      />
+                    </div>
+                    <Button
+                      variant="primary"
+                      onClick={() => handleImportWSDL(wsdlLocation.trim())}
+                      disabled={isLoading || !wsdlLocation.trim()}
+                      loading={isLoading}
+                    >
+                      Import
+                    </Button>
+                    <label className="cursor-pointer">
+                      <input
+                        type="file"
+                        accept=".wsdl,.xml"
+                        onChange={handleWSDLFile}
+                        className="hidden"
+                        disabled={isLoading}
+                      />
+                      <span className="inline-flex items-center px-3 py-2 text-sm font-medium border border-gray-300 rounded-lg bg-white hover:bg-gray-50">
+                        <FileText className="h-4 w-4 mr-2" />
+                        Choose File
+                      </span>
+                    </label>
+                  </div>
+                  <div className="flex items-center gap-2">
+                    <input
+                      type="checkbox"
+                      id="wsdl-skip-tls-verify"
+                      checked={wsdlSkipTLSVerify}
+                      onChange={(e) => setWSDLSkipTLSVerify(e.target.checked)}
+                      className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+                    />
+                    <label htmlFor="wsdl-skip-tls-verify" className="text-sm text-gray-700">
+                      Accept any server certificate, e.g. self-signed ones
+                    </label>
+                  </div>
+                </div>
+              </div>
+
               {importResult && (
                 <div className={`
                   p-4 rounded-lg border
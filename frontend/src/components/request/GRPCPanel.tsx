@@ -0,0 +1,390 @@
+import React from 'react';
+import { Events } from '@wailsio/runtime';
+import { Play, Square, Save, RefreshCw, Plus, Trash2, ArrowUpRight, ArrowDownLeft } from 'lucide-react';
+import { Button, Input, Select, Tabs, VariableInput, VariablePreview } from '@/components/ui';
+import { useAPIStore } from '@/store';
+import { cn } from '@/utils';
+import type { APIResponse, GRPCMessage, GRPCMethod, GRPCRequestBody, GRPCService, Request } from '@/types';
+
+type GRPCTab = 'message' | 'metadata' | 'protos';
+
+interface GRPCPanelProps {
+  request: Request;
+  onRequestUpdate?: (request: Request) => void;
+  onResponseUpdate?: (response: APIResponse) => void;
+  onExecutionStart?: () => void;
+  className?: string;
+}
+
+const parseSettings = (body: string): GRPCRequestBody => {
+  const defaults: GRPCRequestBody = { method: '', messages: ['{}'], proto_files: [], import_paths: [], tls: false };
+  try {
+    return body ? { ...defaults, ...JSON.parse(body) } : defaults;
+  } catch {
+    return defaults;
+  }
+};
+
+const parseMetadata = (headers: string): { key: string; value: string }[] => {
+  try {
+    return Object.entries(JSON.parse(headers || '{}')).map(([key, value]) => ({ key, value: String(value) }));
+  } catch {
+    return [];
+  }
+};
+
+const lines = (text: string) => text.split('\n').map(line => line.trim()).filter(Boolean);
+
+const streamingLabel = (method: GRPCMethod) => {
+  if (method.client_streaming && method.server_streaming) return 'bidi stream';
+  if (method.client_streaming) return 'client stream';
+  if (method.server_streaming) return 'server stream';
+  return 'unary';
+};
+
+export const GRPCPanel: React.FC<GRPCPanelProps> = ({
+  request,
+  onRequestUpdate,
+  onResponseUpdate,
+  onExecutionStart,
+  className,
+}) => {
+  const { environments, listGRPCServices, executeGRPC, cancelRequest, updateRequest } = useAPIStore();
+
+  const initial = parseSettings(request.body);
+  const [target, setTarget] = React.useState(request.url);
+  const [method, setMethod] = React.useState(initial.method);
+  const [messages, setMessages] = React.useState<string[]>(initial.messages.length > 0 ? initial.messages : ['{}']);
+  const [metadata, setMetadata] = React.useState(parseMetadata(request.headers));
+  const [protoFiles, setProtoFiles] = React.useState(initial.proto_files.join('\n'));
+  const [importPaths, setImportPaths] = React.useState(initial.import_paths.join('\n'));
+  const [tls, setTls] = React.useState(initial.tls);
+
+  const [services, setServices] = React.useState<GRPCService[]>([]);
+  const [isLoadingServices, setIsLoadingServices] = React.useState(false);
+  const [executionId, setExecutionId] = React.useState<string>();
+  const [streamed, setStreamed] = React.useState<GRPCMessage[]>([]);
+  const [activeTab, setActiveTab] = React.useState<GRPCTab>('message');
+  const [error, setError] = React.useState<string>();
+
+  const methods = services.flatMap(service => service.methods);
+  const selectedMethod = methods.find(m => m.full_name === method);
+  const isStreaming = !!selectedMethod && (selectedMethod.client_streaming || selectedMethod.server_streaming);
+
+  // Streamed messages are pushed by the backend as they're exchanged
+  React.useEffect(() => {
+    if (!executionId) return;
+
+    return Events.On('execution:grpc', (event) => {
+      const [message] = event.data as GRPCMessage[];
+      if (message.execution_id !== executionId) return;
+      setStreamed(current => [...current, message]);
+    });
+  }, [executionId]);
+
+  const currentSettings = (): GRPCRequestBody => ({
+    method,
+    messages,
+    proto_files: lines(protoFiles),
+    import_paths: lines(importPaths),
+    tls,
+  });
+
+  const currentMetadata = () => JSON.stringify(
+    Object.fromEntries(metadata.filter(m => m.key.trim()).map(m => [m.key.trim(), m.value]))
+  );
+
+  const handleLoadServices = async () => {
+    setError(undefined);
+    setIsLoadingServices(true);
+    try {
+      const loaded = await listGRPCServices(target, currentMetadata(), currentSettings());
+      setServices(loaded);
+    } catch (err) {
+      setError(String(err));
+    } finally {
+      setIsLoadingServices(false);
+    }
+  };
+
+  const handleMethodChange = (fullName: string) => {
+    setMethod(fullName);
+    const chosen = methods.find(m => m.full_name === fullName);
+    // Start from the template unless a message was already written
+    if (chosen && messages.every(m => !m.trim() || m.trim() === '{}')) {
+      setMessages([chosen.input_template]);
+    }
+  };
+
+  const handleSend = async () => {
+    if (executionId) return;
+
+    const id = crypto.randomUUID();
+    setError(undefined);
+    setStreamed([]);
+    setExecutionId(id);
+    onExecutionStart?.();
+
+    try {
+      const response = await executeGRPC(target, currentMetadata(), currentSettings(), {
+        executionId: id,
+        requestId: request.id > 0 ? request.id : undefined,
+      });
+      onResponseUpdate?.(response);
+    } catch (err) {
+      setError(String(err));
+    } finally {
+      setExecutionId(undefined);
+    }
+  };
+
+  // Stops the call in flight, e.g. to end a server stream
+  const handleStop = async () => {
+    if (!executionId) return;
+
+    try {
+      await cancelRequest(executionId);
+    } catch (err) {
+      console.error('Failed to stop gRPC call:', err);
+    }
+  };
+
+  const handleSave = async () => {
+    if (request.id <= 0) return;
+
+    try {
+      const saved = await updateRequest(
+        request.id,
+        request.name,
+        'POST',
+        target,
+        currentMetadata(),
+        JSON.stringify(currentSettings()),
+        request.collection_id,
+        request.folder_id,
+        request.auth,
+        'grpc',
+        undefined,
+        request.settings
+      );
+      onRequestUpdate?.(saved);
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const tabItems = [
+    { id: 'message' as const, label: selectedMethod?.client_streaming ? 'Messages' : 'Message', badge: selectedMethod?.client_streaming ? messages.length : undefined },
+    { id: 'metadata' as const, label: 'Metadata', badge: metadata.filter(m => m.key.trim()).length || undefined },
+    { id: 'protos' as const, label: 'Proto Files', badge: lines(protoFiles).length || undefined },
+  ];
+
+  const methodOptions = [
+    { value: '', label: methods.length > 0 ? 'Select a method' : 'Load services first' },
+    ...methods.map(m => ({ value: m.full_name, label: `${m.full_name} (${streamingLabel(m)})` })),
+  ];
+  if (method && !selectedMethod) {
+    methodOptions.push({ value: method, label: method });
+  }
+
+  return (
+    <div className={cn('flex-1 flex flex-col bg-white min-h-0', className)}>
+      {/* Call */}
+      <div className="p-4 border-b border-gray-200 space-y-3">
+        <div className="flex items-center justify-between">
+          <h2 className="text-lg font-semibold text-gray-900">{request.name}</h2>
+          <Button
+            size="sm"
+            variant="ghost"
+            icon={<Save className="h-4 w-4" />}
+            title="Save Request"
+            onClick={handleSave}
+            disabled={request.id <= 0}
+          />
+        </div>
+
+        <div className="flex items-start gap-2">
+          <div className="flex-1 min-w-0">
+            <VariableInput
+              value={target}
+              onChange={setTarget}
+              placeholder="grpcs://api.example.com:443 or localhost:50051"
+              className="font-mono text-sm"
+            />
+          </div>
+          <label className="flex items-center gap-1 text-sm text-gray-700 pt-2 flex-shrink-0">
+            <input type="checkbox" checked={tls} onChange={(e) => setTls(e.target.checked)} />
+            TLS
+          </label>
+          <div className="flex-shrink-0">
+            {executionId ? (
+              <Button
+                variant="secondary"
+                icon={<Square className="h-4 w-4" />}
+                onClick={handleStop}
+                className="!px-6"
+              >
+                Stop
+              </Button>
+            ) : (
+              <Button
+                variant="primary"
+                icon={<Play className="h-4 w-4" />}
+                onClick={handleSend}
+                disabled={!target.trim() || !method}
+                className="!px-6"
+              >
+                Invoke
+              </Button>
+            )}
+          </div>
+        </div>
+
+        <VariablePreview text={target} environments={environments} label="Target with variables:" />
+
+        <div className="flex items-center gap-2">
+          <div className="flex-1 min-w-0">
+            <Select
+              options={methodOptions}
+              value={method}
+              onChange={(value) => handleMethodChange(String(value))}
+            />
+          </div>
+          <Button
+            variant="ghost"
+            icon={<RefreshCw className="h-4 w-4" />}
+            onClick={handleLoadServices}
+            loading={isLoadingServices}
+            disabled={!target.trim() && !protoFiles.trim()}
+            title={protoFiles.trim() ? 'Load services from the proto files' : 'Load services through server reflection'}
+          >
+            Load services
+          </Button>
+        </div>
+
+        {error && (
+          <div className="text-sm text-red-600 bg-red-50 border border-red-200 rounded p-2 whitespace-pre-wrap">{error}</div>
+        )}
+      </div>
+
+      <div className="border-b border-gray-200">
+        <Tabs items={tabItems} activeTab={activeTab} onChange={(tab) => setActiveTab(tab as GRPCTab)} variant="underline" />
+      </div>
+
+      <div className="flex-1 overflow-auto p-4 min-h-0 space-y-3">
+        {activeTab === 'message' && (
+          <>
+            {messages.map((message, index) => (
+              <div key={index} className="space-y-1">
+                {selectedMethod?.client_streaming && (
+                  <div className="flex items-center justify-between text-xs text-gray-500">
+                    <span>Message {index + 1}</span>
+                    <Button
+                      size="sm"
+                      variant="ghost"
+                      icon={<Trash2 className="h-3 w-3" />}
+                      onClick={() => setMessages(messages.filter((_, i) => i !== index))}
+                      disabled={messages.length === 1}
+                      title="Remove message"
+                    />
+                  </div>
+                )}
+                <textarea
+                  value={message}
+                  onChange={(e) => setMessages(messages.map((m, i) => i === index ? e.target.value : m))}
+                  placeholder="{}"
+                  className="w-full h-40 p-2 border border-gray-300 rounded font-mono text-sm resize-y focus:outline-none focus:ring-2 focus:ring-blue-500"
+                />
+              </div>
+            ))}
+            {selectedMethod?.client_streaming && (
+              <Button
+                size="sm"
+                variant="ghost"
+                icon={<Plus className="h-4 w-4" />}
+                onClick={() => setMessages([...messages, selectedMethod.input_template])}
+              >
+                Add message
+              </Button>
+            )}
+          </>
+        )}
+
+        {activeTab === 'metadata' && (
+          <>
+            {metadata.map((pair, index) => (
+              <div key={index} className="flex items-center gap-2">
+                <Input
+                  value={pair.key}
+                  onChange={(e) => setMetadata(metadata.map((m, i) => i === index ? { ...m, key: e.target.value } : m))}
+                  placeholder="Key"
+                />
+                <Input
+                  value={pair.value}
+                  onChange={(e) => setMetadata(metadata.map((m, i) => i === index ? { ...m, value: e.target.value } : m))}
+                  placeholder="Value"
+                />
+                <Button
+                  size="sm"
+                  variant="ghost"
+                  icon={<Trash2 className="h-4 w-4" />}
+                  onClick={() => setMetadata(metadata.filter((_, i) => i !== index))}
+                  title="Remove"
+                />
+              </div>
+            ))}
+            <Button
+              size="sm"
+              variant="ghost"
+              icon={<Plus className="h-4 w-4" />}
+              onClick={() => setMetadata([...metadata, { key: '', value: '' }])}
+            >
+              Add metadata
+            </Button>
+          </>
+        )}
+
+        {activeTab === 'protos' && (
+          <>
+            <p className="text-xs text-gray-500">
+              Without .proto files, services are discovered through server reflection.
+            </p>
+            <div>
+              <label className="block text-sm font-medium text-gray-700 mb-1">.proto files, one path per line</label>
+              <textarea
+                value={protoFiles}
+                onChange={(e) => setProtoFiles(e.target.value)}
+                placeholder="/path/to/service.proto"
+                className="w-full h-24 p-2 border border-gray-300 rounded font-mono text-sm resize-y focus:outline-none focus:ring-2 focus:ring-blue-500"
+              />
+            </div>
+            <div>
+              <label className="block text-sm font-medium text-gray-700 mb-1">Import paths, one per line</label>
+              <textarea
+                value={importPaths}
+                onChange={(e) => setImportPaths(e.target.value)}
+                placeholder="/path/to/protos"
+                className="w-full h-20 p-2 border border-gray-300 rounded font-mono text-sm resize-y focus:outline-none focus:ring-2 focus:ring-blue-500"
+              />
+            </div>
+          </>
+        )}
+      </div>
+
+      {/* Live stream */}
+      {isStreaming && streamed.length > 0 && (
+        <div className="border-t border-gray-200 max-h-64 overflow-auto p-4 space-y-2">
+          <h3 className="text-sm font-medium text-gray-700">Stream ({streamed.length})</h3>
+          {streamed.map((message, index) => (
+            <div key={index} className="flex items-start gap-2 text-xs">
+              {message.direction === 'sent'
+                ? <ArrowUpRight className="h-3 w-3 text-blue-600 flex-shrink-0 mt-0.5" />
+                : <ArrowDownLeft className="h-3 w-3 text-green-600 flex-shrink-0 mt-0.5" />}
+              <pre className="font-mono text-gray-800 whitespace-pre-wrap break-all">{message.data}</pre>
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  );
+};
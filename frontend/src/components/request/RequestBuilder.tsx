@@ -1,12 +1,13 @@
 import React from 'react';
-import { Play, Save, Copy, MoreHorizontal } from 'lucide-react';
+import { Play, Square, Save, Copy, MoreHorizontal } from 'lucide-react';
 import { Button, Input, Select, Tabs, VariablePreview, VariableInput, Toast } from '@/components/ui';
 import { useUIStore, useAPIStore } from '@/store';
-import { cn, getMethodColor, applyEnvironmentVariables } from '@/utils';
+import { cn, getMethodColor, parseGraphQLBody, isGraphQLSubscription, requestBodyType } from '@/utils';
 import { ParamsTab } from './tabs/ParamsTab';
 import { AuthTab } from './tabs/AuthTab';
 import { HeadersTab } from './tabs/HeadersTab';
 import { BodyTab } from './tabs/BodyTab';
+import { SettingsTab } from './tabs/SettingsTab';
 import { TestsTab } from './tabs/TestsTab';
 import { SaveRequestModal } from '@/components/modals/SaveRequestModal';
 import type { HTTPMethod, Request, APIResponse } from '@/types';
@@ -37,9 +38,10 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
   className,
 }) => {
   const { activeTab, setActiveTab, activeRequest, setActiveRequest, updateActiveRequestField } = useUIStore();
-  const { executeRequest, environments, createRequest, updateRequest } = useAPIStore();
+  const { executeRequest, executeGraphQLSubscription, cancelRequest, environments, createRequest, updateRequest } = useAPIStore();
   
   const [isExecutingRequest, setIsExecutingRequest] = React.useState(false);
+  const [executionId, setExecutionId] = React.useState<string>();
   const [showSaveRequestModal, setShowSaveRequestModal] = React.useState(false);
   const [toast, setToast] = React.useState<{
     message: string;
@@ -129,7 +131,11 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
           currentRequest.headers,
           currentRequest.body,
           collectionId,
-          folderId
+          folderId,
+          currentRequest.auth,
+          currentRequest.type,
+          currentRequest.body_type,
+          currentRequest.settings
         );
         setActiveRequest(newRequest);
         console.log('✅ Request created successfully:', newRequest);
@@ -143,7 +149,11 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
           currentRequest.headers,
           currentRequest.body,
           collectionId,
-          folderId
+          folderId,
+          currentRequest.auth,
+          currentRequest.type,
+          currentRequest.body_type,
+          currentRequest.settings
         );
         setActiveRequest(updatedRequest);
         console.log('✅ Request updated successfully:', updatedRequest);
@@ -156,23 +166,32 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
   const handleSendRequest = async () => {
     if (isExecutingRequest) return;
 
+    const id = crypto.randomUUID();
+    setExecutionId(id);
     setIsExecutingRequest(true);
     onExecutionStart?.();
     
     try {
-      // Apply environment variables to request data
-      const processedRequest = applyEnvironmentVariables({
-        url: currentRequest.url,
-        headers: currentRequest.headers,
-        body: currentRequest.body,
-      }, environments);
+      const options = {
+        executionId: id,
+        collectionId: currentRequest.collection_id,
+        requestId: currentRequest.id > 0 ? currentRequest.id : undefined,
+        settings: currentRequest.settings,
+      };
 
-      const response = await executeRequest(
-        currentRequest.method,
-        processedRequest.url,
-        processedRequest.headers,
-        processedRequest.body
-      );
+      // Variables are resolved by the backend, which reports the ones left unresolved
+      const bodyType = requestBodyType(currentRequest);
+      const graphql = bodyType === 'graphql' ? parseGraphQLBody(currentRequest.body) : undefined;
+      const response = graphql && isGraphQLSubscription(graphql.query)
+        ? await executeGraphQLSubscription(currentRequest.url, currentRequest.headers, graphql, currentRequest.auth, options)
+        : await executeRequest(
+            currentRequest.method,
+            currentRequest.url,
+            currentRequest.headers,
+            currentRequest.body,
+            currentRequest.auth,
+            { ...options, graphql, bodyType }
+          );
       
       onResponseUpdate?.(response);
     } catch (error) {
@@ -180,6 +199,18 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
       // TODO: Show error toast
     } finally {
       setIsExecutingRequest(false);
+      setExecutionId(undefined);
+    }
+  };
+
+  // Stops the execution in flight, e.g. to end an event stream
+  const handleStopRequest = async () => {
+    if (!executionId) return;
+
+    try {
+      await cancelRequest(executionId);
+    } catch (error) {
+      console.error('Failed to stop request:', error);
     }
   };
 
@@ -202,6 +233,10 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
       id: 'body' as const,
       label: 'Body',
     },
+    {
+      id: 'settings' as const,
+      label: 'Settings',
+    },
     {
       id: 'tests' as const,
       label: 'Tests',
@@ -263,15 +298,25 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
             </div>
 
             <div className="flex-shrink-0">
-              <Button
-                variant="primary"
-                loading={isExecutingRequest}
-                icon={<Play className="h-4 w-4" />}
-                onClick={handleSendRequest}
-                className="!px-6"
-              >
-                Send
-              </Button>
+              {isExecutingRequest ? (
+                <Button
+                  variant="secondary"
+                  icon={<Square className="h-4 w-4" />}
+                  onClick={handleStopRequest}
+                  className="!px-6"
+                >
+                  Stop
+                </Button>
+              ) : (
+                <Button
+                  variant="primary"
+                  icon={<Play className="h-4 w-4" />}
+                  onClick={handleSendRequest}
+                  className="!px-6"
+                >
+                  Send
+                </Button>
+              )}
             </div>
           </div>
 
@@ -302,6 +347,7 @@ export const RequestBuilder: React.FC<RequestBuilderProps> = ({
         {activeTab === 'auth' && <AuthTab />}
         {activeTab === 'headers' && <HeadersTab />}
         {activeTab === 'body' && <BodyTab />}
+        {activeTab === 'settings' && <SettingsTab />}
         {activeTab === 'tests' && <TestsTab />}
       </div>
 
@@ -42,8 +42,11 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
     // TODO: Show success toast
   };
 
+  const isBase64Body = response.bodyEncoding === 'base64';
+
   const handleDownloadResponse = () => {
-    const blob = new Blob([response.body], { type: response.contentType });
+    const content = isBase64Body ? Uint8Array.from(atob(response.body), c => c.charCodeAt(0)) : response.body;
+    const blob = new Blob([content], { type: response.contentType });
     const url = URL.createObjectURL(blob);
     const a = document.createElement('a');
     a.href = url;
@@ -59,7 +62,8 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
     if (contentType.includes('xml')) return 'xml';
     if (contentType.includes('html')) return 'html';
     if (contentType.includes('text')) return 'txt';
-    return 'txt';
+    if (contentType.startsWith('image/')) return contentType.slice('image/'.length).split(/[;+]/)[0];
+    return response.isBinary ? 'bin' : 'txt';
   };
 
   const parseResponseBody = () => {
@@ -72,7 +76,7 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
 
   const isJsonResponse = response.contentType.includes('application/json');
   const parsedJson = isJsonResponse ? parseResponseBody() : null;
-  const responseSize = new Blob([response.body]).size;
+  const responseSize = response.bodySize ?? new Blob([response.body]).size;
 
   const tabItems = [
     { id: 'body' as const, label: 'Body', badge: formatFileSize(responseSize) },
@@ -101,6 +105,17 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
             )}>
               {response.statusText}
             </div>
+            {response.grpcStatus && (
+              <div
+                className={cn(
+                  'px-2 py-1 rounded text-sm font-medium flex-shrink-0',
+                  response.grpcStatus.code === 0 ? 'text-green-600 bg-green-50' : 'text-red-600 bg-red-50'
+                )}
+                title={response.grpcStatus.message}
+              >
+                gRPC {response.grpcStatus.code} {response.grpcStatus.name}
+              </div>
+            )}
           </div>
 
           <div className="flex items-center gap-2 flex-shrink-0 ml-2">
@@ -116,6 +131,7 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
               variant="ghost"
               icon={<Download className="h-4 w-4" />}
               onClick={handleDownloadResponse}
+              disabled={!!response.savedTo}
               title="Download Response"
             />
             <Button
@@ -130,7 +146,16 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
 
         {/* Response Stats */}
         <div className="flex items-center gap-4 text-sm text-gray-600 flex-wrap min-w-0">
-          <div className="flex items-center gap-1 flex-shrink-0">
+          <div
+            className="flex items-center gap-1 flex-shrink-0"
+            title={response.timings && [
+              `DNS lookup: ${formatResponseTime(Math.round(response.timings.dns_lookup))}`,
+              `TCP connect: ${formatResponseTime(Math.round(response.timings.tcp_connect))}`,
+              `TLS handshake: ${formatResponseTime(Math.round(response.timings.tls_handshake))}`,
+              `First byte: ${formatResponseTime(Math.round(response.timings.first_byte))}`,
+              `Download: ${formatResponseTime(Math.round(response.timings.download))}`,
+            ].join('\n')}
+          >
             <span className="font-medium">Time:</span>
             <span>{formatResponseTime(response.responseTime)}</span>
           </div>
@@ -143,6 +168,65 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
             <span className="truncate">{response.contentType}</span>
           </div>
         </div>
+
+        {/* Bodies cut at the maximum response size or streamed to a file */}
+        {response.truncated && (
+          <div className="mt-3 p-2 bg-yellow-50 border border-yellow-200 rounded-lg text-xs text-yellow-800">
+            <span className="font-medium">Body truncated:</span> only the first {formatFileSize(responseSize)} were kept,
+            raise the maximum response size or save the body to a file to get all of it
+          </div>
+        )}
+        {response.savedTo && (
+          <div className="mt-3 p-2 bg-blue-50 border border-blue-200 rounded-lg text-xs text-blue-800">
+            <span className="font-medium">Body saved to</span>{' '}
+            <span className="font-mono break-all">{response.savedTo}</span>
+          </div>
+        )}
+
+        {/* Variables no environment defines, sent as written */}
+        {response.unresolvedVariables && (
+          <div className="mt-3 p-2 bg-yellow-50 border border-yellow-200 rounded-lg text-xs text-yellow-800">
+            <span className="font-medium">Unresolved variables:</span>{' '}
+            <span className="font-mono">{response.unresolvedVariables.map(name => `{{${name}}}`).join(', ')}</span>
+          </div>
+        )}
+
+        {/* GraphQL errors, which usually come with a 200 status */}
+        {response.graphqlErrors && response.graphqlErrors.length > 0 && (
+          <div className="mt-3 p-2 bg-red-50 border border-red-200 rounded-lg text-xs text-red-700 space-y-1">
+            <div className="font-medium">
+              {response.graphqlErrors.length === 1 ? 'GraphQL error' : `${response.graphqlErrors.length} GraphQL errors`}
+            </div>
+            {response.graphqlErrors.map((error, index) => (
+              <div key={index} className="font-mono break-words">
+                {error.message}
+                {error.path && error.path.length > 0 && (
+                  <span className="text-red-500"> at {error.path.join('.')}</span>
+                )}
+                {error.locations && error.locations.length > 0 && (
+                  <span className="text-red-500"> ({error.locations.map(l => `${l.line}:${l.column}`).join(', ')})</span>
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+
+        {/* SOAP fault */}
+        {response.soapFault && (
+          <div className="mt-3 p-2 bg-red-50 border border-red-200 rounded-lg text-xs text-red-700 space-y-1">
+            <div className="font-medium">
+              SOAP {response.soapFault.version} fault: {[response.soapFault.code, ...(response.soapFault.subcodes ?? [])].filter(Boolean).join(' / ')}
+            </div>
+            {response.soapFault.reason && <div>{response.soapFault.reason}</div>}
+            {response.soapFault.actor && <div className="text-red-500">Actor: {response.soapFault.actor}</div>}
+            {response.soapFault.node && <div className="text-red-500">Node: {response.soapFault.node}</div>}
+            {response.soapFault.detail && (
+              <pre className="font-mono whitespace-pre-wrap break-words bg-white border border-red-100 rounded p-1">
+                {response.soapFault.detail}
+              </pre>
+            )}
+          </div>
+        )}
       </div>
 
       {/* Response Tabs */}
@@ -186,6 +270,13 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
                     }}
                   />
                 </div>
+              ) : responseBodyTab === 'raw' && (isBase64Body || response.savedTo) ? (
+                <div className="flex items-center justify-center h-full text-gray-500">
+                  <div className="text-center">
+                    <p>{response.savedTo ? 'The body was saved to a file' : 'Binary body'} ({formatFileSize(responseSize)})</p>
+                    {isBase64Body && <p className="text-sm">Use Preview or Download to see it</p>}
+                  </div>
+                </div>
               ) : responseBodyTab === 'raw' ? (
                 <div className="h-full">
                   <Editor
@@ -215,7 +306,9 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
                   ) : response.contentType.includes('image/') ? (
                     <div className="flex items-center justify-center h-full overflow-auto">
                       <img
-                        src={`data:${response.contentType};base64,${btoa(response.body)}`}
+                        src={isBase64Body
+                          ? `data:${response.contentType};base64,${response.body}`
+                          : `data:${response.contentType},${encodeURIComponent(response.body)}`}
                         alt="Response preview"
                         className="max-w-full max-h-full"
                       />
@@ -240,6 +333,12 @@ export const ResponseViewer: React.FC<ResponseViewerProps> = ({ response }) => {
         {responseTab === 'headers' && (
           <div className="flex-1 flex flex-col min-h-0 p-4">
             <HeadersTable headers={JSON.parse(response.headers)} />
+            {response.trailers && Object.keys(JSON.parse(response.trailers)).length > 0 && (
+              <>
+                <h4 className="text-sm font-medium text-gray-700 mt-4 mb-2">Trailers</h4>
+                <HeadersTable headers={JSON.parse(response.trailers)} />
+              </>
+            )}
           </div>
         )}
 
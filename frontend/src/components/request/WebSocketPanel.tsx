@@ -0,0 +1,319 @@
+import React from 'react';
+import { Events } from '@wailsio/runtime';
+import { Plug, Unplug, Send, Activity, Save, Trash2, ArrowUpRight, ArrowDownLeft } from 'lucide-react';
+import { Button, Input, Select, VariableInput, VariablePreview } from '@/components/ui';
+import { useAPIStore } from '@/store';
+import { cn } from '@/utils';
+import type { Request, WebSocketClosed, WebSocketMessage } from '@/types';
+
+// The backend sends this header's subprotocols itself, so they're edited apart from the other headers
+const SUBPROTOCOL_HEADER = 'Sec-WebSocket-Protocol';
+
+const MESSAGE_TYPES = [
+  { value: 'text', label: 'Text' },
+  { value: 'binary', label: 'Binary (base64)' },
+];
+
+type ConnectionStatus = 'disconnected' | 'connecting' | 'connected' | 'closing';
+
+interface WebSocketPanelProps {
+  request: Request;
+  onRequestUpdate?: (request: Request) => void;
+  className?: string;
+}
+
+const parseHeaders = (headers: string): Record<string, string> => {
+  try {
+    return headers ? JSON.parse(headers) : {};
+  } catch {
+    return {};
+  }
+};
+
+export const WebSocketPanel: React.FC<WebSocketPanelProps> = ({
+  request,
+  onRequestUpdate,
+  className,
+}) => {
+  const { environments, connectWebSocket, sendWebSocketMessage, pingWebSocket, closeWebSocket, updateRequest } = useAPIStore();
+
+  const [url, setUrl] = React.useState(request.url);
+  const [subprotocols, setSubprotocols] = React.useState(parseHeaders(request.headers)[SUBPROTOCOL_HEADER] || '');
+  const [status, setStatus] = React.useState<ConnectionStatus>('disconnected');
+  const [sessionId, setSessionId] = React.useState<string>();
+  const [subprotocol, setSubprotocol] = React.useState('');
+  const [messageType, setMessageType] = React.useState<'text' | 'binary'>('text');
+  const [draft, setDraft] = React.useState('');
+  const [messages, setMessages] = React.useState<WebSocketMessage[]>([]);
+  const [error, setError] = React.useState<string>();
+
+  // Messages and the end of the session are pushed by the backend as they happen
+  React.useEffect(() => {
+    if (!sessionId) return;
+
+    const offMessage = Events.On('websocket:message', (event) => {
+      const [message] = event.data as WebSocketMessage[];
+      if (message.session_id !== sessionId) return;
+      setMessages(current => [...current, message]);
+    });
+    const offClosed = Events.On('websocket:closed', (event) => {
+      const [closed] = event.data as WebSocketClosed[];
+      if (closed.session_id !== sessionId) return;
+      setStatus('disconnected');
+      setSessionId(undefined);
+      if (closed.error) setError(closed.error);
+    });
+
+    return () => {
+      offMessage();
+      offClosed();
+    };
+  }, [sessionId]);
+
+  // Leaving the tab ends its session
+  const sessionRef = React.useRef<string>();
+  sessionRef.current = sessionId;
+  React.useEffect(() => () => {
+    if (sessionRef.current) closeWebSocket(sessionRef.current).catch(() => {});
+  }, [closeWebSocket]);
+
+  const requestHeaders = () => {
+    const headers = parseHeaders(request.headers);
+    delete headers[SUBPROTOCOL_HEADER];
+    if (subprotocols.trim()) headers[SUBPROTOCOL_HEADER] = subprotocols.trim();
+    return JSON.stringify(headers);
+  };
+
+  const handleConnect = async () => {
+    const id = crypto.randomUUID();
+    setError(undefined);
+    setMessages([]);
+    setStatus('connecting');
+    setSessionId(id);
+
+    try {
+      const headers = parseHeaders(requestHeaders());
+      const offered = (headers[SUBPROTOCOL_HEADER] || '').split(',').map(p => p.trim()).filter(Boolean);
+      delete headers[SUBPROTOCOL_HEADER];
+
+      const session = await connectWebSocket(id, url, JSON.stringify(headers), offered, request.id > 0 ? request.id : undefined);
+      setSubprotocol(session.subprotocol);
+      setStatus('connected');
+    } catch (err) {
+      setError(String(err));
+      setStatus('disconnected');
+      setSessionId(undefined);
+    }
+  };
+
+  const handleDisconnect = async () => {
+    if (!sessionId) return;
+
+    setStatus('closing');
+    try {
+      await closeWebSocket(sessionId);
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const handleSend = async () => {
+    if (!sessionId || !draft) return;
+
+    try {
+      await sendWebSocketMessage(sessionId, messageType, draft);
+      setDraft('');
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const handlePing = async () => {
+    if (!sessionId) return;
+
+    try {
+      await pingWebSocket(sessionId);
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const handleSave = async () => {
+    if (request.id <= 0) return;
+
+    try {
+      const saved = await updateRequest(
+        request.id,
+        request.name,
+        request.method,
+        url,
+        requestHeaders(),
+        request.body,
+        request.collection_id,
+        request.folder_id,
+        request.auth,
+        'websocket',
+        undefined,
+        request.settings
+      );
+      onRequestUpdate?.(saved);
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const isOpen = status === 'connected' || status === 'closing';
+
+  return (
+    <div className={cn('flex-1 flex flex-col bg-white min-h-0', className)}>
+      {/* Connection */}
+      <div className="p-4 border-b border-gray-200 space-y-3">
+        <div className="flex items-center justify-between">
+          <h2 className="text-lg font-semibold text-gray-900">{request.name}</h2>
+          <Button
+            size="sm"
+            variant="ghost"
+            icon={<Save className="h-4 w-4" />}
+            title="Save Request"
+            onClick={handleSave}
+            disabled={request.id <= 0}
+          />
+        </div>
+
+        <div className="flex items-start gap-2">
+          <div className="flex-1 min-w-0">
+            <VariableInput
+              value={url}
+              onChange={setUrl}
+              placeholder="wss://api.example.com/socket"
+              className="font-mono text-sm"
+              disabled={status !== 'disconnected'}
+            />
+          </div>
+          <div className="flex-shrink-0">
+            {status === 'disconnected' ? (
+              <Button
+                variant="primary"
+                icon={<Plug className="h-4 w-4" />}
+                onClick={handleConnect}
+                disabled={!url.trim()}
+                className="!px-6"
+              >
+                Connect
+              </Button>
+            ) : (
+              <Button
+                variant="secondary"
+                icon={<Unplug className="h-4 w-4" />}
+                onClick={handleDisconnect}
+                loading={status === 'connecting' || status === 'closing'}
+                className="!px-6"
+              >
+                Disconnect
+              </Button>
+            )}
+          </div>
+        </div>
+
+        <VariablePreview text={url} environments={environments} label="URL with variables:" />
+
+        <Input
+          value={subprotocols}
+          onChange={(e) => setSubprotocols(e.target.value)}
+          placeholder="Subprotocols, comma separated (optional)"
+          disabled={status !== 'disconnected'}
+        />
+
+        <div className="flex items-center gap-2 text-xs text-gray-500">
+          <span className={cn(
+            'w-2 h-2 rounded-full',
+            status === 'connected' ? 'bg-green-500' : status === 'disconnected' ? 'bg-gray-300' : 'bg-yellow-400'
+          )} />
+          <span className="capitalize">{status}</span>
+          {isOpen && subprotocol && <span>• subprotocol {subprotocol}</span>}
+        </div>
+
+        {error && (
+          <div className="text-sm text-red-600 bg-red-50 border border-red-200 rounded p-2">{error}</div>
+        )}
+      </div>
+
+      {/* Message log */}
+      <div className="flex items-center justify-between px-4 py-2 border-b border-gray-200 bg-gray-50">
+        <h3 className="text-sm font-medium text-gray-700">Messages ({messages.length})</h3>
+        <Button
+          size="sm"
+          variant="ghost"
+          icon={<Trash2 className="h-4 w-4" />}
+          title="Clear messages"
+          onClick={() => setMessages([])}
+        />
+      </div>
+      <div className="flex-1 overflow-auto p-4 space-y-2 min-h-0">
+        {messages.length === 0 ? (
+          <div className="text-sm text-gray-400 text-center py-8">No messages yet</div>
+        ) : messages.map((message, index) => (
+          <div
+            key={index}
+            className={cn(
+              'flex items-start gap-2 p-2 rounded border text-sm',
+              message.direction === 'sent' ? 'bg-blue-50 border-blue-100' : 'bg-gray-50 border-gray-200'
+            )}
+          >
+            {message.direction === 'sent'
+              ? <ArrowUpRight className="h-4 w-4 text-blue-600 flex-shrink-0 mt-0.5" />
+              : <ArrowDownLeft className="h-4 w-4 text-green-600 flex-shrink-0 mt-0.5" />}
+            <div className="min-w-0 flex-1">
+              <div className="flex items-center gap-2 text-xs text-gray-500">
+                <span className="font-medium uppercase">{message.type}</span>
+                {message.type === 'close' && <span>{message.close_code}</span>}
+                <span>{new Date(message.time).toLocaleTimeString()}</span>
+              </div>
+              {message.data && (
+                <pre className="font-mono text-xs text-gray-800 whitespace-pre-wrap break-all mt-1">{message.data}</pre>
+              )}
+            </div>
+          </div>
+        ))}
+      </div>
+
+      {/* Composer */}
+      <div className="p-4 border-t border-gray-200 space-y-2">
+        <textarea
+          value={draft}
+          onChange={(e) => setDraft(e.target.value)}
+          placeholder={messageType === 'binary' ? 'Base64 encoded data' : 'Message'}
+          className="w-full h-24 p-2 border border-gray-300 rounded font-mono text-sm resize-none focus:outline-none focus:ring-2 focus:ring-blue-500"
+          disabled={status !== 'connected'}
+        />
+        <div className="flex items-center justify-between gap-2">
+          <div className="w-40">
+            <Select
+              options={MESSAGE_TYPES}
+              value={messageType}
+              onChange={(value) => setMessageType(value as 'text' | 'binary')}
+            />
+          </div>
+          <div className="flex items-center gap-2">
+            <Button
+              variant="ghost"
+              icon={<Activity className="h-4 w-4" />}
+              onClick={handlePing}
+              disabled={status !== 'connected'}
+            >
+              Ping
+            </Button>
+            <Button
+              variant="primary"
+              icon={<Send className="h-4 w-4" />}
+              onClick={handleSend}
+              disabled={status !== 'connected' || !draft}
+            >
+              Send
+            </Button>
+          </div>
+        </div>
+      </div>
+    </div>
+  );
+};
@@ -1,9 +1,11 @@
 import React from 'react';
-import { Select, VariablePreview } from '@/components/ui';
+import { RefreshCw } from 'lucide-react';
+import { Button, Input, Select, VariablePreview } from '@/components/ui';
 import { useUIStore, useAPIStore } from '@/store';
-import { cn } from '@/utils';
-import type { BodyType } from '@/types';
-import Editor from '@monaco-editor/react';
+import { cn, isFormBodyType, parseFormFields, parseGraphQLBody, requestBodyType } from '@/utils';
+import type { BodyType, FormField, GraphQLRequestBody, GraphQLSchema } from '@/types';
+import Editor, { type Monaco } from '@monaco-editor/react';
+import { Dialogs } from '@wailsio/runtime';
 
 const BODY_TYPES: { value: BodyType; label: string }[] = [
   { value: 'none', label: 'None' },
@@ -13,6 +15,7 @@ const BODY_TYPES: { value: BodyType; label: string }[] = [
   { value: 'x-www-form-urlencoded', label: 'URL Encoded' },
   { value: 'raw', label: 'Raw Text' },
   { value: 'binary', label: 'Binary' },
+  { value: 'graphql', label: 'GraphQL' },
 ];
 
 // const CONTENT_TYPE_MAP: Record<BodyType, string> = {
@@ -30,12 +33,17 @@ export const BodyTab: React.FC = () => {
   const { environments } = useAPIStore();
   
   // Use store values directly instead of local state
-  const bodyType = activeRequest?.bodyType || 'none';
   const bodyContent = activeRequest?.body || '';
+  const bodyType = activeRequest ? requestBodyType(activeRequest) : 'none';
 
   const setBodyType = (newType: BodyType) => {
     if (activeRequest) {
-      updateActiveRequestField('bodyType', newType);
+      updateActiveRequestField('body_type', newType);
+      if (newType === 'graphql') {
+        updateActiveRequestField('body', JSON.stringify(parseGraphQLBody(bodyContent)));
+      } else if (isFormBodyType(newType)) {
+        updateActiveRequestField('body', JSON.stringify(parseFormFields(bodyContent)));
+      }
       
       // Auto-update Content-Type header
       if (newType !== 'none') {
@@ -352,6 +360,9 @@ export const BodyTab: React.FC = () => {
       case 'x-www-form-urlencoded':
         return <FormDataEditor bodyType={bodyType} />;
 
+      case 'graphql':
+        return <GraphQLEditor />;
+
       case 'binary':
         return (
           <div className="flex-1 flex flex-col">
@@ -439,29 +450,40 @@ export const BodyTab: React.FC = () => {
 };
 
 // Form Data Editor Component
+const emptyFormField: FormField = { key: '', value: '', type: 'text', file_path: '', content_type: '', enabled: true };
+
 const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded' }> = ({ bodyType }) => {
-  const [formFields, setFormFields] = React.useState([
-    { id: '1', key: '', value: '', type: 'text' as 'text' | 'file', enabled: true }
-  ]);
+  const { activeRequest, updateActiveRequestField } = useUIStore();
+
+  // The fields are kept in the body, as JSON
+  const savedFields = parseFormFields(activeRequest?.body || '');
+  const formFields = savedFields.length > 0 ? savedFields : [emptyFormField];
+  const setFormFields = (fields: FormField[]) => {
+    updateActiveRequestField('body', JSON.stringify(fields));
+  };
 
   const addField = () => {
-    setFormFields(prev => [...prev, {
-      id: Date.now().toString(),
-      key: '',
-      value: '',
-      type: 'text',
-      enabled: true,
-    }]);
+    setFormFields([...formFields, emptyFormField]);
+  };
+
+  const updateField = <K extends keyof FormField>(index: number, field: K, value: FormField[K]) => {
+    setFormFields(formFields.map((f, i) => i === index ? { ...f, [field]: value } : f));
   };
 
-  const updateField = (id: string, field: string, value: any) => {
-    setFormFields(prev => prev.map(f => 
-      f.id === id ? { ...f, [field]: value } : f
-    ));
+  const removeField = (index: number) => {
+    setFormFields(formFields.filter((_, i) => i !== index));
   };
 
-  const removeField = (id: string) => {
-    setFormFields(prev => prev.filter(f => f.id !== id));
+  // The backend reads the file when the request is sent, so only its path is kept
+  const chooseFile = async (index: number) => {
+    const path: string = await Dialogs.OpenFile({
+      Title: 'Choose the file to upload',
+      CanChooseFiles: true,
+      CanChooseDirectories: false,
+    });
+    if (path) {
+      updateField(index, 'file_path', path);
+    }
   };
 
   return (
@@ -490,9 +512,9 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
         </div>
 
         {/* Form Fields */}
-        {formFields.map((field) => (
+        {formFields.map((field, index) => (
           <div
-            key={field.id}
+            key={index}
             className={cn(
               'grid gap-3 items-center py-2 px-3 rounded-lg border transition-colors',
               bodyType === 'form-data' ? 'grid-cols-12' : 'grid-cols-10',
@@ -506,7 +528,7 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
               <input
                 type="checkbox"
                 checked={field.enabled}
-                onChange={(e) => updateField(field.id, 'enabled', e.target.checked)}
+                onChange={(e) => updateField(index, 'enabled', e.target.checked)}
                 className="rounded border-gray-300 text-primary-600 focus:ring-primary-500"
               />
             </div>
@@ -516,7 +538,7 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
               <input
                 type="text"
                 value={field.key}
-                onChange={(e) => updateField(field.id, 'key', e.target.value)}
+                onChange={(e) => updateField(index, 'key', e.target.value)}
                 placeholder="Field name"
                 className="w-full px-3 py-2 border border-gray-200 rounded-lg text-sm focus:outline-none focus:ring-2 focus:ring-primary-500/20 focus:border-primary-500"
                 disabled={!field.enabled}
@@ -526,17 +548,26 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
             {/* Value */}
             <div className="col-span-4">
               {field.type === 'file' ? (
-                <input
-                  type="file"
-                  onChange={(e) => updateField(field.id, 'value', e.target.files?.[0])}
-                  className="w-full text-sm text-gray-500 file:mr-4 file:py-2 file:px-4 file:rounded-lg file:border-0 file:text-sm file:bg-gray-50 file:text-gray-700"
-                  disabled={!field.enabled}
-                />
+                <div className="flex items-center gap-2">
+                  <button
+                    type="button"
+                    onClick={() => chooseFile(index)}
+                    className="flex-shrink-0 px-3 py-2 border border-gray-200 rounded-lg text-sm bg-white hover:bg-gray-50 transition-colors"
+                    disabled={!field.enabled}
+                  >
+                    Choose file
+                  </button>
+                  <span className="min-w-0 truncate text-sm font-mono text-gray-700" title={field.file_path}>
+                    {field.file_path
+                      ? field.file_path.split(/[\\/]/).pop()
+                      : <span className="font-sans text-gray-400">No file chosen</span>}
+                  </span>
+                </div>
               ) : (
                 <input
                   type="text"
                   value={field.value}
-                  onChange={(e) => updateField(field.id, 'value', e.target.value)}
+                  onChange={(e) => updateField(index, 'value', e.target.value)}
                   placeholder="Field value"
                   className="w-full px-3 py-2 border border-gray-200 rounded-lg text-sm focus:outline-none focus:ring-2 focus:ring-primary-500/20 focus:border-primary-500"
                   disabled={!field.enabled}
@@ -549,7 +580,7 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
               <div className="col-span-2">
                 <select
                   value={field.type}
-                  onChange={(e) => updateField(field.id, 'type', e.target.value)}
+                  onChange={(e) => updateField(index, 'type', e.target.value as FormField['type'])}
                   className="w-full px-3 py-2 border border-gray-200 rounded-lg text-sm focus:outline-none focus:ring-2 focus:ring-primary-500/20 focus:border-primary-500"
                   disabled={!field.enabled}
                 >
@@ -562,7 +593,7 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
             {/* Delete */}
             <div className="col-span-1 flex justify-end">
               <button
-                onClick={() => removeField(field.id)}
+                onClick={() => removeField(index)}
                 className="p-1 rounded hover:bg-red-50 hover:text-red-600 transition-colors text-gray-400"
               >
                 <svg className="h-4 w-4" fill="none" stroke="currentColor" viewBox="0 0 24 24">
@@ -575,4 +606,199 @@ const FormDataEditor: React.FC<{ bodyType: 'form-data' | 'x-www-form-urlencoded'
       </div>
     </div>
   );
-};
\ No newline at end of file
+};
+
+type SchemaType = {
+  kind: string;
+  name: string;
+  description?: string;
+  fields?: { name: string; description?: string; type: SchemaTypeRef }[];
+};
+type SchemaTypeRef = { kind: string; name?: string; ofType?: SchemaTypeRef };
+
+const formatTypeRef = (ref: SchemaTypeRef): string => {
+  if (ref.kind === 'NON_NULL' && ref.ofType) return `${formatTypeRef(ref.ofType)}!`;
+  if (ref.kind === 'LIST' && ref.ofType) return `[${formatTypeRef(ref.ofType)}]`;
+  return ref.name || '';
+};
+
+// Completion items built from the types and fields of an introspected schema
+const schemaSuggestions = (schema: GraphQLSchema, monaco: Monaco) => {
+  let types: SchemaType[] = [];
+  try {
+    types = JSON.parse(schema.schema).__schema?.types ?? [];
+  } catch {
+    return [];
+  }
+
+  const suggestions = new Map<string, { label: string; kind: number; detail: string; documentation?: string }>();
+  for (const type of types) {
+    if (!type.name || type.name.startsWith('__')) continue;
+    suggestions.set(type.name, {
+      label: type.name,
+      kind: monaco.languages.CompletionItemKind.Class,
+      detail: type.kind.toLowerCase(),
+      documentation: type.description,
+    });
+    for (const field of type.fields ?? []) {
+      if (suggestions.has(field.name)) continue;
+      suggestions.set(field.name, {
+        label: field.name,
+        kind: monaco.languages.CompletionItemKind.Field,
+        detail: `${type.name}.${field.name}: ${formatTypeRef(field.type)}`,
+        documentation: field.description,
+      });
+    }
+  }
+  return [...suggestions.values()];
+};
+
+// GraphQL Editor Component
+const GraphQLEditor: React.FC = () => {
+  const { activeRequest, updateActiveRequestField } = useUIStore();
+  const { environments, introspectGraphQL } = useAPIStore();
+
+  const graphql = parseGraphQLBody(activeRequest?.body || '');
+  const [schema, setSchema] = React.useState<GraphQLSchema>();
+  const [schemaError, setSchemaError] = React.useState<string>();
+  const [isFetchingSchema, setIsFetchingSchema] = React.useState(false);
+  const [monaco, setMonaco] = React.useState<Monaco>();
+
+  const update = (field: keyof GraphQLRequestBody, value: string) => {
+    updateActiveRequestField('body', JSON.stringify({ ...graphql, [field]: value }));
+  };
+
+  // Schemas are cached by the backend, so only refreshing queries the endpoint again once fetched
+  const fetchSchema = async (refresh: boolean) => {
+    if (!activeRequest?.url) return;
+
+    setIsFetchingSchema(true);
+    setSchemaError(undefined);
+    try {
+      setSchema(await introspectGraphQL(activeRequest.url, activeRequest.headers, activeRequest.auth, refresh));
+    } catch (error) {
+      setSchemaError(error instanceof Error ? error.message : String(error));
+    } finally {
+      setIsFetchingSchema(false);
+    }
+  };
+
+  React.useEffect(() => {
+    if (!monaco || !schema) return;
+
+    const suggestions = schemaSuggestions(schema, monaco);
+    const provider = monaco.languages.registerCompletionItemProvider('graphql', {
+      provideCompletionItems: (model, position) => {
+        const word = model.getWordUntilPosition(position);
+        const range = {
+          startLineNumber: position.lineNumber,
+          endLineNumber: position.lineNumber,
+          startColumn: word.startColumn,
+          endColumn: word.endColumn,
+        };
+        return { suggestions: suggestions.map(suggestion => ({ ...suggestion, insertText: suggestion.label, range })) };
+      },
+    });
+    return () => provider.dispose();
+  }, [monaco, schema]);
+
+  const editorOptions = {
+    minimap: { enabled: false },
+    fontSize: 14,
+    lineNumbers: 'on' as const,
+    scrollBeyondLastLine: false,
+    wordWrap: 'on' as const,
+    automaticLayout: true,
+  };
+
+  return (
+    <div className="flex-1 flex flex-col min-h-0 gap-2">
+      {/* Compact toolbar */}
+      <div className="flex-shrink-0 p-2 bg-gray-50 border border-gray-200 rounded-lg flex items-center justify-between gap-2">
+        <span className="text-xs font-medium text-gray-700">
+          GraphQL Query
+          {schema && (
+            <span className="ml-2 font-normal text-gray-500">
+              schema fetched {new Date(schema.fetched_at).toLocaleString()}
+            </span>
+          )}
+        </span>
+        <div className="flex gap-1">
+          <Button
+            size="sm"
+            variant="secondary"
+            onClick={() => fetchSchema(false)}
+            loading={isFetchingSchema}
+            disabled={!activeRequest?.url}
+          >
+            {schema ? 'Schema loaded' : 'Fetch schema'}
+          </Button>
+          <Button
+            size="sm"
+            variant="ghost"
+            icon={<RefreshCw className="h-4 w-4" />}
+            onClick={() => fetchSchema(true)}
+            disabled={!activeRequest?.url || isFetchingSchema}
+            title="Fetch the schema again"
+          />
+        </div>
+      </div>
+
+      {schemaError && (
+        <div className="flex-shrink-0 p-2 bg-red-50 border border-red-200 rounded-lg text-xs text-red-700">
+          {schemaError}
+        </div>
+      )}
+
+      <div className="flex-1 border border-gray-200 rounded-lg overflow-hidden">
+        <Editor
+          height="260px"
+          defaultLanguage="graphql"
+          value={graphql.query}
+          onChange={(value) => update('query', value || '')}
+          onMount={(_, monacoInstance) => setMonaco(monacoInstance)}
+          theme="vs"
+          options={editorOptions}
+        />
+      </div>
+
+      <div className="flex-shrink-0">
+        <span className="text-xs font-medium text-gray-700">Variables</span>
+        <div className="mt-1 border border-gray-200 rounded-lg overflow-hidden">
+          <Editor
+            height="120px"
+            defaultLanguage="json"
+            value={graphql.variables}
+            onChange={(value) => update('variables', value || '')}
+            theme="vs"
+            options={editorOptions}
+          />
+        </div>
+      </div>
+
+      <div className="flex-shrink-0 grid grid-cols-2 gap-2">
+        <Input
+          label="Operation name"
+          value={graphql.operation_name}
+          onChange={(e) => update('operation_name', e.target.value)}
+          placeholder="Needed when the query has several operations"
+        />
+        <Input
+          label="Connection params"
+          value={graphql.connection_params}
+          onChange={(e) => update('connection_params', e.target.value)}
+          placeholder='Subscriptions only, e.g. {"token": "{{token}}"}'
+        />
+      </div>
+
+      {(graphql.query + graphql.variables).includes('{{') && (
+        <VariablePreview
+          text={graphql.query + '\n' + graphql.variables}
+          environments={environments}
+          className="flex-shrink-0 p-2 bg-blue-50 border border-blue-200 rounded-lg text-xs"
+          label="Variables:"
+        />
+      )}
+    </div>
+  );
+};
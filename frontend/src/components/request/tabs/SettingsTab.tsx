@@ -0,0 +1,215 @@
+import React from 'react';
+import { Input } from '@/components/ui';
+import { useUIStore } from '@/store';
+import { RETRY_NETWORK_ERRORS } from '@/utils';
+import type { RequestSettings, RetryNetworkError, RetryPolicy } from '@/types';
+
+const DEFAULT_RETRY: RetryPolicy = {
+  max_attempts: 3,
+  status_codes: [429, 502, 503, 504],
+  network_errors: ['connection_refused', 'connection_reset', 'timeout'],
+};
+
+const MEBIBYTE = 1 << 20;
+
+export const SettingsTab: React.FC = () => {
+  const { activeRequest, updateActiveRequestField } = useUIStore();
+
+  const settings: RequestSettings = activeRequest?.settings ?? {};
+  const retry = settings.retry;
+
+  // Status codes are kept as typed until they're parsed, so a trailing comma isn't lost
+  const [statusCodes, setStatusCodes] = React.useState(retry?.status_codes.join(', ') ?? '');
+  React.useEffect(() => {
+    setStatusCodes(activeRequest?.settings?.retry?.status_codes.join(', ') ?? '');
+  }, [activeRequest?.id]);
+
+  const updateSettings = (changes: Partial<RequestSettings>) => {
+    updateActiveRequestField('settings', { ...settings, ...changes });
+  };
+
+  const updateRetry = (changes: Partial<RetryPolicy>) => {
+    if (!retry) return;
+    updateSettings({ retry: { ...retry, ...changes } });
+  };
+
+  const toggleRetry = (enabled: boolean) => {
+    setStatusCodes(enabled ? DEFAULT_RETRY.status_codes.join(', ') : '');
+    updateSettings({ retry: enabled ? DEFAULT_RETRY : null });
+  };
+
+  const toggleNetworkError = (value: RetryNetworkError) => {
+    if (!retry) return;
+    const errors = retry.network_errors.includes(value)
+      ? retry.network_errors.filter(e => e !== value)
+      : [...retry.network_errors, value];
+    updateRetry({ network_errors: errors });
+  };
+
+  const handleStatusCodesChange = (value: string) => {
+    setStatusCodes(value);
+    updateRetry({
+      status_codes: value
+        .split(',')
+        .map(code => parseInt(code.trim()))
+        .filter(code => !isNaN(code)),
+    });
+  };
+
+  return (
+    <div className="h-full overflow-auto p-4 space-y-6">
+      <section className="space-y-3">
+        <h3 className="text-sm font-medium text-gray-900">Timeout</h3>
+        <Input
+          type="number"
+          min={0}
+          label="Timeout (ms)"
+          value={settings.timeout_ms || ''}
+          onChange={(e) => updateSettings({ timeout_ms: parseInt(e.target.value) || 0 })}
+          placeholder="30000"
+          helperText="Leave empty for the default of 30 seconds"
+        />
+      </section>
+
+      <section className="space-y-3">
+        <h3 className="text-sm font-medium text-gray-900">Redirects</h3>
+        <div className="flex items-center gap-2">
+          <input
+            type="checkbox"
+            id="settings-follow-redirects"
+            checked={!settings.disable_redirects}
+            onChange={(e) => updateSettings({ disable_redirects: !e.target.checked })}
+            className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+          />
+          <label htmlFor="settings-follow-redirects" className="text-sm text-gray-700">
+            Follow redirects
+          </label>
+        </div>
+        <div className="flex items-center gap-2">
+          <input
+            type="checkbox"
+            id="settings-rewrite-307"
+            checked={!!settings.rewrite_method_on_307}
+            onChange={(e) => updateSettings({ rewrite_method_on_307: e.target.checked })}
+            disabled={settings.disable_redirects}
+            className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+          />
+          <label htmlFor="settings-rewrite-307" className="text-sm text-gray-700">
+            Follow 307 and 308 redirects with a GET without body
+          </label>
+        </div>
+        <Input
+          type="number"
+          min={0}
+          label="Maximum redirects"
+          value={settings.max_redirects || ''}
+          onChange={(e) => updateSettings({ max_redirects: parseInt(e.target.value) || 0 })}
+          placeholder="10"
+          disabled={settings.disable_redirects}
+        />
+      </section>
+
+      <section className="space-y-3">
+        <h3 className="text-sm font-medium text-gray-900">Connection</h3>
+        <div className="flex items-center gap-2">
+          <input
+            type="checkbox"
+            id="settings-skip-tls-verify"
+            checked={!!settings.skip_tls_verify}
+            onChange={(e) => updateSettings({ skip_tls_verify: e.target.checked })}
+            className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+          />
+          <label htmlFor="settings-skip-tls-verify" className="text-sm text-gray-700">
+            Accept any server certificate, e.g. self-signed ones
+          </label>
+        </div>
+        <Input
+          label="Unix socket"
+          value={settings.unix_socket ?? ''}
+          onChange={(e) => updateSettings({ unix_socket: e.target.value })}
+          placeholder="/var/run/docker.sock"
+          helperText="Send the request through this socket instead of connecting to the host of the URL"
+          className="font-mono text-sm"
+        />
+      </section>
+
+      <section className="space-y-3">
+        <h3 className="text-sm font-medium text-gray-900">Response</h3>
+        <Input
+          type="number"
+          min={0}
+          label="Maximum response size (MiB)"
+          value={settings.max_response_bytes ? settings.max_response_bytes / MEBIBYTE : ''}
+          onChange={(e) => updateSettings({ max_response_bytes: Math.round((parseFloat(e.target.value) || 0) * MEBIBYTE) })}
+          placeholder="50"
+          helperText="Larger bodies are truncated"
+        />
+        <Input
+          label="Save the body to a file"
+          value={settings.save_to_file ?? ''}
+          onChange={(e) => updateSettings({ save_to_file: e.target.value })}
+          placeholder="response.bin"
+          helperText="Relative paths are saved in your Downloads folder"
+          className="font-mono text-sm"
+        />
+      </section>
+
+      <section className="space-y-3">
+        <h3 className="text-sm font-medium text-gray-900">Retries</h3>
+        <div className="flex items-center gap-2">
+          <input
+            type="checkbox"
+            id="settings-retry"
+            checked={!!retry}
+            onChange={(e) => toggleRetry(e.target.checked)}
+            className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+          />
+          <label htmlFor="settings-retry" className="text-sm text-gray-700">
+            Override the retry policy of the collection
+          </label>
+        </div>
+        {retry && (
+          <>
+            <div className="grid grid-cols-2 gap-3">
+              <Input
+                type="number"
+                min={1}
+                max={10}
+                label="Max attempts"
+                value={retry.max_attempts}
+                onChange={(e) => updateRetry({ max_attempts: parseInt(e.target.value) || 1 })}
+              />
+              <Input
+                label="Status codes"
+                value={statusCodes}
+                onChange={(e) => handleStatusCodesChange(e.target.value)}
+                placeholder="429, 502, 503, 504"
+                disabled={retry.max_attempts <= 1}
+              />
+            </div>
+            <div className="grid grid-cols-2 gap-2">
+              {RETRY_NETWORK_ERRORS.map(({ value, label }) => (
+                <div key={value} className="flex items-center gap-2">
+                  <input
+                    type="checkbox"
+                    id={`settings-retry-${value}`}
+                    checked={retry.network_errors.includes(value)}
+                    onChange={() => toggleNetworkError(value)}
+                    disabled={retry.max_attempts <= 1}
+                    className="h-4 w-4 text-primary-600 focus:ring-primary-500 border-gray-300 rounded"
+                  />
+                  <label htmlFor={`settings-retry-${value}`} className="text-sm text-gray-700">
+                    {label}
+                  </label>
+                </div>
+              ))}
+            </div>
+            <p className="text-xs text-gray-500">
+              Max attempts including the first one; 1 never retries this request, whatever its collection does
+            </p>
+          </>
+        )}
+      </section>
+    </div>
+  );
+};
@@ -2,16 +2,29 @@
 // This file will use the actual Wails runtime to call Go backend methods
 
 import type { 
+  Auth,
+  BodyType,
   Collection, 
   Folder, 
   Request, 
   Environment, 
   RequestHistory, 
-  APIResponse 
+  RequestSettings,
+  RetryPolicy,
+  APIResponse,
+  ExecuteOptions,
+  RequestType,
+  GRPCRequestBody,
+  GRPCService,
+  GraphQLRequestBody,
+  GraphQLSchema,
+  WebSocketSession
 } from '@/types';
 
 // Import Wails v3 bindings
 import { APIClientService } from '../../bindings/apiclient/backend/services/index.js';
+import { KeyValue, FormField, RequestAuth, RequestSettings as RequestSettingsModel, RequestSpec, RetryPolicy as RetryPolicyModel, WebSocketSpec, GRPCSpec, GraphQLBody } from '../../bindings/apiclient/backend/models/index.js';
+import { isFormBodyType, parseFormFields } from '@/utils';
 
 // Real API service using Wails
 export class APIService {
@@ -38,6 +51,12 @@ export class APIService {
     return result;
   }
 
+  async setCollectionRetryPolicy(id: number, policy?: RetryPolicy): Promise<Collection> {
+    const result = await APIClientService.SetCollectionRetryPolicy(id, policy ? new RetryPolicyModel(policy) : null);
+    if (!result) throw new Error('Failed to update collection retry policy');
+    return result;
+  }
+
   async deleteCollection(id: number): Promise<void> {
     await APIClientService.DeleteCollection(id);
   }
@@ -81,10 +100,15 @@ export class APIService {
     headers: string,
     body: string,
     collectionId?: number,
-    folderId?: number
+    folderId?: number,
+    auth?: Auth,
+    type: RequestType = 'http',
+    bodyType?: BodyType,
+    settings?: RequestSettings | null
   ): Promise<Request> {
     const result = await APIClientService.CreateRequest(
-      name, method, url, headers, body, collectionId || null, folderId || null
+      name, type, method, url, headers, body, bodyType ?? '', auth ? new RequestAuth(auth) : null,
+      settings ? new RequestSettingsModel(settings) : null, collectionId || null, folderId || null
     );
     if (!result) throw new Error('Failed to create request');
     return result;
@@ -107,10 +131,15 @@ export class APIService {
     headers: string,
     body: string,
     collectionId?: number,
-    folderId?: number
+    folderId?: number,
+    auth?: Auth,
+    type: RequestType = 'http',
+    bodyType?: BodyType,
+    settings?: RequestSettings | null
   ): Promise<Request> {
     const result = await APIClientService.UpdateRequest(
-      id, name, method, url, headers, body, collectionId || null, folderId || null
+      id, name, type, method, url, headers, body, bodyType ?? '', auth ? new RequestAuth(auth) : null,
+      settings ? new RequestSettingsModel(settings) : null, collectionId || null, folderId || null
     );
     if (!result) throw new Error('Failed to update request');
     return result;
@@ -166,10 +195,11 @@ export class APIService {
     responseStatus: number,
     responseTime: number,
     responseBody: string,
-    responseHeaders: string
+    responseHeaders: string,
+    timings: string = ''
   ): Promise<RequestHistory> {
     const result = await APIClientService.CreateRequestHistory(
-      requestId, responseStatus, responseTime, responseBody, responseHeaders
+      requestId, responseStatus, responseTime, responseBody, responseHeaders, timings
     );
     if (!result) throw new Error('Failed to create request history');
     return result;
@@ -197,23 +227,198 @@ export class APIService {
     await APIClientService.ClearRequestHistory();
   }
 
+  // Imports
+  async importWSDL(location: string, document: string, collectionId?: number, skipTLSVerify = false): Promise<Request[]> {
+    const result = await APIClientService.ImportWSDL(
+      location, document, new RequestSettingsModel({ skip_tls_verify: skipTLSVerify }), collectionId ?? null
+    );
+    return result.filter(r => r !== null) as Request[];
+  }
+
   // File operations
   async saveFileToDownloads(filename: string, content: string): Promise<string> {
     return await APIClientService.SaveFileToDownloads(filename, content);
   }
 
   // Request execution
-  async executeRequest(method: string, url: string, headers: string, body: string): Promise<APIResponse> {
-    const response = await APIClientService.ExecuteRequest(method, url, headers, body);
+  async executeRequest(method: string, url: string, headers: string, body: string, auth?: Auth, options: ExecuteOptions = {}): Promise<APIResponse> {
+    const parsedHeaders: Record<string, string> = headers ? JSON.parse(headers) : {};
+    const bodyType = options.graphql ? 'graphql' : options.bodyType ?? (body ? 'raw' : 'none');
+    const response = await APIClientService.ExecuteRequest(new RequestSpec({
+      execution_id: options.executionId ?? '',
+      method,
+      url,
+      headers: Object.entries(parsedHeaders).map(([key, value]) => new KeyValue({ key, value, enabled: true })),
+      body_type: bodyType,
+      body: bodyType === 'graphql' || isFormBodyType(bodyType) ? '' : body,
+      form_fields: isFormBodyType(bodyType) ? parseFormFields(body).map(field => new FormField(field)) : [],
+      graphql: options.graphql ? new GraphQLBody(options.graphql) : null,
+      auth: auth ? new RequestAuth(auth) : null,
+      settings: new RequestSettingsModel(options.settings ?? {}),
+      collection_id: options.collectionId ?? null,
+      request_id: options.requestId ?? null,
+    }));
+    if (!response) throw new Error('Failed to execute request');
+    // Event streams usually end by being stopped, which keeps the events received until then
+    const stoppedStream = response.outcome === 'cancelled' && response.events.length > 0;
+    if (response.outcome !== 'completed' && !stoppedStream) throw new Error(response.error);
+    return {
+      status: response.status,
+      statusText: response.status_text,
+      headers: JSON.stringify(response.headers),
+      body: response.body,
+      bodyEncoding: response.body_encoding === 'base64' ? 'base64' : 'text',
+      isBinary: response.is_binary,
+      bodySize: response.body_size,
+      truncated: response.truncated || undefined,
+      savedTo: response.saved_to || undefined,
+      responseTime: Math.round(response.timings.total),
+      timings: response.timings,
+      contentType: response.content_type,
+      events: response.events.length > 0 ? response.events : undefined,
+      trailers: response.trailers ? JSON.stringify(response.trailers) : undefined,
+      graphqlErrors: response.graphql_errors?.length ? response.graphql_errors : undefined,
+      soapFault: response.soap_fault ?? undefined,
+      unresolvedVariables: response.unresolved_variables?.length ? response.unresolved_variables : undefined,
+    };
+  }
+
+  async cancelRequest(executionId: string): Promise<void> {
+    await APIClientService.CancelRequest(executionId);
+  }
+
+  // GraphQL
+  async introspectGraphQL(url: string, headers: string, auth?: Auth, refresh = false): Promise<GraphQLSchema> {
+    const parsedHeaders: Record<string, string> = headers ? JSON.parse(headers) : {};
+    const result = await APIClientService.IntrospectGraphQL(new RequestSpec({
+      method: 'POST',
+      url,
+      headers: Object.entries(parsedHeaders).map(([key, value]) => new KeyValue({ key, value, enabled: true })),
+      auth: auth ? new RequestAuth(auth) : null,
+    }), refresh);
+    if (!result) throw new Error('Failed to fetch the GraphQL schema');
+    return result as GraphQLSchema;
+  }
+
+  async clearGraphQLSchema(endpoint: string): Promise<void> {
+    await APIClientService.ClearGraphQLSchema(endpoint);
+  }
+
+  async executeGraphQLSubscription(
+    url: string,
+    headers: string,
+    graphql: GraphQLRequestBody,
+    auth?: Auth,
+    options: ExecuteOptions = {}
+  ): Promise<APIResponse> {
+    const parsedHeaders: Record<string, string> = headers ? JSON.parse(headers) : {};
+    const response = await APIClientService.ExecuteGraphQLSubscription(new RequestSpec({
+      execution_id: options.executionId ?? '',
+      url,
+      headers: Object.entries(parsedHeaders).map(([key, value]) => new KeyValue({ key, value, enabled: true })),
+      body_type: 'graphql',
+      graphql: new GraphQLBody(graphql),
+      auth: auth ? new RequestAuth(auth) : null,
+      settings: new RequestSettingsModel(options.settings ?? {}),
+      collection_id: options.collectionId ?? null,
+      request_id: options.requestId ?? null,
+    }));
+    if (!response) throw new Error('Failed to execute subscription');
+    // Subscriptions usually end by being stopped, which keeps the events received until then
+    const stoppedStream = response.outcome === 'cancelled' && response.subscription_events.length > 0;
+    if (response.outcome !== 'completed' && !stoppedStream) throw new Error(response.error);
+    return {
+      status: response.status,
+      statusText: response.status_text,
+      headers: JSON.stringify(response.headers ?? {}),
+      body: response.body,
+      responseTime: Math.round(response.timings.total),
+      timings: response.timings,
+      contentType: response.content_type,
+      graphqlErrors: response.graphql_errors?.length ? response.graphql_errors : undefined,
+      subscriptionEvents: response.subscription_events,
+      unresolvedVariables: response.unresolved_variables?.length ? response.unresolved_variables : undefined,
+    };
+  }
+
+  // gRPC
+  async listGRPCServices(target: string, metadata: string, settings: GRPCRequestBody): Promise<GRPCService[]> {
+    return await APIClientService.ListGRPCServices(this.grpcSpec(target, metadata, settings));
+  }
+
+  async executeGRPC(
+    target: string,
+    metadata: string,
+    settings: GRPCRequestBody,
+    options: ExecuteOptions = {}
+  ): Promise<APIResponse> {
+    const response = await APIClientService.ExecuteGRPC(new GRPCSpec({
+      ...this.grpcSpec(target, metadata, settings),
+      execution_id: options.executionId ?? '',
+      request_id: options.requestId ?? null,
+    }));
+    if (!response) throw new Error('Failed to execute gRPC call');
+    // Server streams usually end by being stopped, which keeps the messages received until then
+    const stoppedStream = response.outcome === 'cancelled' && response.messages.length > 0;
+    if (response.outcome !== 'completed' && !stoppedStream) throw new Error(response.error);
     return {
       status: response.status,
-      statusText: response.statusText,
-      headers: response.headers,
+      statusText: response.status_text,
+      headers: JSON.stringify(response.headers ?? {}),
       body: response.body,
-      responseTime: response.responseTime,
-      contentType: response.contentType,
+      responseTime: Math.round(response.timings.total),
+      timings: response.timings,
+      contentType: response.content_type,
+      trailers: JSON.stringify(response.trailers ?? {}),
+      messages: response.messages,
+      grpcStatus: response.grpc_status ?? undefined,
     };
   }
+
+  private grpcSpec(target: string, metadata: string, settings: GRPCRequestBody): GRPCSpec {
+    const parsedMetadata: Record<string, string> = metadata ? JSON.parse(metadata) : {};
+    return new GRPCSpec({
+      target,
+      method: settings.method,
+      messages: settings.messages,
+      metadata: Object.entries(parsedMetadata).map(([key, value]) => new KeyValue({ key, value, enabled: true })),
+      proto_files: settings.proto_files,
+      import_paths: settings.import_paths,
+      tls: settings.tls,
+    });
+  }
+
+  // WebSockets
+  async connectWebSocket(
+    sessionId: string,
+    url: string,
+    headers: string,
+    subprotocols: string[],
+    requestId?: number
+  ): Promise<WebSocketSession> {
+    const parsedHeaders: Record<string, string> = headers ? JSON.parse(headers) : {};
+    const result = await APIClientService.ConnectWebSocket(new WebSocketSpec({
+      session_id: sessionId,
+      url,
+      headers: Object.entries(parsedHeaders).map(([key, value]) => new KeyValue({ key, value, enabled: true })),
+      subprotocols,
+      request_id: requestId || null,
+    }));
+    if (!result) throw new Error('Failed to connect');
+    return result;
+  }
+
+  async sendWebSocketMessage(sessionId: string, type: 'text' | 'binary', data: string): Promise<void> {
+    await APIClientService.SendWebSocketMessage(sessionId, type, data);
+  }
+
+  async pingWebSocket(sessionId: string, data: string = ''): Promise<void> {
+    await APIClientService.PingWebSocket(sessionId, data);
+  }
+
+  async closeWebSocket(sessionId: string, code: number = 1000, reason: string = ''): Promise<void> {
+    await APIClientService.CloseWebSocket(sessionId, code, reason);
+  }
 }
 
 // Export singleton instance
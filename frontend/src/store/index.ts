@@ -1,12 +1,18 @@
 import { create } from 'zustand';
 import { devtools, persist } from 'zustand/middleware';
 import type { 
+  Auth,
+  BodyType,
   Collection, 
   Folder, 
   Request, 
   Environment, 
   RequestHistory, 
+  RequestSettings,
+  RequestType,
+  RetryPolicy,
   APIResponse,
+  ExecuteOptions,
   CollectionTreeItem,
   KeyValue,
   HTTPMethod,
@@ -14,6 +20,11 @@ import type {
   RequestTab,
   CollectionTab,
   TabsState,
+  GRPCRequestBody,
+  GraphQLRequestBody,
+  GraphQLSchema,
+  GRPCService,
+  WebSocketSession,
 } from '@/types';
 import { generateId, parseHeaders, serializeHeaders } from '@/utils';
 import { apiService } from '@/services/api';
@@ -29,6 +40,7 @@ interface APIServiceState {
   // Actions
   createCollection: (name: string, description: string, environmentId?: number) => Promise<Collection>;
   updateCollection: (id: number, name: string, description: string, environmentId?: number) => Promise<Collection>;
+  setCollectionRetryPolicy: (id: number, policy?: RetryPolicy) => Promise<Collection>;
   deleteCollection: (id: number) => Promise<void>;
   
   createFolder: (name: string, collectionId: number, parentFolderId?: number) => Promise<Folder>;
@@ -42,7 +54,11 @@ interface APIServiceState {
     headers: string, 
     body: string, 
     collectionId?: number, 
-    folderId?: number
+    folderId?: number,
+    auth?: Auth,
+    type?: RequestType,
+    bodyType?: BodyType,
+    settings?: RequestSettings | null
   ) => Promise<Request>;
   updateRequest: (
     id: number,
@@ -52,7 +68,11 @@ interface APIServiceState {
     headers: string, 
     body: string, 
     collectionId?: number, 
-    folderId?: number
+    folderId?: number,
+    auth?: Auth,
+    type?: RequestType,
+    bodyType?: BodyType,
+    settings?: RequestSettings | null
   ) => Promise<Request>;
   deleteRequest: (id: number) => Promise<void>;
   
@@ -64,7 +84,20 @@ interface APIServiceState {
   updateEnvironment: (id: number, name: string, variables: string, isActive: boolean) => Promise<Environment>;
   deleteEnvironment: (id: number) => Promise<void>;
   
-  executeRequest: (method: HTTPMethod, url: string, headers: string, body: string) => Promise<APIResponse>;
+  executeRequest: (method: HTTPMethod, url: string, headers: string, body: string, auth?: Auth, options?: ExecuteOptions) => Promise<APIResponse>;
+  cancelRequest: (executionId: string) => Promise<void>;
+
+  importWSDL: (location: string, document: string, collectionId?: number, skipTLSVerify?: boolean) => Promise<Request[]>;
+  introspectGraphQL: (url: string, headers: string, auth?: Auth, refresh?: boolean) => Promise<GraphQLSchema>;
+  clearGraphQLSchema: (endpoint: string) => Promise<void>;
+  executeGraphQLSubscription: (url: string, headers: string, graphql: GraphQLRequestBody, auth?: Auth, options?: ExecuteOptions) => Promise<APIResponse>;
+  listGRPCServices: (target: string, metadata: string, settings: GRPCRequestBody) => Promise<GRPCService[]>;
+  executeGRPC: (target: string, metadata: string, settings: GRPCRequestBody, options?: ExecuteOptions) => Promise<APIResponse>;
+
+  connectWebSocket: (sessionId: string, url: string, headers: string, subprotocols: string[], requestId?: number) => Promise<WebSocketSession>;
+  sendWebSocketMessage: (sessionId: string, type: 'text' | 'binary', data: string) => Promise<void>;
+  pingWebSocket: (sessionId: string, data?: string) => Promise<void>;
+  closeWebSocket: (sessionId: string, code?: number, reason?: string) => Promise<void>;
   
   // Data fetchers
   fetchCollections: () => Promise<void>;
@@ -93,7 +126,7 @@ interface UIState {
   // Request Builder
   activeRequest?: Request;
   unsavedChanges: boolean;
-  activeTab: 'params' | 'auth' | 'headers' | 'body' | 'tests';
+  activeTab: 'params' | 'auth' | 'headers' | 'body' | 'settings' | 'tests';
   
   // Response Viewer
   lastResponse?: APIResponse;
@@ -113,7 +146,7 @@ interface UIState {
   
   setActiveRequest: (request?: Request) => void;
   setUnsavedChanges: (hasChanges: boolean) => void;
-  setActiveTab: (tab: 'params' | 'auth' | 'headers' | 'body' | 'tests') => void;
+  setActiveTab: (tab: 'params' | 'auth' | 'headers' | 'body' | 'settings' | 'tests') => void;
   
   setLastResponse: (response?: APIResponse) => void;
   setResponseTab: (tab: 'body' | 'headers' | 'test-results') => void;
@@ -153,6 +186,14 @@ export const useAPIStore = create<APIServiceState>()(
         return collection;
       },
       
+      async setCollectionRetryPolicy(id: number, policy?: RetryPolicy) {
+        const collection = await apiService.setCollectionRetryPolicy(id, policy);
+        set(state => ({
+          collections: state.collections.map(c => c.id === id ? collection : c)
+        }));
+        return collection;
+      },
+      
       async deleteCollection(id: number) {
         await apiService.deleteCollection(id);
         set(state => ({ collections: state.collections.filter(c => c.id !== id) }));
@@ -177,14 +218,14 @@ export const useAPIStore = create<APIServiceState>()(
         set(state => ({ folders: state.folders.filter(f => f.id !== id) }));
       },
       
-      async createRequest(name: string, method: HTTPMethod, url: string, headers: string, body: string, collectionId?: number, folderId?: number) {
-        const request = await apiService.createRequest(name, method, url, headers, body, collectionId, folderId);
+      async createRequest(name: string, method: HTTPMethod, url: string, headers: string, body: string, collectionId?: number, folderId?: number, auth?: Auth, type?: RequestType, bodyType?: BodyType, settings?: RequestSettings | null) {
+        const request = await apiService.createRequest(name, method, url, headers, body, collectionId, folderId, auth, type, bodyType, settings);
         set(state => ({ requests: [...state.requests, request] }));
         return request;
       },
       
-      async updateRequest(id: number, name: string, method: HTTPMethod, url: string, headers: string, body: string, collectionId?: number, folderId?: number) {
-        const request = await apiService.updateRequest(id, name, method, url, headers, body, collectionId, folderId);
+      async updateRequest(id: number, name: string, method: HTTPMethod, url: string, headers: string, body: string, collectionId?: number, folderId?: number, auth?: Auth, type?: RequestType, bodyType?: BodyType, settings?: RequestSettings | null) {
+        const request = await apiService.updateRequest(id, name, method, url, headers, body, collectionId, folderId, auth, type, bodyType, settings);
         set(state => ({
           requests: state.requests.map(r => r.id === id ? request : r)
         }));
@@ -210,7 +251,11 @@ export const useAPIStore = create<APIServiceState>()(
             request.headers,
             request.body,
             newCollectionId,
-            newFolderId
+            newFolderId,
+            request.auth,
+            request.type,
+            request.body_type,
+            request.settings
           );
 
           // Update local state
@@ -276,8 +321,55 @@ export const useAPIStore = create<APIServiceState>()(
         set(state => ({ environments: state.environments.filter(e => e.id !== id) }));
       },
       
-      async executeRequest(method: HTTPMethod, url: string, headers: string, body: string) {
-        return await apiService.executeRequest(method, url, headers, body);
+      async executeRequest(method: HTTPMethod, url: string, headers: string, body: string, auth?: Auth, options?: ExecuteOptions) {
+        return await apiService.executeRequest(method, url, headers, body, auth, options);
+      },
+      
+      async cancelRequest(executionId: string) {
+        await apiService.cancelRequest(executionId);
+      },
+
+      async importWSDL(location: string, document: string, collectionId?: number, skipTLSVerify?: boolean) {
+        const requests = await apiService.importWSDL(location, document, collectionId, skipTLSVerify);
+        // The import may create a collection and folders besides the requests
+        await Promise.all([get().fetchCollections(), get().fetchFolders(), get().fetchRequests()]);
+        return requests;
+      },
+
+      async introspectGraphQL(url: string, headers: string, auth?: Auth, refresh?: boolean) {
+        return await apiService.introspectGraphQL(url, headers, auth, refresh);
+      },
+
+      async clearGraphQLSchema(endpoint: string) {
+        await apiService.clearGraphQLSchema(endpoint);
+      },
+
+      async executeGraphQLSubscription(url: string, headers: string, graphql: GraphQLRequestBody, auth?: Auth, options?: ExecuteOptions) {
+        return await apiService.executeGraphQLSubscription(url, headers, graphql, auth, options);
+      },
+
+      async listGRPCServices(target: string, metadata: string, settings: GRPCRequestBody) {
+        return await apiService.listGRPCServices(target, metadata, settings);
+      },
+
+      async executeGRPC(target: string, metadata: string, settings: GRPCRequestBody, options?: ExecuteOptions) {
+        return await apiService.executeGRPC(target, metadata, settings, options);
+      },
+
+      async connectWebSocket(sessionId: string, url: string, headers: string, subprotocols: string[], requestId?: number) {
+        return await apiService.connectWebSocket(sessionId, url, headers, subprotocols, requestId);
+      },
+
+      async sendWebSocketMessage(sessionId: string, type: 'text' | 'binary', data: string) {
+        await apiService.sendWebSocketMessage(sessionId, type, data);
+      },
+
+      async pingWebSocket(sessionId: string, data?: string) {
+        await apiService.pingWebSocket(sessionId, data);
+      },
+
+      async closeWebSocket(sessionId: string, code?: number, reason?: string) {
+        await apiService.closeWebSocket(sessionId, code, reason);
       },
       
       async fetchCollections() {
@@ -364,6 +456,7 @@ export const useAPIStore = create<APIServiceState>()(
                 type: 'request',
                 collection_id: request.collection_id,
                 method: request.method,
+                request_type: request.type,
               });
             });
           }
@@ -399,6 +492,7 @@ export const useAPIStore = create<APIServiceState>()(
               type: 'request',
               collection_id: request.collection_id,
               method: request.method,
+              request_type: request.type,
             });
           });
           
@@ -2,7 +2,51 @@
 export type HTTPMethod = 'GET' | 'POST' | 'PUT' | 'DELETE' | 'PATCH' | 'HEAD' | 'OPTIONS';
 
 // Auth Types
-export type AuthType = 'none' | 'bearer' | 'basic' | 'api-key';
+export type AuthType = 'none' | 'bearer' | 'basic' | 'digest' | 'api-key' | 'oauth1' | 'oauth2' | 'aws-sigv4' | 'jwt';
+
+export interface OAuth1Config {
+  consumer_key: string;
+  consumer_secret?: string;
+  token?: string;
+  token_secret?: string;
+  signature_method: 'HMAC-SHA1' | 'RSA-SHA1' | 'PLAINTEXT';
+  private_key?: string;
+  realm?: string;
+  add_to: 'header' | 'query' | 'body';
+}
+
+export type OAuth2GrantType = 'client_credentials' | 'password' | 'authorization_code';
+
+export interface OAuth2Config {
+  grant_type: OAuth2GrantType;
+  token_url: string;
+  auth_url?: string;
+  client_id: string;
+  client_secret?: string;
+  scope?: string;
+  username?: string;
+  password?: string;
+  redirect_port?: number;
+  client_auth?: '' | 'header' | 'body';
+}
+
+export interface JWTConfig {
+  algorithm: 'HS256' | 'HS384' | 'HS512' | 'RS256' | 'ES256';
+  secret?: string;
+  private_key?: string;
+  key_id?: string;
+  claims: string;
+  expires_in?: number;
+  issued_at_offset?: number;
+}
+
+export interface AWSConfig {
+  access_key: string;
+  secret_key: string;
+  session_token?: string;
+  region: string;
+  service: string;
+}
 
 export interface Auth {
   type: AuthType;
@@ -11,11 +55,15 @@ export interface Auth {
   password?: string;
   key?: string;
   value?: string;
-  addTo?: 'header' | 'query';
+  add_to?: 'header' | 'query';
+  oauth1?: OAuth1Config;
+  oauth2?: OAuth2Config;
+  aws?: AWSConfig;
+  jwt?: JWTConfig;
 }
 
 // Request Body Types
-export type BodyType = 'none' | 'json' | 'xml' | 'form-data' | 'x-www-form-urlencoded' | 'raw' | 'binary';
+export type BodyType = 'none' | 'json' | 'xml' | 'form-data' | 'x-www-form-urlencoded' | 'raw' | 'binary' | 'graphql';
 
 export interface KeyValue {
   id: string;
@@ -30,12 +78,38 @@ export interface FormDataItem extends KeyValue {
   file?: File;
 }
 
+// Retry Policy
+export type RetryNetworkError = 'connection_refused' | 'connection_reset' | 'dns' | 'timeout' | 'eof';
+
+export interface RetryPolicy {
+  max_attempts: number;
+  status_codes: number[];
+  network_errors: RetryNetworkError[];
+  initial_delay_ms?: number;
+  max_delay_ms?: number;
+  ignore_retry_after?: boolean;
+}
+
+// Per-request execution options
+export interface RequestSettings {
+  timeout_ms?: number; // 0 uses the default timeout of 30 seconds
+  disable_redirects?: boolean;
+  max_redirects?: number; // 0 uses the default of 10 hops
+  rewrite_method_on_307?: boolean; // follow 307/308 with a GET without body
+  skip_tls_verify?: boolean;
+  unix_socket?: string; // e.g. /var/run/docker.sock
+  max_response_bytes?: number; // 0 keeps up to 50 MiB of the body in memory
+  save_to_file?: string; // relative paths are saved in Downloads
+  retry?: RetryPolicy | null; // overrides the retry policy of the collection
+}
+
 // Core Data Models
 export interface Collection {
   id: number;
   name: string;
   description: string;
   environment_id?: number; // Linked environment
+  retry_policy?: RetryPolicy | null;
   created_at: string;
   updated_at: string;
 }
@@ -48,13 +122,17 @@ export interface Folder {
   created_at: string;
 }
 
+export type RequestType = 'http' | 'websocket' | 'grpc';
+
 export interface Request {
   id: number;
   name: string;
+  type?: RequestType;
   method: HTTPMethod;
   url: string;
   headers: string; // JSON string
   body: string;
+  body_type?: BodyType; // empty for requests saved before it was kept
   collection_id?: number;
   folder_id?: number;
   created_at: string;
@@ -63,8 +141,8 @@ export interface Request {
   parsedHeaders?: KeyValue[];
   parsedBody?: any;
   auth?: Auth;
+  settings?: RequestSettings | null; // null uses the defaults
   params?: KeyValue[];
-  bodyType?: BodyType;
 }
 
 export interface Environment {
@@ -84,17 +162,181 @@ export interface RequestHistory {
   response_time: number;
   response_body: string;
   response_headers: string; // JSON string
+  timings: string; // JSON string
+  transcript: string; // JSON string, messages of a streamed session
   executed_at: string;
 }
 
 // Response Types
+export interface ServerSentEvent {
+  execution_id: string;
+  event: string;
+  id: string;
+  data: string;
+  retry: number;
+  received_at: string;
+}
+
 export interface APIResponse {
   status: number;
   statusText: string;
   headers: string;
-  body: string;
+  body: string; // base64 when bodyEncoding is base64
+  bodyEncoding?: 'text' | 'base64'; // base64 for binary bodies
+  isBinary?: boolean; // also set for binary bodies saved to a file
+  bodySize?: number; // bytes received, or written to savedTo
+  truncated?: boolean; // the body exceeded the maximum response size and was cut
+  savedTo?: string; // file the body was streamed to instead of being returned
   responseTime: number;
+  timings?: ExecutionTimings;
   contentType: string;
+  events?: ServerSentEvent[]; // text/event-stream responses
+  trailers?: string; // JSON string
+  messages?: GRPCMessage[]; // transcript of gRPC calls
+  grpcStatus?: GRPCStatus; // status gRPC calls ended with, while status is the HTTP one
+  graphqlErrors?: GraphQLError[]; // errors of GraphQL responses, which usually come with a 200 status
+  subscriptionEvents?: GraphQLSubscriptionEvent[]; // transcript of GraphQL subscriptions
+  soapFault?: SOAPFault;
+  unresolvedVariables?: string[]; // {{variables}} sent as is, as no environment defines them
+}
+
+// Phases of an execution, in milliseconds
+export interface ExecutionTimings {
+  dns_lookup: number;
+  tcp_connect: number;
+  tls_handshake: number;
+  first_byte: number; // from the request being written to the first response byte
+  download: number;
+  total: number;
+}
+
+// Published as execution:progress events while bodies are sent and received
+export interface TransferProgress {
+  execution_id: string;
+  direction: 'upload' | 'download';
+  bytes: number;
+  total: number; // -1 when unknown
+  bytes_per_second: number;
+  done: boolean;
+}
+
+export interface SOAPFault {
+  version: '1.1' | '1.2';
+  code: string;
+  subcodes: string[] | null; // SOAP 1.2 only
+  reason: string;
+  actor: string; // faultactor, or the SOAP 1.2 role
+  node: string;
+  detail: string; // XML
+}
+
+// GraphQL bodies are saved as this JSON in the request body
+export interface FormField {
+  key: string;
+  value: string;
+  type: 'text' | 'file';
+  file_path: string; // file to upload, for file fields
+  content_type: string; // of the file, guessed from its extension when empty
+  enabled: boolean;
+}
+
+export interface GraphQLRequestBody {
+  query: string;
+  variables: string; // JSON object, may be empty
+  operation_name: string;
+  connection_params: string; // JSON payload sent when subscriptions connect
+}
+
+export interface GraphQLError {
+  message: string;
+  locations?: { line: number; column: number }[];
+  path?: (string | number)[];
+  extensions?: Record<string, any>;
+}
+
+export interface GraphQLSubscriptionEvent {
+  execution_id: string;
+  type: 'next' | 'error' | 'complete';
+  payload: string; // JSON
+  received_at: string;
+}
+
+export interface GraphQLSchema {
+  id: number;
+  endpoint: string;
+  schema: string; // JSON data of the introspection query
+  fetched_at: string;
+}
+
+export interface GRPCMethod {
+  name: string;
+  full_name: string; // package.Service/Method
+  client_streaming: boolean;
+  server_streaming: boolean;
+  input_type: string;
+  output_type: string;
+  input_template: string;
+}
+
+export interface GRPCService {
+  name: string;
+  methods: GRPCMethod[];
+}
+
+export interface GRPCStatus {
+  code: number;
+  name: string; // e.g. NotFound
+  message: string;
+}
+
+export interface GRPCMessage {
+  execution_id: string;
+  direction: 'sent' | 'received';
+  data: string; // JSON
+  time: string;
+}
+
+// Saved gRPC requests keep their target in url, their metadata in headers and these settings in body
+export interface GRPCRequestBody {
+  method: string; // package.Service/Method
+  messages: string[];
+  proto_files: string[];
+  import_paths: string[];
+  tls: boolean;
+}
+
+export interface WebSocketSession {
+  session_id: string;
+  url: string;
+  subprotocol: string;
+  status: number;
+  headers: Record<string, string[]>;
+  unresolved_variables: string[];
+}
+
+export interface WebSocketMessage {
+  session_id: string;
+  direction: 'sent' | 'received';
+  type: 'text' | 'binary' | 'ping' | 'pong' | 'close';
+  data: string; // base64 for binary messages
+  close_code: number;
+  time: string;
+}
+
+export interface WebSocketClosed {
+  session_id: string;
+  code: number;
+  reason: string;
+  error: string;
+}
+
+export interface ExecuteOptions {
+  executionId?: string; // lets the execution be stopped with cancelRequest
+  collectionId?: number;
+  requestId?: number;
+  graphql?: GraphQLRequestBody; // sends the body as a GraphQL operation
+  bodyType?: BodyType; // form bodies hold their fields as JSON
+  settings?: RequestSettings | null;
 }
 
 // UI State Types
@@ -102,7 +344,7 @@ export interface RequestBuilderState {
   activeRequest?: Request;
   isLoading: boolean;
   lastResponse?: APIResponse;
-  activeTab: 'params' | 'auth' | 'headers' | 'body' | 'tests';
+  activeTab: 'params' | 'auth' | 'headers' | 'body' | 'settings' | 'tests';
   bodyTab: 'pretty' | 'raw' | 'preview';
 }
 
@@ -141,6 +383,7 @@ export interface CollectionTreeItem {
   collection_id?: number;
   parent_folder_id?: number;
   method?: HTTPMethod;
+  request_type?: RequestType;
 }
 
 // Import/Export Types
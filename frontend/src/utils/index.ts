@@ -1,6 +1,6 @@
 import { type ClassValue, clsx } from 'clsx';
 import { nanoid } from 'nanoid';
-import type { KeyValue, HTTPMethod } from '@/types';
+import type { KeyValue, HTTPMethod, GraphQLRequestBody, BodyType, FormField, Request, RetryNetworkError } from '@/types';
 
 // Utility for merging class names
 export function cn(...inputs: ClassValue[]) {
@@ -29,7 +29,55 @@ export function safeStringifyJSON(obj: any): string {
   }
 }
 
+// Body utilities
+
+// Requests saved before their body type was kept send their body, if any, as raw text
+export function requestBodyType(request: Pick<Request, 'body' | 'body_type'>): BodyType {
+  return request.body_type || (request.body ? 'raw' : 'none');
+}
+
+export function isFormBodyType(bodyType: BodyType): bodyType is 'form-data' | 'x-www-form-urlencoded' {
+  return bodyType === 'form-data' || bodyType === 'x-www-form-urlencoded';
+}
+
+// The body of form requests keeps their fields as JSON
+export function parseFormFields(body: string): FormField[] {
+  const parsed = safeParseJSON<unknown>(body, []);
+  if (!Array.isArray(parsed)) return [];
+  return parsed
+    .filter(field => field && typeof field === 'object')
+    .map(field => ({ key: '', value: '', type: 'text', file_path: '', content_type: '', enabled: true, ...field }));
+}
+
+// GraphQL utilities
+
+// The body of GraphQL requests keeps their operation as JSON
+export function parseGraphQLBody(body: string): GraphQLRequestBody {
+  const parsed = safeParseJSON<Record<string, unknown>>(body, {});
+  const field = (key: keyof GraphQLRequestBody) => typeof parsed[key] === 'string' ? parsed[key] as string : '';
+  return {
+    query: field('query'),
+    variables: field('variables'),
+    operation_name: field('operation_name'),
+    connection_params: field('connection_params'),
+  };
+}
+
+export function isGraphQLSubscription(query: string): boolean {
+  // Skip comments before the operation
+  return /^subscription\b/.test(query.replace(/#.*$/gm, '').trim());
+}
+
 // Key-Value utilities
+// Network errors a retry policy can retry on
+export const RETRY_NETWORK_ERRORS: { value: RetryNetworkError; label: string }[] = [
+  { value: 'connection_refused', label: 'Connection refused' },
+  { value: 'connection_reset', label: 'Connection reset' },
+  { value: 'dns', label: 'DNS failure' },
+  { value: 'timeout', label: 'Timeout' },
+  { value: 'eof', label: 'Connection closed' },
+];
+
 export function parseHeaders(headersString: string): KeyValue[] {
   const headers = safeParseJSON<Record<string, string>>(headersString, {});
   return Object.entries(headers).map(([key, value]) => ({
@@ -108,28 +156,6 @@ export function getActiveEnvironmentVariables(environments: any[]): Record<strin
   return parseEnvironmentVariables(activeEnv.variables);
 }
 
-// Apply environment variables to request data
-export function applyEnvironmentVariables(
-  requestData: {
-    url?: string;
-    headers?: string;
-    body?: string;
-  },
-  environments: any[]
-): {
-  url: string;
-  headers: string;
-  body: string;
-} {
-  const variables = getActiveEnvironmentVariables(environments);
-  
-  return {
-    url: substituteVariables(requestData.url || '', variables),
-    headers: substituteVariables(requestData.headers || '', variables),
-    body: substituteVariables(requestData.body || '', variables),
-  };
-}
-
 // HTTP method utilities
 export function getMethodColor(method: HTTPMethod): string {
   const colors = {
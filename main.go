@@ -26,6 +26,11 @@ func main() {
 	// Initialize the database
 	database.InitDB()
 
+	// The API client service publishes progress and streaming events through the application
+	apiClientService := services.NewAPIClientService(func(name string, data ...any) {
+		application.Get().Event.Emit(name, data...)
+	})
+
 	// Create a new Wails application by providing the necessary options.
 	// Variables 'Name' and 'Description' are for application metadata.
 	// 'Assets' configures the asset server with the 'FS' variable pointing to the frontend files.
@@ -35,7 +40,7 @@ func main() {
 		Name:        "API Client",
 		Description: "A powerful API client for testing and debugging APIs",
 		Services: []application.Service{
-			application.NewService(&services.APIClientService{}),
+			application.NewService(apiClientService),
 		},
 		Assets: application.AssetOptions{
 			Handler: application.AssetFileServerFS(assets),